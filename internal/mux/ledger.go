@@ -0,0 +1,203 @@
+package mux
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"mondaynightpoker-server/pkg/model"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/sirupsen/logrus"
+)
+
+const ledgerDateFormat = "2006-01-02"
+
+var ledgerCSVHeader = []string{
+	"id",
+	"created",
+	"table_uuid",
+	"table",
+	"player_id",
+	"player",
+	"game_id",
+	"game_type",
+	"adjustment",
+	"previous_balance",
+	"current_balance",
+	"reason",
+}
+
+// getTableUUIDLedger exports every change to the balances of the players at the table
+// note: this requires a table admin
+func (m *Mux) getTableUUIDLedger() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !tableMember(w, r, true) {
+			return
+		}
+
+		ledgerRange, format, ok := parseLedgerOptions(w, r)
+		if !ok {
+			return
+		}
+
+		tbl := r.Context().Value(ctxTableKey).(*model.Table)
+		entries, err := tbl.GetLedger(r.Context(), ledgerRange)
+		if err != nil {
+			writeJSONError(w, http.StatusInternalServerError, err)
+			return
+		}
+
+		writeLedger(w, format, fmt.Sprintf("ledger-%s", tbl.UUID), entries)
+	})
+}
+
+// getPlayerIDLedger exports every change to the player's balances at all of their tables
+// note: a player can only export their own ledger unless they are a site admin
+func (m *Mux) getPlayerIDLedger() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// ParseInt will always succeed
+		playerID, _ := strconv.ParseInt(mux.Vars(r)["id"], 10, 64)
+
+		player := r.Context().Value(ctxPlayerKey).(*model.Player)
+		if player.ID != playerID && !player.IsSiteAdmin {
+			writeJSONError(w, http.StatusForbidden, nil)
+			return
+		}
+
+		ledgerRange, format, ok := parseLedgerOptions(w, r)
+		if !ok {
+			return
+		}
+
+		if player.ID != playerID {
+			var err error
+			if player, err = model.GetPlayerByID(r.Context(), playerID); err != nil {
+				writeMaybeNotFoundError(w, err)
+				return
+			}
+		}
+
+		entries, err := player.GetLedger(r.Context(), ledgerRange)
+		if err != nil {
+			writeJSONError(w, http.StatusInternalServerError, err)
+			return
+		}
+
+		writeLedger(w, format, fmt.Sprintf("ledger-player-%d", playerID), entries)
+	})
+}
+
+// parseLedgerOptions parses the date range and the format of a ledger export
+// from and to are dates (2006-01-02) or times (RFC 3339). A date in to includes the whole day
+func parseLedgerOptions(w http.ResponseWriter, r *http.Request) (model.LedgerRange, string, bool) {
+	var ledgerRange model.LedgerRange
+
+	format := r.FormValue("format")
+	if format == "" {
+		format = "json"
+	}
+
+	if format != "json" && format != "csv" {
+		writeJSONError(w, http.StatusBadRequest, errors.New("format must be json or csv"))
+		return ledgerRange, "", false
+	}
+
+	if from := r.FormValue("from"); from != "" {
+		t, _, err := parseLedgerTime(from)
+		if err != nil {
+			writeJSONError(w, http.StatusBadRequest, fmt.Errorf("from: %w", err))
+			return ledgerRange, "", false
+		}
+
+		ledgerRange.From = t
+	}
+
+	if to := r.FormValue("to"); to != "" {
+		t, isDate, err := parseLedgerTime(to)
+		if err != nil {
+			writeJSONError(w, http.StatusBadRequest, fmt.Errorf("to: %w", err))
+			return ledgerRange, "", false
+		}
+
+		if isDate {
+			t = t.AddDate(0, 0, 1)
+		}
+
+		ledgerRange.To = t
+	}
+
+	if !ledgerRange.From.IsZero() && !ledgerRange.To.IsZero() && !ledgerRange.To.After(ledgerRange.From) {
+		writeJSONError(w, http.StatusBadRequest, errors.New("to must be after from"))
+		return ledgerRange, "", false
+	}
+
+	return ledgerRange, format, true
+}
+
+func parseLedgerTime(value string) (t time.Time, isDate bool, err error) {
+	if t, err := time.Parse(ledgerDateFormat, value); err == nil {
+		return t, true, nil
+	}
+
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, false, nil
+	}
+
+	return time.Time{}, false, fmt.Errorf("%q is not a date (YYYY-MM-DD) or time (RFC 3339)", value)
+}
+
+// csvText keeps a value that was entered by a user from running as a formula when the CSV is opened
+// in a spreadsheet, by prefixing it with a quote
+func csvText(value string) string {
+	if value != "" && strings.ContainsRune("=+-@\t\r", rune(value[0])) {
+		return "'" + value
+	}
+
+	return value
+}
+
+// writeLedger writes the entries as JSON or as a CSV download
+// The CSV amounts are in dollars, i.e., 1.25
+func writeLedger(w http.ResponseWriter, format string, filename string, entries []*model.LedgerEntry) {
+	if format == "json" {
+		writeJSON(w, http.StatusOK, entries)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="%s.csv"`, filename))
+	w.WriteHeader(http.StatusOK)
+
+	cw := csv.NewWriter(w)
+	_ = cw.Write(ledgerCSVHeader)
+	for _, e := range entries {
+		gameID := ""
+		if e.GameID > 0 {
+			gameID = strconv.FormatInt(e.GameID, 10)
+		}
+
+		_ = cw.Write([]string{
+			strconv.FormatInt(e.ID, 10),
+			e.Created.UTC().Format(time.RFC3339),
+			e.TableUUID,
+			csvText(e.TableName),
+			strconv.FormatInt(e.PlayerID, 10),
+			csvText(e.PlayerName),
+			gameID,
+			csvText(e.GameType),
+			formatDecimal(e.Adjustment),
+			formatDecimal(e.PreviousBalance),
+			formatDecimal(e.CurrentBalance),
+			csvText(e.Reason),
+		})
+	}
+
+	cw.Flush()
+	if err := cw.Error(); err != nil {
+		logrus.WithError(err).Error("could not write the ledger")
+	}
+}
@@ -0,0 +1,129 @@
+package mux
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"mondaynightpoker-server/pkg/model"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_parseLedgerOptions(t *testing.T) {
+	a := assert.New(t)
+
+	parse := func(queryString string) (model.LedgerRange, string, int) {
+		req, _ := http.NewRequest(http.MethodGet, "https://example.domain/"+queryString, nil)
+		w := httptest.NewRecorder()
+		ledgerRange, format, _ := parseLedgerOptions(w, req)
+		return ledgerRange, format, w.Code
+	}
+
+	ledgerRange, format, code := parse("")
+	a.Equal(http.StatusOK, code)
+	a.Equal("json", format)
+	a.True(ledgerRange.From.IsZero())
+	a.True(ledgerRange.To.IsZero())
+
+	// a date in to includes the whole day
+	ledgerRange, format, code = parse("?format=csv&from=2020-03-01&to=2020-03-31")
+	a.Equal(http.StatusOK, code)
+	a.Equal("csv", format)
+	a.Equal(time.Date(2020, 3, 1, 0, 0, 0, 0, time.UTC), ledgerRange.From)
+	a.Equal(time.Date(2020, 4, 1, 0, 0, 0, 0, time.UTC), ledgerRange.To)
+
+	ledgerRange, _, code = parse("?to=2020-03-31T20:00:00Z")
+	a.Equal(http.StatusOK, code)
+	a.Equal(time.Date(2020, 3, 31, 20, 0, 0, 0, time.UTC), ledgerRange.To)
+
+	_, _, code = parse("?format=xml")
+	a.Equal(http.StatusBadRequest, code)
+
+	_, _, code = parse("?from=03/01/2020")
+	a.Equal(http.StatusBadRequest, code)
+
+	_, _, code = parse("?from=2020-03-31&to=2020-03-01")
+	a.Equal(http.StatusBadRequest, code)
+}
+
+func Test_writeLedger_csvFormulas(t *testing.T) {
+	a := assert.New(t)
+
+	w := httptest.NewRecorder()
+	writeLedger(w, "csv", "ledger", []*model.LedgerEntry{{
+		ID:         1,
+		TableName:  "+Friday",
+		PlayerName: "=HYPERLINK(\"https://example.domain\")",
+		GameType:   "bourre",
+		Adjustment: -125,
+		Reason:     "@admin",
+	}})
+
+	a.Equal(http.StatusOK, w.Code)
+	lines := strings.Split(strings.TrimSpace(w.Body.String()), "\n")
+	if a.Equal(2, len(lines)) {
+		a.Contains(lines[1], ",'+Friday,")
+		a.Contains(lines[1], `,"'=HYPERLINK(""https://example.domain"")",`)
+		a.Contains(lines[1], ",bourre,-1.25,")
+		a.True(strings.HasSuffix(lines[1], ",'@admin"))
+	}
+
+	a.Equal("'-5", csvText("-5"))
+	a.Equal("'\tcmd", csvText("\tcmd"))
+	a.Equal("Bob", csvText("Bob"))
+	a.Equal("", csvText(""))
+}
+
+func Test_getTableUUIDLedger(t *testing.T) {
+	setupJWT()
+	ts := httptest.NewServer(NewMux(""))
+	defer ts.Close()
+
+	p1, j := player()
+	p2, j2 := player()
+
+	tbl, _ := p1.CreateTable(context.Background(), "My Table")
+	pt1, _ := p1.GetPlayerTable(context.Background(), tbl)
+	pt2, _ := p2.Join(context.Background(), tbl)
+	game, _ := tbl.CreateGame(context.Background(), "bourre")
+	assert.NoError(t, pt1.AdjustBalance(context.Background(), 125, "won pot", game))
+	assert.NoError(t, pt2.AdjustBalance(context.Background(), -125, "lost pot", game))
+
+	path := fmt.Sprintf("/table/%s/ledger", tbl.UUID)
+	var errObj errorResponse
+	assertGet(t, ts, path, &errObj, 403, j2)
+	assert.Equal(t, "you must be a table admin", errObj.Message)
+
+	var entries []*model.LedgerEntry
+	assertGet(t, ts, path, &entries, 200, j)
+	if assert.Equal(t, 2, len(entries)) {
+		assert.Equal(t, "bourre", entries[0].GameType)
+		assert.Equal(t, 125, entries[0].Adjustment)
+		assert.Equal(t, -125, entries[1].Adjustment)
+	}
+
+	resp := assertGetWithResp(t, ts, path+"?format=csv", nil, 200, j)
+	assert.Equal(t, "text/csv; charset=utf-8", resp.Header.Get("Content-Type"))
+	b, _ := io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+	lines := strings.Split(strings.TrimSpace(string(b)), "\n")
+	if assert.Equal(t, 3, len(lines)) {
+		assert.Equal(t, strings.Join(ledgerCSVHeader, ","), lines[0])
+		assert.Contains(t, lines[1], ",bourre,1.25,0.00,1.25,won pot")
+	}
+
+	assertGet(t, ts, path+"?from=2000-01-01&to=2000-01-31", &entries, 200, j)
+	assert.Empty(t, entries)
+
+	// players can only export their own ledger
+	assertGet(t, ts, fmt.Sprintf("/player/%d/ledger", p1.ID), nil, 403, j2)
+	assertGet(t, ts, fmt.Sprintf("/player/%d/ledger", p2.ID), &entries, 200, j2)
+	if assert.Equal(t, 1, len(entries)) {
+		assert.Equal(t, tbl.Name, entries[0].TableName)
+	}
+}
@@ -85,6 +85,8 @@ func NewMux(version string) *Mux {
 
 		r.Methods(http.MethodPost).Path("/player/{id:[0-9]+}").Handler(this.postPlayerID())
 		r.Methods(http.MethodDelete).Path("/player/{id:[0-9]+}").Handler(this.deletePlayerID())
+		r.Methods(http.MethodGet).Path("/player/{id:[0-9]+}/ledger").Handler(this.getPlayerIDLedger())
+		r.Methods(http.MethodGet).Path("/player/{id:[0-9]+}/stats").Handler(this.getPlayerIDStats())
 
 		r.Methods(http.MethodGet).Path("/table").Handler(this.getTable())
 		r.Methods(http.MethodPost).Path("/table").Handler(this.postTable())
@@ -95,6 +97,16 @@ func NewMux(version string) *Mux {
 		tr.Methods(http.MethodGet).Path("").Handler(this.getTableUUID())
 		tr.Methods(http.MethodGet).Path("/ws").Handler(this.getTableUUIDWS())
 		tr.Methods(http.MethodPost).Path("/seat").Handler(this.postTableUUIDSeat())
+		tr.Methods(http.MethodGet).Path("/game").Handler(this.getTableUUIDGame())
+		tr.Methods(http.MethodGet).Path("/game/{id:[0-9]+}").Handler(this.getTableUUIDGameID())
+		tr.Methods(http.MethodGet).Path("/hand-history").Handler(this.getTableUUIDHandHistory())
+		tr.Methods(http.MethodGet).Path("/game/{id:[0-9]+}/hand-history").Handler(this.getTableUUIDGameIDHandHistory())
+		tr.Methods(http.MethodGet).Path("/settlement").Handler(this.getTableUUIDSettlement())
+		tr.Methods(http.MethodPost).Path("/settlement/transfer").Handler(this.postTableUUIDSettlementTransfer())
+		tr.Methods(http.MethodPost).Path("/settlement/email").Handler(this.postTableUUIDSettlementEmail())
+		tr.Methods(http.MethodGet).Path("/ledger").Handler(this.getTableUUIDLedger())
+		tr.Methods(http.MethodGet).Path("/leaderboard").Handler(this.getTableUUIDLeaderboard())
+		tr.Methods(http.MethodPost).Path("/balance").Handler(this.postTableUUIDBalance())
 	}
 
 	// requires admin access
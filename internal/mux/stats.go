@@ -0,0 +1,58 @@
+package mux
+
+import (
+	"mondaynightpoker-server/pkg/model"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+)
+
+// getPlayerIDStats returns the player's lifetime statistics, and their statistics by game type and by table
+// note: a player can only view their own statistics unless they are a site admin
+func (m *Mux) getPlayerIDStats() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// ParseInt will always succeed
+		playerID, _ := strconv.ParseInt(mux.Vars(r)["id"], 10, 64)
+
+		player := r.Context().Value(ctxPlayerKey).(*model.Player)
+		if player.ID != playerID && !player.IsSiteAdmin {
+			writeJSONError(w, http.StatusForbidden, nil)
+			return
+		}
+
+		if player.ID != playerID {
+			var err error
+			if player, err = model.GetPlayerByID(r.Context(), playerID); err != nil {
+				writeMaybeNotFoundError(w, err)
+				return
+			}
+		}
+
+		profile, err := player.GetStatsProfile(r.Context())
+		if err != nil {
+			writeJSONError(w, http.StatusInternalServerError, err)
+			return
+		}
+
+		writeJSON(w, http.StatusOK, profile)
+	})
+}
+
+// getTableUUIDLeaderboard returns the statistics of every player who has played at the table
+func (m *Mux) getTableUUIDLeaderboard() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !tableMember(w, r, false) {
+			return
+		}
+
+		tbl := r.Context().Value(ctxTableKey).(*model.Table)
+		entries, err := tbl.GetLeaderboard(r.Context())
+		if err != nil {
+			writeJSONError(w, http.StatusInternalServerError, err)
+			return
+		}
+
+		writeJSON(w, http.StatusOK, entries)
+	})
+}
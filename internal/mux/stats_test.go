@@ -0,0 +1,72 @@
+package mux
+
+import (
+	"context"
+	"fmt"
+	"mondaynightpoker-server/pkg/model"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_getPlayerIDStats(t *testing.T) {
+	setupJWT()
+	ts := httptest.NewServer(NewMux(""))
+	defer ts.Close()
+
+	p1, j := player()
+	p2, j2 := player()
+
+	tbl, _ := p1.CreateTable(context.Background(), "My Table")
+	_, _ = p2.Join(context.Background(), tbl)
+	assert.NoError(t, tbl.AddPlayerStats(context.Background(), "bourre", map[int64]*model.PlayerStats{
+		p1.ID: {GamesPlayed: 1, Net: 125, BiggestPot: 125},
+		p2.ID: {GamesPlayed: 1, Net: -125},
+	}))
+
+	var profile model.PlayerProfile
+	assertGet(t, ts, fmt.Sprintf("/player/%d/stats", p1.ID), &profile, 200, j)
+	assert.Equal(t, p1.ID, profile.PlayerID)
+	assert.Equal(t, 125, profile.Lifetime.Net)
+	if assert.Equal(t, 1, len(profile.GameTypes)) {
+		assert.Equal(t, "bourre", profile.GameTypes[0].GameType)
+	}
+	if assert.Equal(t, 1, len(profile.Tables)) {
+		assert.Equal(t, tbl.Name, profile.Tables[0].TableName)
+	}
+
+	// players can only view their own statistics
+	assertGet(t, ts, fmt.Sprintf("/player/%d/stats", p1.ID), nil, 403, j2)
+}
+
+func Test_getTableUUIDLeaderboard(t *testing.T) {
+	setupJWT()
+	ts := httptest.NewServer(NewMux(""))
+	defer ts.Close()
+
+	p1, j := player()
+	p2, j2 := player()
+	_, j3 := player()
+
+	tbl, _ := p1.CreateTable(context.Background(), "My Table")
+	_, _ = p2.Join(context.Background(), tbl)
+	assert.NoError(t, tbl.AddPlayerStats(context.Background(), "bourre", map[int64]*model.PlayerStats{
+		p1.ID: {GamesPlayed: 1, Net: -125},
+		p2.ID: {GamesPlayed: 1, Net: 125, BiggestPot: 125},
+	}))
+
+	path := fmt.Sprintf("/table/%s/leaderboard", tbl.UUID)
+	assertGet(t, ts, path, nil, 403, j3)
+
+	var entries []*model.LeaderboardEntry
+	assertGet(t, ts, path, &entries, 200, j)
+	if assert.Equal(t, 2, len(entries)) {
+		assert.Equal(t, p2.ID, entries[0].PlayerID)
+		assert.Equal(t, 125, entries[0].Net)
+		assert.Equal(t, p1.ID, entries[1].PlayerID)
+	}
+
+	assertGet(t, ts, path, &entries, 200, j2)
+	assert.Equal(t, 2, len(entries))
+}
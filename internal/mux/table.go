@@ -83,17 +83,54 @@ func (m *Mux) getTableUUID() http.Handler {
 	})
 }
 
+type postTableUUIDSeatPayload struct {
+	// Seat is the seat to take, if nil a player who joins the table takes the lowest seat that is free
+	Seat *int `json:"seat"`
+}
+
+// postTableUUIDSeat joins the table, or moves a player who is already at the table to another seat
 func (m *Mux) postTableUUIDSeat() http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var payload postTableUUIDSeatPayload
+		if r.ContentLength != 0 && !decodeRequest(w, r, &payload) {
+			return
+		}
+
 		player := r.Context().Value(ctxPlayerKey).(*model.Player)
 		tbl := r.Context().Value(ctxTableKey).(*model.Table)
 
-		playerTable, err := player.Join(r.Context(), tbl)
+		playerTable, err := player.GetPlayerTable(r.Context(), tbl)
+		if err != nil && err != model.ErrPlayerNotAtTable {
+			writeJSONError(w, http.StatusInternalServerError, err)
+			return
+		}
+
+		if playerTable != nil {
+			if payload.Seat == nil {
+				writeJSONError(w, http.StatusBadRequest, errors.New("player is already at the table"))
+				return
+			}
+
+			if err := playerTable.SetSeat(r.Context(), *payload.Seat); err != nil {
+				writeSeatError(w, err)
+				return
+			}
+
+			writeJSON(w, http.StatusOK, playerTable)
+			return
+		}
+
+		seat := 0
+		if payload.Seat != nil {
+			seat = *payload.Seat
+		}
+
+		playerTable, err = player.JoinAtSeat(r.Context(), tbl, seat)
 		if err != nil {
 			if err == model.ErrDuplicateKey {
 				writeJSONError(w, http.StatusBadRequest, errors.New("player is already at the table"))
 			} else {
-				writeJSONError(w, http.StatusInternalServerError, err)
+				writeSeatError(w, err)
 			}
 
 			return
@@ -103,6 +140,15 @@ func (m *Mux) postTableUUIDSeat() http.Handler {
 	})
 }
 
+func writeSeatError(w http.ResponseWriter, err error) {
+	var ue model.UserError
+	if errors.As(err, &ue) {
+		writeJSONError(w, http.StatusBadRequest, err)
+	} else {
+		writeJSONError(w, http.StatusInternalServerError, err)
+	}
+}
+
 func (m *Mux) tableMiddleware(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		uuid := mux.Vars(r)["uuid"]
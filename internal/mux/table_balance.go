@@ -0,0 +1,40 @@
+package mux
+
+import (
+	"errors"
+	"mondaynightpoker-server/pkg/model"
+	"mondaynightpoker-server/pkg/room"
+	"net/http"
+)
+
+// postTableUUIDBalance records a buy-in, cash-out or correction to the balance of a player at the table
+// note: this requires a table admin
+func (m *Mux) postTableUUIDBalance() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !tableMember(w, r, true) {
+			return
+		}
+
+		var payload room.BalanceAdjustment
+		if !decodeRequest(w, r, &payload) {
+			return
+		}
+
+		player := r.Context().Value(ctxPlayerKey).(*model.Player)
+		tbl := r.Context().Value(ctxTableKey).(*model.Table)
+
+		pt, err := m.pitBoss.AdjustBalance(r.Context(), tbl, player, &payload)
+		if err != nil {
+			var ue model.UserError
+			if errors.As(err, &ue) || err == model.ErrPlayerNotAtTable {
+				writeJSONError(w, http.StatusBadRequest, err)
+			} else {
+				writeJSONError(w, http.StatusInternalServerError, err)
+			}
+
+			return
+		}
+
+		writeJSON(w, http.StatusOK, pt)
+	})
+}
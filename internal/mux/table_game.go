@@ -0,0 +1,122 @@
+package mux
+
+import (
+	"encoding/json"
+	"mondaynightpoker-server/pkg/model"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gorilla/mux"
+)
+
+type gameResponse struct {
+	ID           int64                    `json:"id"`
+	GameType     string                   `json:"gameType"`
+	Started      time.Time                `json:"started"`
+	Ended        time.Time                `json:"ended"`
+	Abandoned    bool                     `json:"abandoned"`
+	Participants []*model.GameParticipant `json:"participants"`
+
+	// Data is the log of the game, which is only returned to its participants
+	Data json.RawMessage `json:"data,omitempty"`
+}
+
+func newGameResponse(game *model.Game, participants []*model.GameParticipant) *gameResponse {
+	return &gameResponse{
+		ID:           game.ID,
+		GameType:     game.GameType,
+		Started:      game.Created,
+		Ended:        game.Ended,
+		Abandoned:    game.Abandoned,
+		Participants: participants,
+	}
+}
+
+// getTableUUIDGame returns the games that have ended at the table, newest first
+// The start and rows parameters page through the games
+func (m *Mux) getTableUUIDGame() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		offset, limit, err := parsePaginationOptions(r)
+		if err != nil {
+			writeJSONError(w, http.StatusBadRequest, err)
+			return
+		}
+
+		if !tableMember(w, r, false) {
+			return
+		}
+
+		tbl := r.Context().Value(ctxTableKey).(*model.Table)
+		games, err := tbl.GetEndedGames(r.Context(), offset, limit)
+		if err != nil {
+			writeJSONError(w, http.StatusInternalServerError, err)
+			return
+		}
+
+		participants, err := model.GetGameParticipants(r.Context(), games...)
+		if err != nil {
+			writeJSONError(w, http.StatusInternalServerError, err)
+			return
+		}
+
+		resp := make([]*gameResponse, len(games))
+		for i, game := range games {
+			resp[i] = newGameResponse(game, participants[game.ID])
+		}
+
+		writeJSON(w, http.StatusOK, resp)
+	})
+}
+
+// getTableUUIDGameID returns a game that has ended at the table
+// The log of the game is only included for the players who were seated in it
+func (m *Mux) getTableUUIDGameID() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !tableMember(w, r, false) {
+			return
+		}
+
+		player := r.Context().Value(ctxPlayerKey).(*model.Player)
+		tbl := r.Context().Value(ctxTableKey).(*model.Table)
+
+		// ParseInt will always succeed
+		id, _ := strconv.ParseInt(mux.Vars(r)["id"], 10, 64)
+		game, err := model.GameByID(r.Context(), id)
+		if err != nil {
+			writeMaybeNotFoundError(w, err)
+			return
+		}
+
+		if game.TableUUID != tbl.UUID || game.Ended.IsZero() {
+			writeJSONError(w, http.StatusNotFound, nil)
+			return
+		}
+
+		participants, err := model.GetGameParticipants(r.Context(), game)
+		if err != nil {
+			writeJSONError(w, http.StatusInternalServerError, err)
+			return
+		}
+
+		resp := newGameResponse(game, participants[game.ID])
+		if player.IsSiteAdmin || isGameParticipant(participants[game.ID], player.ID) {
+			if resp.Data, err = game.Data(); err != nil {
+				writeJSONError(w, http.StatusInternalServerError, err)
+				return
+			}
+		}
+
+		writeJSON(w, http.StatusOK, resp)
+	})
+}
+
+func isGameParticipant(participants []*model.GameParticipant, playerID int64) bool {
+	for _, p := range participants {
+		if p.PlayerID == playerID {
+			return true
+		}
+	}
+
+	return false
+}
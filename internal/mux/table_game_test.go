@@ -0,0 +1,71 @@
+package mux
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"mondaynightpoker-server/pkg/model"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_getTableUUIDGame(t *testing.T) {
+	setupJWT()
+	ts := httptest.NewServer(NewMux(""))
+	defer ts.Close()
+
+	p1, j := player()
+	p2, j2 := player()
+	p3, j3 := player()
+	_, j4 := player()
+
+	tbl, _ := p1.CreateTable(context.Background(), "My Table")
+	_, _ = p2.Join(context.Background(), tbl)
+	game, _ := tbl.StartGame(context.Background(), "bourre", []*model.GameParticipant{{PlayerID: p1.ID, Seat: 1, StartingStake: 2000}, {PlayerID: p2.ID, Seat: 2, StartingStake: 2000}})
+	assert.NoError(t, game.EndGame(context.Background(), map[string]int{"pot": 100}, map[int64]int{p1.ID: 50, p2.ID: -50}))
+	_, _ = p3.Join(context.Background(), tbl)
+	unfinished, _ := tbl.CreateGame(context.Background(), "bourre")
+
+	var errObj errorResponse
+	assertGet(t, ts, fmt.Sprintf("/table/%s/game", tbl.UUID), &errObj, 403, j4)
+	assert.Equal(t, "player is not a member of the table", errObj.Message)
+
+	var games []*gameResponse
+	assertGet(t, ts, fmt.Sprintf("/table/%s/game", tbl.UUID), &games, 200, j3)
+	if assert.Equal(t, 1, len(games)) {
+		assert.Equal(t, game.ID, games[0].ID)
+		assert.Equal(t, "bourre", games[0].GameType)
+		assert.False(t, games[0].Ended.IsZero())
+		assert.Equal(t, 2, len(games[0].Participants))
+		assert.Nil(t, games[0].Data)
+	}
+
+	assertGet(t, ts, fmt.Sprintf("/table/%s/game?start=1", tbl.UUID), &games, 200, j)
+	assert.Empty(t, games)
+
+	path := fmt.Sprintf("/table/%s/game/%d", tbl.UUID, game.ID)
+	var resp gameResponse
+	assertGet(t, ts, path, &resp, 200, j2)
+	assert.Equal(t, game.ID, resp.ID)
+	if assert.Equal(t, 2, len(resp.Participants)) {
+		assert.Equal(t, 2, resp.Participants[1].Seat)
+		assert.Equal(t, -50, resp.Participants[1].Adjustment)
+		assert.Equal(t, model.GameResultLost, resp.Participants[1].Result)
+	}
+
+	var data map[string]int
+	assert.NoError(t, json.Unmarshal(resp.Data, &data))
+	assert.Equal(t, 100, data["pot"])
+
+	// players who were not seated in the game cannot see its log
+	resp = gameResponse{}
+	assertGet(t, ts, path, &resp, 200, j3)
+	assert.Equal(t, game.ID, resp.ID)
+	assert.Nil(t, resp.Data)
+
+	assertGet(t, ts, path, nil, 403, j4)
+	assertGet(t, ts, fmt.Sprintf("/table/%s/game/%d", tbl.UUID, unfinished.ID), nil, 404, j)
+	assertGet(t, ts, fmt.Sprintf("/table/%s/game/%d", tbl.UUID, game.ID+1000), nil, 404, j)
+}
@@ -0,0 +1,158 @@
+package mux
+
+import (
+	"archive/zip"
+	"bytes"
+	"errors"
+	"fmt"
+	"mondaynightpoker-server/pkg/model"
+	"mondaynightpoker-server/pkg/playable/poker/texasholdem"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+)
+
+// getTableUUIDGameIDHandHistory downloads a game of Texas Hold'em in the PokerStars hand history format
+func (m *Mux) getTableUUIDGameIDHandHistory() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		player := r.Context().Value(ctxPlayerKey).(*model.Player)
+		tbl := r.Context().Value(ctxTableKey).(*model.Table)
+
+		names, ok := handHistoryNames(w, r, player, tbl)
+		if !ok {
+			return
+		}
+
+		id, _ := strconv.ParseInt(mux.Vars(r)["id"], 10, 64)
+		game, err := model.GameByID(r.Context(), id)
+		if err != nil {
+			writeMaybeNotFoundError(w, err)
+			return
+		}
+
+		if game.TableUUID != tbl.UUID || game.Ended.IsZero() {
+			writeJSONError(w, http.StatusNotFound, nil)
+			return
+		}
+
+		var buf bytes.Buffer
+		if err := writeHandHistory(&buf, tbl, game, names, player.ID); err != nil {
+			if errors.Is(err, texasholdem.ErrNoHandHistory) || errors.Is(err, texasholdem.ErrHandHistoryNotSupported) {
+				writeJSONError(w, http.StatusBadRequest, err)
+			} else {
+				writeJSONError(w, http.StatusInternalServerError, err)
+			}
+
+			return
+		}
+
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+		w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="hand-%d.txt"`, game.ID))
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write(buf.Bytes())
+	})
+}
+
+// getTableUUIDHandHistory downloads a zip archive with a PokerStars hand history for each game of Texas Hold'em
+// The start and rows parameters page through all of the table's games, newest first
+func (m *Mux) getTableUUIDHandHistory() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		offset, limit, err := parsePaginationOptions(r)
+		if err != nil {
+			writeJSONError(w, http.StatusBadRequest, err)
+			return
+		}
+
+		player := r.Context().Value(ctxPlayerKey).(*model.Player)
+		tbl := r.Context().Value(ctxTableKey).(*model.Table)
+
+		names, ok := handHistoryNames(w, r, player, tbl)
+		if !ok {
+			return
+		}
+
+		games, err := tbl.GetEndedGames(r.Context(), offset, limit)
+		if err != nil {
+			writeJSONError(w, http.StatusInternalServerError, err)
+			return
+		}
+
+		var buf bytes.Buffer
+		archive := zip.NewWriter(&buf)
+		for _, game := range games {
+			var hh bytes.Buffer
+			if err := writeHandHistory(&hh, tbl, game, names, player.ID); err != nil {
+				if errors.Is(err, texasholdem.ErrNoHandHistory) || errors.Is(err, texasholdem.ErrHandHistoryNotSupported) {
+					continue
+				}
+
+				writeJSONError(w, http.StatusInternalServerError, err)
+				return
+			}
+
+			f, err := archive.Create(fmt.Sprintf("hand-%d.txt", game.ID))
+			if err != nil {
+				writeJSONError(w, http.StatusInternalServerError, err)
+				return
+			}
+
+			if _, err := f.Write(hh.Bytes()); err != nil {
+				writeJSONError(w, http.StatusInternalServerError, err)
+				return
+			}
+		}
+
+		if err := archive.Close(); err != nil {
+			writeJSONError(w, http.StatusInternalServerError, err)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/zip")
+		w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="hand-history-%s.zip"`, tbl.UUID))
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write(buf.Bytes())
+	})
+}
+
+// handHistoryNames returns the display names of the players at the table
+// Only players at the table can download its hand histories
+func handHistoryNames(w http.ResponseWriter, r *http.Request, player *model.Player, tbl *model.Table) (map[int64]string, bool) {
+	if _, err := player.GetPlayerTable(r.Context(), tbl); err != nil {
+		if err == model.ErrPlayerNotAtTable {
+			writeJSONError(w, http.StatusForbidden, err)
+		} else {
+			writeJSONError(w, http.StatusInternalServerError, err)
+		}
+
+		return nil, false
+	}
+
+	players, err := tbl.GetPlayers(r.Context())
+	if err != nil {
+		writeJSONError(w, http.StatusInternalServerError, err)
+		return nil, false
+	}
+
+	names := make(map[int64]string, len(players))
+	for _, pt := range players {
+		names[pt.PlayerID] = pt.Player.DisplayName
+	}
+
+	return names, true
+}
+
+func writeHandHistory(buf *bytes.Buffer, tbl *model.Table, game *model.Game, names map[int64]string, hero int64) error {
+	data, err := game.Data()
+	if err != nil {
+		return err
+	}
+
+	return texasholdem.WritePokerStarsHandHistory(buf, data, texasholdem.HandHistory{
+		GameID:    game.ID,
+		TableName: tbl.Name,
+		Time:      game.Created,
+		Names:     names,
+		Hero:      hero,
+	})
+}
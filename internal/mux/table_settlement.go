@@ -0,0 +1,267 @@
+package mux
+
+import (
+	"errors"
+	"fmt"
+	"mondaynightpoker-server/internal/config"
+	"mondaynightpoker-server/pkg/model"
+	"mondaynightpoker-server/pkg/settlement"
+	"net/http"
+
+	"github.com/sirupsen/logrus"
+)
+
+type settlementResponse struct {
+	// Balances are the balances of the players at the table, by player ID
+	Balances  map[int64]int          `json:"balances"`
+	Transfers []*settlement.Transfer `json:"transfers"`
+
+	// Unsettled is the amount left over if the balances do not add up to zero
+	Unsettled int `json:"unsettled"`
+}
+
+func newSettlementResponse(players []*model.PlayerTable) *settlementResponse {
+	balances := make(map[int64]int, len(players))
+	unsettled := 0
+	for _, pt := range players {
+		balances[pt.PlayerID] = pt.Balance
+		unsettled += pt.Balance
+	}
+
+	return &settlementResponse{
+		Balances:  balances,
+		Transfers: settlement.Settle(balances),
+		Unsettled: unsettled,
+	}
+}
+
+// getTableUUIDSettlement returns the transfers that settle the balances of the players at the table
+func (m *Mux) getTableUUIDSettlement() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		players, ok := settlementPlayers(w, r, false)
+		if !ok {
+			return
+		}
+
+		writeJSON(w, http.StatusOK, newSettlementResponse(players))
+	})
+}
+
+type postTableUUIDSettlementTransferPayload struct {
+	From   int64 `json:"from"`
+	To     int64 `json:"to"`
+	Amount int   `json:"amount"`
+}
+
+// postTableUUIDSettlementTransfer marks a transfer as paid
+// note: this requires a table admin
+func (m *Mux) postTableUUIDSettlementTransfer() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		players, ok := settlementPlayers(w, r, true)
+		if !ok {
+			return
+		}
+
+		var payload postTableUUIDSettlementTransferPayload
+		if !decodeRequest(w, r, &payload) {
+			return
+		}
+
+		var from, to *model.PlayerTable
+		for _, pt := range players {
+			switch pt.PlayerID {
+			case payload.From:
+				from = pt
+			case payload.To:
+				to = pt
+			}
+		}
+
+		if from == nil || to == nil {
+			writeJSONError(w, http.StatusBadRequest, model.ErrPlayerNotAtTable)
+			return
+		}
+
+		if from.Balance >= 0 {
+			writeJSONError(w, http.StatusBadRequest, fmt.Errorf("%s does not owe any money", from.Player.DisplayName))
+			return
+		}
+
+		if to.Balance <= 0 {
+			writeJSONError(w, http.StatusBadRequest, fmt.Errorf("%s is not owed any money", to.Player.DisplayName))
+			return
+		}
+
+		// a transfer cannot pay more than is owed
+		if payload.Amount > -from.Balance || payload.Amount > to.Balance {
+			writeJSONError(w, http.StatusBadRequest, fmt.Errorf("the amount must be at most ${%d}", minInt(-from.Balance, to.Balance)))
+			return
+		}
+
+		if err := model.RecordTransfer(r.Context(), from, to, payload.Amount); err != nil {
+			var ue model.UserError
+			if errors.As(err, &ue) {
+				writeJSONError(w, http.StatusBadRequest, err)
+			} else {
+				writeJSONError(w, http.StatusInternalServerError, err)
+			}
+
+			return
+		}
+
+		writeJSON(w, http.StatusOK, newSettlementResponse(players))
+	})
+}
+
+// postTableUUIDSettlementEmail emails the settlement to every player at the table
+// note: this requires a table admin
+func (m *Mux) postTableUUIDSettlementEmail() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		players, ok := settlementPlayers(w, r, true)
+		if !ok {
+			return
+		}
+
+		tbl := r.Context().Value(ctxTableKey).(*model.Table)
+		go m.sendSettlementEmails(tbl, players)
+
+		writeJSON(w, http.StatusOK, statusOK)
+	})
+}
+
+type settlementEmailTransfer struct {
+	From   string
+	To     string
+	Amount string
+}
+
+func (m *Mux) sendSettlementEmails(tbl *model.Table, players []*model.PlayerTable) {
+	if config.Instance().Email.Disable {
+		return
+	}
+
+	names := make(map[int64]string, len(players))
+	for _, pt := range players {
+		names[pt.PlayerID] = pt.Player.DisplayName
+	}
+
+	transfers := newSettlementResponse(players).Transfers
+	for _, pt := range players {
+		if pt.Player.Email == "" {
+			continue
+		}
+
+		mine := make([]*settlementEmailTransfer, 0)
+		all := make([]*settlementEmailTransfer, len(transfers))
+		for i, transfer := range transfers {
+			all[i] = &settlementEmailTransfer{
+				From:   names[transfer.From],
+				To:     names[transfer.To],
+				Amount: formatDollars(transfer.Amount),
+			}
+
+			if transfer.From == pt.PlayerID || transfer.To == pt.PlayerID {
+				mine = append(mine, all[i])
+			}
+		}
+
+		log := logrus.WithFields(logrus.Fields{
+			"to":    pt.Player.Email,
+			"table": tbl.UUID,
+		})
+
+		msg, err := m.emailTemplates.RenderTemplate("settlement.html", map[string]interface{}{
+			"host":      config.Instance().Host,
+			"email":     pt.Player.Email,
+			"table":     tbl.Name,
+			"balance":   formatDollars(pt.Balance),
+			"mine":      mine,
+			"transfers": all,
+		})
+
+		if err != nil {
+			log.WithError(err).Error("could not render the template")
+			continue
+		}
+
+		if err := m.email.SendSimple(pt.Player.Email, "Settlement for "+tbl.Name, msg); err != nil {
+			log.WithError(err).Error("could not send email")
+		} else {
+			log.Info("sent settlement email")
+		}
+	}
+}
+
+// settlementPlayers returns the players at the table
+// Only the players at the table can see the settlement, and only table admins can change it
+func settlementPlayers(w http.ResponseWriter, r *http.Request, requireAdmin bool) ([]*model.PlayerTable, bool) {
+	if !tableMember(w, r, requireAdmin) {
+		return nil, false
+	}
+
+	tbl := r.Context().Value(ctxTableKey).(*model.Table)
+	players, err := tbl.GetPlayers(r.Context())
+	if err != nil {
+		writeJSONError(w, http.StatusInternalServerError, err)
+		return nil, false
+	}
+
+	return players, true
+}
+
+// tableMember returns true if the player is a member of the table, or a table admin if requireAdmin is true
+// Site admins are members of every table
+func tableMember(w http.ResponseWriter, r *http.Request, requireAdmin bool) bool {
+	player := r.Context().Value(ctxPlayerKey).(*model.Player)
+	tbl := r.Context().Value(ctxTableKey).(*model.Table)
+
+	if player.IsSiteAdmin {
+		return true
+	}
+
+	pt, err := player.GetPlayerTable(r.Context(), tbl)
+	if err != nil {
+		if err == model.ErrPlayerNotAtTable {
+			writeJSONError(w, http.StatusForbidden, err)
+		} else {
+			writeJSONError(w, http.StatusInternalServerError, err)
+		}
+
+		return false
+	}
+
+	if requireAdmin && !pt.IsTableAdmin {
+		writeJSONError(w, http.StatusForbidden, errors.New("you must be a table admin"))
+		return false
+	}
+
+	return true
+}
+
+// formatDollars formats an amount in cents, i.e., $1.25
+func formatDollars(cents int) string {
+	if cents < 0 {
+		return "-$" + formatDecimal(-cents)
+	}
+
+	return "$" + formatDecimal(cents)
+}
+
+// formatDecimal formats an amount in cents as dollars without a currency symbol, i.e., 1.25
+func formatDecimal(cents int) string {
+	sign := ""
+	if cents < 0 {
+		sign = "-"
+		cents = -cents
+	}
+
+	return fmt.Sprintf("%s%d.%02d", sign, cents/100, cents%100)
+}
+
+func minInt(a, b int) int {
+	if a < b {
+		return a
+	}
+
+	return b
+}
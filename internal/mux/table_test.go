@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"github.com/stretchr/testify/assert"
 	"mondaynightpoker-server/pkg/model"
+	"mondaynightpoker-server/pkg/room"
 	"net/http/httptest"
 	"strings"
 	"testing"
@@ -94,6 +95,22 @@ func Test_postTableUUIDJoin(t *testing.T) {
 	assertPost(t, ts, path, nil, &respObj, 201, j2)
 	assert.Equal(t, 0, respObj.Balance)
 	assert.True(t, respObj.Active)
+	assert.Equal(t, 2, *respObj.Seat)
+
+	// a player can choose their seat
+	_, j3 := player()
+	assertPost(t, ts, path, map[string]int{"seat": 2}, &errObj, 400, j3)
+	assert.Equal(t, "the seat is taken", errObj.Message)
+	assertPost(t, ts, path, map[string]int{"seat": 11}, &errObj, 400, j3)
+	assert.Equal(t, "the seat must be between 1 and 10", errObj.Message)
+	assertPost(t, ts, path, map[string]int{"seat": 7}, &respObj, 201, j3)
+	assert.Equal(t, 7, *respObj.Seat)
+
+	// and a player at the table can move to another seat
+	assertPost(t, ts, path, map[string]int{"seat": 4}, &respObj, 200, j)
+	assert.Equal(t, 4, *respObj.Seat)
+	assertPost(t, ts, path, map[string]int{"seat": 7}, &errObj, 400, j)
+	assert.Equal(t, "the seat is taken", errObj.Message)
 }
 
 func Test_getTableUUID(t *testing.T) {
@@ -114,3 +131,106 @@ func Test_getTableUUID(t *testing.T) {
 	assert.Equal(t, tbl.UUID, respObj.Table.UUID)
 	assert.Equal(t, 2, len(respObj.Players))
 }
+
+func Test_getTableUUIDHandHistory(t *testing.T) {
+	setupJWT()
+	ts := httptest.NewServer(NewMux(""))
+	defer ts.Close()
+
+	p1, j := player()
+	_, j2 := player()
+
+	tbl, _ := p1.CreateTable(context.Background(), "My Table")
+	game, _ := tbl.CreateGame(context.Background(), "bourre")
+	assert.NoError(t, game.EndGame(context.Background(), map[string]int{"pot": 100}, nil))
+
+	var errObj errorResponse
+	assertGet(t, ts, fmt.Sprintf("/table/%s/hand-history", tbl.UUID), &errObj, 403, j2)
+	assert.Equal(t, "player is not a member of the table", errObj.Message)
+
+	path := fmt.Sprintf("/table/%s/game/%d/hand-history", tbl.UUID, game.ID)
+	assertGet(t, ts, path, &errObj, 400, j)
+	assert.Equal(t, "the game does not have a hand history", errObj.Message)
+
+	assertGet(t, ts, fmt.Sprintf("/table/%s/game/%d/hand-history", tbl.UUID, game.ID+1000), nil, 404, j)
+
+	resp := assertGetWithResp(t, ts, fmt.Sprintf("/table/%s/hand-history", tbl.UUID), nil, 200, j)
+	assert.Equal(t, "application/zip", resp.Header.Get("Content-Type"))
+}
+
+func Test_getTableUUIDSettlement(t *testing.T) {
+	setupJWT()
+	ts := httptest.NewServer(NewMux(""))
+	defer ts.Close()
+
+	p1, j := player()
+	p2, j2 := player()
+	_, j3 := player()
+
+	tbl, _ := p1.CreateTable(context.Background(), "My Table")
+	pt1, _ := p1.GetPlayerTable(context.Background(), tbl)
+	pt2, _ := p2.Join(context.Background(), tbl)
+	assert.NoError(t, pt1.AdjustBalance(context.Background(), 500, "test", nil))
+	assert.NoError(t, pt2.AdjustBalance(context.Background(), -500, "test", nil))
+
+	path := fmt.Sprintf("/table/%s/settlement", tbl.UUID)
+	var errObj errorResponse
+	assertGet(t, ts, path, &errObj, 403, j3)
+	assert.Equal(t, "player is not a member of the table", errObj.Message)
+
+	var respObj settlementResponse
+	assertGet(t, ts, path, &respObj, 200, j2)
+	assert.Equal(t, 0, respObj.Unsettled)
+	if assert.Equal(t, 1, len(respObj.Transfers)) {
+		assert.Equal(t, p2.ID, respObj.Transfers[0].From)
+		assert.Equal(t, p1.ID, respObj.Transfers[0].To)
+		assert.Equal(t, 500, respObj.Transfers[0].Amount)
+	}
+
+	payload := postTableUUIDSettlementTransferPayload{From: p2.ID, To: p1.ID, Amount: 500}
+	assertPost(t, ts, path+"/transfer", payload, &errObj, 403, j2)
+	assert.Equal(t, "you must be a table admin", errObj.Message)
+
+	payload.Amount = 600
+	assertPost(t, ts, path+"/transfer", payload, &errObj, 400, j)
+	assert.Equal(t, "the amount must be at most ${500}", errObj.Message)
+
+	payload.Amount = 500
+	assertPost(t, ts, path+"/transfer", payload, &respObj, 200, j)
+	assert.Equal(t, 0, respObj.Balances[p1.ID])
+	assert.Equal(t, 0, respObj.Balances[p2.ID])
+	assert.Empty(t, respObj.Transfers)
+}
+
+func Test_postTableUUIDBalance(t *testing.T) {
+	setupJWT()
+	ts := httptest.NewServer(NewMux(""))
+	defer ts.Close()
+
+	p1, j := player()
+	p2, j2 := player()
+
+	tbl, _ := p1.CreateTable(context.Background(), "My Table")
+	_, _ = p2.Join(context.Background(), tbl)
+
+	path := fmt.Sprintf("/table/%s/balance", tbl.UUID)
+	payload := room.BalanceAdjustment{PlayerID: p2.ID, Kind: model.AdjustmentBuyIn, Amount: 2000}
+
+	var errObj errorResponse
+	assertPost(t, ts, path, payload, &errObj, 403, j2)
+	assert.Equal(t, "you must be a table admin", errObj.Message)
+
+	assertPost(t, ts, path, payload, &errObj, 400, j)
+	assert.Equal(t, "a reason is required", errObj.Message)
+
+	payload.Reason = "cash"
+	var respObj model.PlayerTable
+	assertPost(t, ts, path, payload, &respObj, 200, j)
+	assert.Equal(t, p2.ID, respObj.PlayerID)
+	assert.Equal(t, 2000, respObj.Balance)
+
+	p3, _ := player()
+	payload.PlayerID = p3.ID
+	assertPost(t, ts, path, payload, &errObj, 400, j)
+	assert.Equal(t, "player is not a member of the table", errObj.Message)
+}
@@ -21,6 +21,9 @@ const (
 	Diamonds Suit = "diamonds"
 	Spades   Suit = "spades"
 	Stars    Suit = "stars"
+
+	// Joker is the suit of a joker, which does not belong to any suit
+	Joker Suit = "joker"
 )
 
 // Card is an individual playing card
@@ -47,7 +50,33 @@ const (
 	LowAce  = 1
 )
 
+// JokerRank is the rank of a joker
+const JokerRank = 0
+
+// NewJoker returns a new joker
+func NewJoker() *Card {
+	return &Card{
+		Rank: JokerRank,
+		Suit: Joker,
+	}
+}
+
+// IsJoker returns true if the card is a joker
+func (c *Card) IsJoker() bool {
+	return c.Suit == Joker
+}
+
+// IsBug returns true if the card is the bug
+// The bug is a joker that is only wild as an ace, or to complete a straight or a flush
+func (c *Card) IsBug() bool {
+	return c.IsJoker()
+}
+
 func (c *Card) String() string {
+	if c.IsJoker() {
+		return "🃏"
+	}
+
 	var rank string
 	switch c.Rank {
 	case Jack:
@@ -115,10 +144,11 @@ func (c *Card) UnsetAllBits() {
 	c.BitField = 0
 }
 
-var cardRx = regexp.MustCompile(`(?i)^(!)?([0-9]|1[0-4])([cdhst])\z`)
+var cardRx = regexp.MustCompile(`(?i)^(!)?([0-9]|1[0-4])([cdhstj])\z`)
 
 // CardFromString returns a Card from the string.
-// The string must be in the format of <rank><suit> where rank >= 2 and <= 14 and suit in [cdhs]
+// The string must be in the format of <rank><suit> where rank >= 2 and <= 14 and suit in [cdhst]
+// A joker is represented as 0j
 func CardFromString(s string) *Card {
 	if s == "" {
 		return nil
@@ -148,6 +178,8 @@ func CardFromString(s string) *Card {
 		suit = Spades
 	case "t":
 		suit = Stars
+	case "j":
+		suit = Joker
 	default:
 		// should never be hit due to the regexp
 		panic("unknown suit")
@@ -238,6 +270,8 @@ func CardToString(card *Card) string {
 		suit = "s"
 	case Stars:
 		suit = "t"
+	case Joker:
+		suit = "j"
 	}
 
 	isWild := ""
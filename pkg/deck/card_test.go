@@ -57,6 +57,8 @@ func TestCard_String(t *testing.T) {
 
 	assert.Equal(t, "2☆", card.String())
 
+	assert.Equal(t, "🃏", NewJoker().String())
+
 	card = Card{
 		Rank: 3,
 		Suit: "Bad",
@@ -203,3 +205,17 @@ func TestCard_UnsetAllBits(t *testing.T) {
 
 	assert.Equal(t, 0, c.BitField)
 }
+
+func TestCard_joker(t *testing.T) {
+	a := assert.New(t)
+
+	joker := CardFromString("0j")
+	a.True(joker.IsJoker())
+	a.Equal(NewJoker(), joker)
+	a.Equal("0j", CardToString(joker))
+	a.False(CardFromString("14s").IsJoker())
+	a.True(joker.IsBug())
+	a.False(CardFromString("!14s").IsBug())
+
+	a.Equal("!0j", CardToString(CardFromString("!0j")))
+}
@@ -12,38 +12,40 @@ import (
 	"github.com/sirupsen/logrus"
 )
 
-type deckType int
-
-const (
-	deckTypeStandard deckType = iota
-	deckTypeFiveSuit
-)
-
 // ErrEndOfDeck is an error when Draw() is attempted and there are no more cards
 var ErrEndOfDeck = errors.New("end of deck reached")
 
 // Deck represents a playing deck
 type Deck struct {
-	Cards    []*Card `json:"cards"`
-	rng      rng.Generator
-	deckType deckType
+	Cards []*Card `json:"cards"`
+	rng   rng.Generator
+	spec  Spec
 }
 
 // New returns a new deck of cards.
 // Important! this deck is unshuffled. You must call the Shuffle() method to shuffle the cards
 func New() *Deck {
-	return newOfDeckType(deckTypeStandard)
+	return newFromValidSpec(StandardSpec())
 }
 
 // NewFiveSuit returns a deck with a fifth suit
 func NewFiveSuit() *Deck {
-	return newOfDeckType(deckTypeFiveSuit)
+	return newFromValidSpec(FiveSuitSpec())
+}
+
+// NewFromSpec returns a new, unshuffled deck built from the spec
+func NewFromSpec(spec Spec) (*Deck, error) {
+	if err := spec.Validate(); err != nil {
+		return nil, err
+	}
+
+	return newFromValidSpec(spec), nil
 }
 
-func newOfDeckType(deckType deckType) *Deck {
+func newFromValidSpec(spec Spec) *Deck {
 	d := &Deck{
-		rng:      rng.Crypto{},
-		deckType: deckType,
+		rng:  rng.Crypto{},
+		spec: spec,
 	}
 
 	d.buildDeck()
@@ -51,29 +53,19 @@ func newOfDeckType(deckType deckType) *Deck {
 }
 
 func (d *Deck) buildDeck() {
-	suits := []Suit{Clubs, Diamonds, Hearts, Spades}
-	if d.deckType != deckTypeStandard {
-		suits = []Suit{Clubs, Diamonds, Hearts, Spades, Stars}
-	}
-
-	cards := make([]*Card, 0, 52)
-	for _, suit := range suits {
-		for rank := 2; rank <= 14; rank++ {
-			cards = append(cards, &Card{
-				Rank: rank,
-				Suit: suit,
-			})
-		}
-	}
+	d.Cards = d.spec.Cards()
+}
 
-	d.Cards = cards
+// Spec returns the composition of the deck
+func (d *Deck) Spec() Spec {
+	return d.spec
 }
 
 // Shuffle will shuffle the deck of cards
 func (d *Deck) Shuffle() {
 	// we always want to shuffle from an unshuffled deck.
 	// this check here is to make sure we aren't double building the deck
-	if len(d.Cards) != 52 {
+	if len(d.Cards) != d.spec.Size() {
 		d.buildDeck()
 	}
 
@@ -140,19 +132,14 @@ func (d *Deck) CardsLeft() int {
 }
 
 // RemoveCard will remove the specified card from the deck
+// Only a single copy of the card is removed from a multi-deck shoe
 // Returns true if the card was removed, returns false if the card could not be found
 func (d *Deck) RemoveCard(targetCard *Card) bool {
-	newDeck := make([]*Card, 0, len(d.Cards))
-	for _, card := range d.Cards {
-		if !card.Equal(targetCard) {
-			newDeck = append(newDeck, card)
-		}
-	}
+	cards := Hand(d.Cards)
+	removed := cards.Discard(targetCard, 1)
 
-	cardWasRemoved := len(newDeck) != len(d.Cards)
-
-	d.Cards = newDeck
-	return cardWasRemoved
+	d.Cards = cards
+	return removed == 1
 }
 
 // SetSeed is a TESTING method for setting pseudo random number generator with a seed
@@ -0,0 +1,132 @@
+package deck
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Spec describes the composition of a deck
+type Spec struct {
+	// Suits are the suits in each deck
+	Suits []Suit
+
+	// MinRank and MaxRank are the lowest and highest ranks (inclusive) of each suit
+	MinRank int
+	MaxRank int
+
+	// Jokers is the number of jokers in each deck
+	Jokers int
+
+	// Copies is the number of decks shuffled together, i.e., 6 for a six-deck shoe
+	// Zero is treated as a single deck
+	Copies int
+}
+
+// StandardSpec is a standard 52-card deck
+func StandardSpec() Spec {
+	return Spec{
+		Suits:   []Suit{Clubs, Diamonds, Hearts, Spades},
+		MinRank: 2,
+		MaxRank: Ace,
+	}
+}
+
+// FiveSuitSpec is a 65-card deck with a fifth suit of stars
+func FiveSuitSpec() Spec {
+	spec := StandardSpec()
+	spec.Suits = append(spec.Suits, Stars)
+	return spec
+}
+
+// ShortDeckSpec is a 36-card deck where the 2s through 5s are removed
+func ShortDeckSpec() Spec {
+	spec := StandardSpec()
+	spec.MinRank = 6
+	return spec
+}
+
+// ShoeSpec is a number of standard decks shuffled together
+func ShoeSpec(decks int) Spec {
+	spec := StandardSpec()
+	spec.Copies = decks
+	return spec
+}
+
+// WithJokers returns a copy of the spec with the number of jokers in each deck
+func (s Spec) WithJokers(jokers int) Spec {
+	s.Jokers = jokers
+	return s
+}
+
+func (s Spec) copies() int {
+	if s.Copies == 0 {
+		return 1
+	}
+
+	return s.Copies
+}
+
+// Size returns the number of cards in the deck
+func (s Spec) Size() int {
+	return (len(s.Suits)*(s.MaxRank-s.MinRank+1) + s.Jokers) * s.copies()
+}
+
+// Validate returns an error if the deck cannot be built
+func (s Spec) Validate() error {
+	if len(s.Suits) == 0 {
+		return errors.New("deck must have at least one suit")
+	}
+
+	seen := make(map[Suit]bool)
+	for _, suit := range s.Suits {
+		if suit == Joker {
+			return errors.New("use Jokers to add jokers to the deck")
+		}
+
+		if seen[suit] {
+			return fmt.Errorf("duplicate suit: %s", suit)
+		}
+
+		seen[suit] = true
+	}
+
+	if s.MinRank < 2 || s.MaxRank > Ace || s.MinRank > s.MaxRank {
+		return fmt.Errorf("invalid rank range: %d-%d", s.MinRank, s.MaxRank)
+	}
+
+	if s.Jokers < 0 {
+		return errors.New("jokers cannot be negative")
+	}
+
+	if s.Copies < 0 {
+		return errors.New("copies cannot be negative")
+	}
+
+	return nil
+}
+
+// IsMultiDeck returns true if the same card may appear more than once
+func (s Spec) IsMultiDeck() bool {
+	return s.copies() > 1
+}
+
+// Cards returns the cards in the spec in an unshuffled order
+func (s Spec) Cards() []*Card {
+	cards := make([]*Card, 0, s.Size())
+	for i := 0; i < s.copies(); i++ {
+		for _, suit := range s.Suits {
+			for rank := s.MinRank; rank <= s.MaxRank; rank++ {
+				cards = append(cards, &Card{
+					Rank: rank,
+					Suit: suit,
+				})
+			}
+		}
+
+		for j := 0; j < s.Jokers; j++ {
+			cards = append(cards, NewJoker())
+		}
+	}
+
+	return cards
+}
@@ -0,0 +1,113 @@
+package deck
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSpec_Size(t *testing.T) {
+	a := assert.New(t)
+	a.Equal(52, StandardSpec().Size())
+	a.Equal(65, FiveSuitSpec().Size())
+	a.Equal(36, ShortDeckSpec().Size())
+	a.Equal(312, ShoeSpec(6).Size())
+	a.Equal(54, StandardSpec().WithJokers(2).Size())
+	a.Equal(106, ShoeSpec(2).WithJokers(1).Size())
+}
+
+func TestSpec_Validate(t *testing.T) {
+	a := assert.New(t)
+
+	a.NoError(StandardSpec().Validate())
+	a.NoError(ShortDeckSpec().WithJokers(1).Validate())
+
+	a.EqualError(Spec{MinRank: 2, MaxRank: 14}.Validate(), "deck must have at least one suit")
+
+	spec := StandardSpec()
+	spec.Suits = []Suit{Clubs, Clubs}
+	a.EqualError(spec.Validate(), "duplicate suit: clubs")
+
+	spec.Suits = []Suit{Clubs, Joker}
+	a.EqualError(spec.Validate(), "use Jokers to add jokers to the deck")
+
+	spec = StandardSpec()
+	spec.MinRank = 1
+	a.EqualError(spec.Validate(), "invalid rank range: 1-14")
+
+	spec.MinRank = 10
+	spec.MaxRank = 9
+	a.EqualError(spec.Validate(), "invalid rank range: 10-9")
+
+	a.EqualError(StandardSpec().WithJokers(-1).Validate(), "jokers cannot be negative")
+	a.EqualError(ShoeSpec(-1).Validate(), "copies cannot be negative")
+}
+
+func TestSpec_Cards(t *testing.T) {
+	a := assert.New(t)
+
+	spec := Spec{
+		Suits:   []Suit{Clubs, Hearts},
+		MinRank: 12,
+		MaxRank: Ace,
+		Jokers:  1,
+		Copies:  2,
+	}
+
+	a.Equal("12c,13c,14c,12h,13h,14h,0j,12c,13c,14c,12h,13h,14h,0j", CardsToString(spec.Cards()))
+	a.True(spec.IsMultiDeck())
+	a.False(StandardSpec().IsMultiDeck())
+}
+
+func TestNewFromSpec(t *testing.T) {
+	a := assert.New(t)
+
+	d, err := NewFromSpec(ShortDeckSpec())
+	a.NoError(err)
+	a.Equal(36, d.CardsLeft())
+	a.Equal(ShortDeckSpec(), d.Spec())
+	for _, card := range d.Cards {
+		a.GreaterOrEqual(card.Rank, 6)
+	}
+
+	d, err = NewFromSpec(Spec{})
+	a.EqualError(err, "deck must have at least one suit")
+	a.Nil(d)
+}
+
+func TestDeck_Shuffle_spec(t *testing.T) {
+	a := assert.New(t)
+
+	// a short deck should not be rebuilt into a 52-card deck
+	d, _ := NewFromSpec(ShortDeckSpec())
+	d.SetSeed(1)
+	d.Shuffle()
+	a.Equal(36, d.CardsLeft())
+
+	// a drawn shoe is rebuilt when shuffled
+	d, _ = NewFromSpec(ShoeSpec(6).WithJokers(2))
+	d.SetSeed(1)
+	_, _ = d.Draw()
+	d.Shuffle()
+	a.Equal(324, d.CardsLeft())
+
+	counts := make(map[string]int)
+	for _, card := range d.Cards {
+		counts[CardToString(card)]++
+	}
+
+	a.Equal(53, len(counts))
+	a.Equal(6, counts["14s"])
+	a.Equal(12, counts["0j"])
+}
+
+func TestDeck_RemoveCard_shoe(t *testing.T) {
+	a := assert.New(t)
+
+	d, _ := NewFromSpec(ShoeSpec(2))
+	a.True(d.RemoveCard(CardFromString("5s")))
+	a.Equal(103, d.CardsLeft())
+	a.True(d.RemoveCard(CardFromString("5s")))
+	a.False(d.RemoveCard(CardFromString("5s")))
+	a.Equal(102, d.CardsLeft())
+}
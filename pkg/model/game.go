@@ -4,9 +4,12 @@ import (
 	"context"
 	"database/sql"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"mondaynightpoker-server/pkg/db"
 	"time"
 
+	"github.com/lib/pq"
 	"github.com/sirupsen/logrus"
 )
 
@@ -19,9 +22,36 @@ type Game struct {
 	data      interface{}
 	Created   time.Time
 	Ended     time.Time
+
+	// Abandoned is true if the game was terminated before it ended
+	Abandoned bool
+
+	// SettlementAttempts is the number of times the balances could not be adjusted after the game
+	SettlementAttempts int
 }
 
-const gamesColumns = `id, parent_id, table_uuid, game_type, data, created, ended`
+// GameResult is the outcome of a game for one of its players
+type GameResult string
+
+// GameResult values
+const (
+	GameResultWon       GameResult = "won"
+	GameResultLost      GameResult = "lost"
+	GameResultEven      GameResult = "even"
+	GameResultAbandoned GameResult = "abandoned"
+)
+
+func gameResultFromAdjustment(adjustment int) GameResult {
+	if adjustment > 0 {
+		return GameResultWon
+	} else if adjustment < 0 {
+		return GameResultLost
+	}
+
+	return GameResultEven
+}
+
+const gamesColumns = `id, parent_id, table_uuid, game_type, data, created, ended, abandoned, settlement_attempts`
 
 // GameByID returns a game object by its ID
 func GameByID(ctx context.Context, id int64) (*Game, error) {
@@ -33,13 +63,184 @@ WHERE id = $1`
 	return gameByRow(row)
 }
 
-func gameByRow(row *sql.Row) (*Game, error) {
+// GetEndedGames returns the games that have ended at the table, newest first
+func (t *Table) GetEndedGames(ctx context.Context, offset int64, limit int) ([]*Game, error) {
+	const query = `
+SELECT ` + gamesColumns + `
+FROM games
+WHERE table_uuid = $1
+  AND ended IS NOT NULL
+ORDER BY id DESC
+OFFSET $2
+LIMIT $3`
+
+	rows, err := db.Instance().QueryContext(ctx, query, t.UUID, offset, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	games := make([]*Game, 0)
+	for rows.Next() {
+		g, err := gameByRow(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		games = append(games, g)
+	}
+
+	return games, rows.Err()
+}
+
+// GameParticipant is a record in the `games_players` table
+type GameParticipant struct {
+	PlayerID    int64  `json:"playerId"`
+	DisplayName string `json:"displayName"`
+	Seat        int    `json:"seat"`
+
+	// StartingStake is the amount the player could play with when the game started
+	StartingStake int `json:"startingStake"`
+
+	// Adjustment and Result are empty until the game ends, and when the game
+	// did not change the balances of the players
+	Adjustment int        `json:"adjustment"`
+	Result     GameResult `json:"result"`
+}
+
+// StartGame creates a new game for the table with the players who were dealt in
+func (t *Table) StartGame(ctx context.Context, gameType string, participants []*GameParticipant) (*Game, error) {
+	tx, err := db.Instance().BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	const query = `
+INSERT INTO games (parent_id, table_uuid, game_type)
+VALUES ($1, $2, $3)
+RETURNING ` + gamesColumns
+
+	g, err := gameByRow(tx.QueryRowContext(ctx, query, nil, t.UUID, gameType))
+	if err != nil {
+		rollback(tx)
+		return nil, err
+	}
+
+	const query2 = `
+INSERT INTO games_players (game_id, player_id, seat, starting_stake)
+VALUES ($1, $2, $3, $4)`
+	for _, p := range participants {
+		if _, err := tx.ExecContext(ctx, query2, g.ID, p.PlayerID, p.Seat, p.StartingStake); err != nil {
+			rollback(tx)
+			return nil, err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return g, nil
+}
+
+// Abandon ends a game that was terminated before it ended
+// The balances of the players are not adjusted
+func (g *Game) Abandon(ctx context.Context) error {
+	tx, err := db.Instance().BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	const query = `
+UPDATE games
+SET ended = NOW() AT TIME ZONE 'UTC', abandoned = true
+WHERE id = $1
+  AND ended IS NULL
+RETURNING ended`
+
+	var ended time.Time
+	if err := tx.QueryRowContext(ctx, query, g.ID).Scan(&ended); err != nil {
+		rollback(tx)
+		return err
+	}
+
+	const query2 = `
+UPDATE games_players
+SET result = $1
+WHERE game_id = $2`
+	if _, err := tx.ExecContext(ctx, query2, GameResultAbandoned, g.ID); err != nil {
+		rollback(tx)
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+
+	g.Ended = ended
+	g.Abandoned = true
+	return nil
+}
+
+// GetGameParticipants returns the participants of each game by game ID, in seat order
+func GetGameParticipants(ctx context.Context, games ...*Game) (map[int64][]*GameParticipant, error) {
+	ids := make([]int64, len(games))
+	participants := make(map[int64][]*GameParticipant, len(games))
+	for i, g := range games {
+		ids[i] = g.ID
+		participants[g.ID] = make([]*GameParticipant, 0)
+	}
+
+	const query = `
+SELECT games_players.game_id,
+       games_players.player_id,
+       players.display_name,
+       games_players.seat,
+       games_players.starting_stake,
+       games_players.adjustment,
+       games_players.result
+FROM games_players
+INNER JOIN players ON games_players.player_id = players.id
+WHERE games_players.game_id = ANY($1)
+ORDER BY games_players.game_id, games_players.seat`
+
+	rows, err := db.Instance().QueryContext(ctx, query, pq.Array(ids))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var gameID int64
+		var p GameParticipant
+		var displayName, result sql.NullString
+		var startingStake, adjustment sql.NullInt64
+		if err := rows.Scan(&gameID, &p.PlayerID, &displayName, &p.Seat, &startingStake, &adjustment, &result); err != nil {
+			return nil, err
+		}
+
+		p.DisplayName = displayName.String
+		p.StartingStake = int(startingStake.Int64)
+		p.Adjustment = int(adjustment.Int64)
+		p.Result = GameResult(result.String)
+		participants[gameID] = append(participants[gameID], &p)
+	}
+
+	return participants, rows.Err()
+}
+
+// Data returns the JSON data that was stored when the game ended
+func (g *Game) Data() ([]byte, error) {
+	return json.Marshal(g.data)
+}
+
+func gameByRow(row db.Scanner) (*Game, error) {
 	var parentID sql.NullInt64
 	var g Game
 	var data []byte
 	var ended sql.NullTime
 
-	if err := row.Scan(&g.ID, &parentID, &g.TableUUID, &g.GameType, &data, &g.Created, &ended); err != nil {
+	if err := row.Scan(&g.ID, &parentID, &g.TableUUID, &g.GameType, &data, &g.Created, &ended, &g.Abandoned, &g.SettlementAttempts); err != nil {
 		return nil, err
 	}
 
@@ -55,74 +256,230 @@ func gameByRow(row *sql.Row) (*Game, error) {
 	return &g, nil
 }
 
-// EndGame will end the game and set the data
+// ErrSettlementPending is returned when the game was saved, but the balances of its players
+// could not be adjusted. The settlement can be retried with Settle
+var ErrSettlementPending = errors.New("the game was saved, but the balances could not be adjusted")
+
+// MaxSettlementAttempts is the number of times the settlement of a game can fail before it is no longer retried
+const MaxSettlementAttempts = 10
+
+// settleAttempts is the number of times Settle tries to adjust the balances when a balance
+// changes while the game is being settled
+const settleAttempts = 3
+
+// EndGame will end the game and set the data, then adjust the balances of the players
+// If balanceAdjustments is nil, the balances are not adjusted
+// If the game is saved but the balances cannot be adjusted, an error wrapping ErrSettlementPending is
+// returned, and the adjustments are kept with the game so the settlement can be retried
 func (g *Game) EndGame(ctx context.Context, data interface{}, balanceAdjustments map[int64]int) error {
-	tbl, err := GetTableByUUID(ctx, g.TableUUID)
+	b, err := json.Marshal(data)
 	if err != nil {
 		return err
 	}
 
-	players, err := tbl.GetPlayers(ctx)
-	if err != nil {
-		return err
+	var adjustments []byte
+	if balanceAdjustments != nil {
+		if adjustments, err = json.Marshal(balanceAdjustments); err != nil {
+			return err
+		}
 	}
 
-	tx, err := db.Instance().BeginTx(ctx, nil)
-	if err != nil {
+	const query = `
+UPDATE games
+SET data = $1, balance_adjustments = $2, ended = NOW() AT TIME ZONE 'UTC'
+WHERE id = $3
+RETURNING ended`
+
+	var ended time.Time
+	if err := db.Instance().QueryRowContext(ctx, query, b, adjustments, g.ID).Scan(&ended); err != nil {
 		return err
 	}
 
-	commit := false
-	defer func() {
-		if !commit {
-			if err := tx.Rollback(); err != nil {
-				logrus.WithError(err).Error("could not rollback transaction")
-				return
-			}
-		}
+	g.data = data
+	g.Ended = ended
+
+	if balanceAdjustments == nil {
+		return nil
+	}
+
+	if err := g.Settle(ctx); err != nil {
+		return fmt.Errorf("%w: %v", ErrSettlementPending, err)
+	}
+
+	return nil
+}
 
-		if err := tx.Commit(); err != nil {
-			logrus.WithError(err).Error("could not commit transaction")
+// Settle adjusts the balances of the players by the adjustments that were saved when the game ended
+// Settling is keyed by the game, so a game that has already been settled is not settled again
+// If a balance changes while the game is being settled, the settlement is retried. If it still fails,
+// the error is recorded with the game and SettlementAttempts is incremented
+func (g *Game) Settle(ctx context.Context) error {
+	var err error
+	for attempt := 1; attempt <= settleAttempts; attempt++ {
+		if err = g.settle(ctx); err == nil || !isBalanceChangedError(err) {
+			break
 		}
-	}()
 
-	g.data = data
+		logrus.WithField("game", g.ID).WithField("attempt", attempt).Warn("balance changed while settling game")
+	}
+
+	if err == nil {
+		return nil
+	}
+
 	const query = `
 UPDATE games
-SET data = $1, ended = NOW() AT TIME ZONE 'UTC'
+SET settlement_attempts = settlement_attempts + 1, settlement_error = $1
 WHERE id = $2
-RETURNING ended`
+RETURNING settlement_attempts`
+	if dbErr := db.Instance().QueryRowContext(ctx, query, err.Error(), g.ID).Scan(&g.SettlementAttempts); dbErr != nil {
+		logrus.WithError(dbErr).WithField("game", g.ID).Error("could not record settlement error")
+		g.SettlementAttempts++
+	}
 
-	b, err := json.Marshal(data)
+	return err
+}
+
+// CanRetrySettlement returns true if the settlement of the game has not failed too many times to retry
+func (g *Game) CanRetrySettlement() bool {
+	return g.SettlementAttempts < MaxSettlementAttempts
+}
+
+func (g *Game) settle(ctx context.Context) error {
+	tx, err := db.Instance().BeginTx(ctx, nil)
 	if err != nil {
 		return err
 	}
 
-	row := tx.QueryRowContext(ctx, query, b, g.ID)
-	var ended time.Time
-	if err := row.Scan(&ended); err != nil {
+	// locking the game keeps the game from being settled twice at the same time
+	const query = `
+SELECT balance_adjustments, settled
+FROM games
+WHERE id = $1
+FOR UPDATE`
+
+	var adjustments []byte
+	var settled sql.NullTime
+	if err := tx.QueryRowContext(ctx, query, g.ID).Scan(&adjustments, &settled); err != nil {
+		rollback(tx)
 		return err
 	}
 
-	stmt, err := tx.PrepareContext(ctx, "SELECT adjust_balance($1, $2, $3, $4, $5)")
+	if settled.Valid || adjustments == nil {
+		rollback(tx)
+		return nil
+	}
+
+	var balanceAdjustments map[int64]int
+	if err := json.Unmarshal(adjustments, &balanceAdjustments); err != nil {
+		rollback(tx)
+		return err
+	}
+
+	const playersQuery = `
+SELECT id, player_id, balance
+FROM players_tables
+WHERE table_uuid = $1`
+	rows, err := tx.QueryContext(ctx, playersQuery, g.TableUUID)
 	if err != nil {
+		rollback(tx)
+		return err
+	}
+
+	type balance struct {
+		playersTablesID int64
+		playerID        int64
+		balance         int
+	}
+
+	balances := make([]*balance, 0)
+	for rows.Next() {
+		var b balance
+		if err := rows.Scan(&b.playersTablesID, &b.playerID, &b.balance); err != nil {
+			_ = rows.Close()
+			rollback(tx)
+			return err
+		}
+
+		balances = append(balances, &b)
+	}
+
+	if err := rows.Close(); err != nil {
+		rollback(tx)
 		return err
 	}
 
-	for _, player := range players {
-		change, found := balanceAdjustments[player.PlayerID]
+	const adjustQuery = `SELECT adjust_balance($1, $2, $3, $4, $5)`
+	const resultQuery = `
+UPDATE games_players
+SET adjustment = $1, result = $2
+WHERE game_id = $3
+  AND player_id = $4`
+	for _, b := range balances {
+		change, found := balanceAdjustments[b.playerID]
 		if !found {
-			logrus.WithField("player", player.PlayerID).Warn("could not find player's balance adjustment")
+			logrus.WithField("player", b.playerID).Warn("could not find player's balance adjustment")
 			continue
 		}
 
-		_, err := stmt.ExecContext(ctx, player.ID, player.Balance, change, g.ID, "game ended")
-		if err != nil {
+		if _, err := tx.ExecContext(ctx, adjustQuery, b.playersTablesID, b.balance, change, g.ID, "game ended"); err != nil {
+			rollback(tx)
+			return err
+		}
+
+		if _, err := tx.ExecContext(ctx, resultQuery, change, gameResultFromAdjustment(change), g.ID, b.playerID); err != nil {
+			rollback(tx)
 			return err
 		}
 	}
 
-	commit = true
-	g.Ended = ended
-	return nil
+	const settledQuery = `
+UPDATE games
+SET settled = NOW() AT TIME ZONE 'UTC', settlement_error = NULL
+WHERE id = $1`
+	if _, err := tx.ExecContext(ctx, settledQuery, g.ID); err != nil {
+		rollback(tx)
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// isBalanceChangedError returns true if adjust_balance failed because the balance changed
+// after it was read
+func isBalanceChangedError(err error) bool {
+	var pqErr *pq.Error
+	return errors.As(err, &pqErr) && pqErr.Message == "balance has changed"
+}
+
+// GetUnsettledGames returns the games at the table that ended without their balances being adjusted, oldest first
+// Games that failed to settle MaxSettlementAttempts times are left for an admin and not returned
+func (t *Table) GetUnsettledGames(ctx context.Context) ([]*Game, error) {
+	const query = `
+SELECT ` + gamesColumns + `
+FROM games
+WHERE table_uuid = $1
+  AND ended IS NOT NULL
+  AND settled IS NULL
+  AND balance_adjustments IS NOT NULL
+  AND settlement_attempts < $2
+ORDER BY id`
+
+	rows, err := db.Instance().QueryContext(ctx, query, t.UUID, MaxSettlementAttempts)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	games := make([]*Game, 0)
+	for rows.Next() {
+		g, err := gameByRow(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		games = append(games, g)
+	}
+
+	return games, rows.Err()
 }
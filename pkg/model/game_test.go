@@ -1,9 +1,12 @@
 package model
 
 import (
-	"github.com/stretchr/testify/assert"
+	"fmt"
+	"mondaynightpoker-server/pkg/db"
 	"testing"
 	"time"
+
+	"github.com/stretchr/testify/assert"
 )
 
 func TestGame_EndGame(t *testing.T) {
@@ -41,3 +44,142 @@ func playerTableAndGame() (*Player, *Table, *Game) {
 
 	return p, tbl, game
 }
+
+func TestTable_GetEndedGames(t *testing.T) {
+	a := assert.New(t)
+
+	_, table, game := playerTableAndGame()
+	unfinished, err := table.CreateGame(cbg, "bourre")
+	a.NoError(err)
+
+	games, err := table.GetEndedGames(cbg, 0, 10)
+	a.NoError(err)
+	a.Equal(0, len(games))
+
+	a.NoError(game.EndGame(cbg, map[string]int{"pot": 100}, nil))
+
+	games, err = table.GetEndedGames(cbg, 0, 10)
+	a.NoError(err)
+	a.Equal(1, len(games))
+	a.Equal(game.ID, games[0].ID)
+	a.NotEqual(unfinished.ID, games[0].ID)
+
+	data, err := games[0].Data()
+	a.NoError(err)
+	a.JSONEq(`{"pot":100}`, string(data))
+
+	games, err = table.GetEndedGames(cbg, 1, 10)
+	a.NoError(err)
+	a.Equal(0, len(games))
+}
+
+func TestGetGameParticipants(t *testing.T) {
+	a := assert.New(t)
+
+	p1, table := playerAndTable()
+	p2 := player()
+	pt2, _ := p2.JoinAtSeat(cbg, table, 7)
+	pt1, _ := p1.GetPlayerTable(cbg, table)
+
+	game, err := table.StartGame(cbg, "bourre", []*GameParticipant{
+		{PlayerID: p2.ID, Seat: *pt2.Seat, StartingStake: 1000},
+		{PlayerID: p1.ID, Seat: *pt1.Seat, StartingStake: 2000},
+	})
+	a.NoError(err)
+	other, _ := table.CreateGame(cbg, "bourre")
+
+	participants, err := GetGameParticipants(cbg, game)
+	a.NoError(err)
+	if a.Equal(2, len(participants[game.ID])) {
+		a.Equal(p1.ID, participants[game.ID][0].PlayerID)
+		a.Equal(1, participants[game.ID][0].Seat)
+		a.Equal(2000, participants[game.ID][0].StartingStake)
+		a.Equal(p2.ID, participants[game.ID][1].PlayerID)
+		a.Equal(7, participants[game.ID][1].Seat)
+		a.Equal(GameResult(""), participants[game.ID][1].Result)
+	}
+
+	// the stored seat is the seat the player took at the table
+	var seat int
+	a.NoError(db.Instance().QueryRow(`SELECT seat FROM players_tables WHERE id = $1`, pt2.ID).Scan(&seat))
+	a.Equal(seat, participants[game.ID][1].Seat)
+
+	a.NoError(game.EndGame(cbg, map[string]int{"pot": 50}, map[int64]int{p1.ID: 25, p2.ID: -25}))
+	a.NoError(other.EndGame(cbg, map[string]int{"pot": 0}, nil))
+
+	participants, err = GetGameParticipants(cbg, game, other)
+	a.NoError(err)
+	if a.Equal(2, len(participants[game.ID])) {
+		a.Equal(p1.ID, participants[game.ID][0].PlayerID)
+		a.Equal(p1.DisplayName, participants[game.ID][0].DisplayName)
+		a.Equal(25, participants[game.ID][0].Adjustment)
+		a.Equal(GameResultWon, participants[game.ID][0].Result)
+		a.Equal(p2.ID, participants[game.ID][1].PlayerID)
+		a.Equal(-25, participants[game.ID][1].Adjustment)
+		a.Equal(GameResultLost, participants[game.ID][1].Result)
+	}
+
+	a.NotNil(participants[other.ID])
+	a.Empty(participants[other.ID])
+}
+
+func TestGame_Abandon(t *testing.T) {
+	a := assert.New(t)
+
+	p, table := playerAndTable()
+	game, err := table.StartGame(cbg, "bourre", []*GameParticipant{{PlayerID: p.ID, StartingStake: 2000}})
+	a.NoError(err)
+
+	a.NoError(game.Abandon(cbg))
+	a.True(game.Abandoned)
+	a.False(game.Ended.IsZero())
+
+	g2, err := GameByID(cbg, game.ID)
+	a.NoError(err)
+	a.True(g2.Abandoned)
+
+	participants, _ := GetGameParticipants(cbg, game)
+	if a.Equal(1, len(participants[game.ID])) {
+		a.Equal(GameResultAbandoned, participants[game.ID][0].Result)
+	}
+
+	// a game can only be abandoned once
+	a.Error(game.Abandon(cbg))
+}
+
+func TestGame_Settle(t *testing.T) {
+	a := assert.New(t)
+
+	player, table, game := playerTableAndGame()
+
+	// a game that ended without its balances being adjusted
+	a.NoError(game.EndGame(cbg, map[string]int{"pot": 100}, nil))
+	_, err := db.Instance().Exec(`UPDATE games SET balance_adjustments = $1 WHERE id = $2`, fmt.Sprintf(`{"%d": 150}`, player.ID), game.ID)
+	a.NoError(err)
+
+	games, err := table.GetUnsettledGames(cbg)
+	a.NoError(err)
+	if a.Equal(1, len(games)) {
+		a.Equal(game.ID, games[0].ID)
+	}
+
+	// a game that failed to settle too many times is left for an admin
+	_, err = db.Instance().Exec(`UPDATE games SET settlement_attempts = $1 WHERE id = $2`, MaxSettlementAttempts, game.ID)
+	a.NoError(err)
+	games, err = table.GetUnsettledGames(cbg)
+	a.NoError(err)
+	a.Empty(games)
+
+	a.NoError(game.Settle(cbg))
+	pt, _ := player.GetPlayerTable(cbg, table)
+	a.Equal(150, pt.Balance)
+
+	// settling is keyed by the game, so the balance is only adjusted once
+	a.NoError(game.Settle(cbg))
+	pt, _ = player.GetPlayerTable(cbg, table)
+	a.Equal(150, pt.Balance)
+
+	games, err = table.GetUnsettledGames(cbg)
+	a.NoError(err)
+	a.Empty(games)
+}
@@ -0,0 +1,108 @@
+package model
+
+import (
+	"context"
+	"database/sql"
+	"mondaynightpoker-server/pkg/db"
+	"time"
+)
+
+// LedgerEntry is a record in the players_tables_transactions table
+type LedgerEntry struct {
+	ID         int64  `json:"id"`
+	PlayerID   int64  `json:"playerId"`
+	PlayerName string `json:"playerName"`
+	TableUUID  string `json:"tableUuid"`
+	TableName  string `json:"tableName"`
+
+	// GameID and GameType are empty if the balance was not changed by a game
+	GameID   int64  `json:"gameId,omitempty"`
+	GameType string `json:"gameType,omitempty"`
+
+	Adjustment      int       `json:"adjustment"`
+	PreviousBalance int       `json:"previousBalance"`
+	CurrentBalance  int       `json:"currentBalance"`
+	Reason          string    `json:"reason"`
+	Created         time.Time `json:"created"`
+}
+
+// LedgerRange limits the ledger to the entries created on or after From and before To
+// A zero time is not limited
+type LedgerRange struct {
+	From time.Time
+	To   time.Time
+}
+
+func (l LedgerRange) args() (from, to sql.NullTime) {
+	return sql.NullTime{Time: l.From.UTC(), Valid: !l.From.IsZero()}, sql.NullTime{Time: l.To.UTC(), Valid: !l.To.IsZero()}
+}
+
+const ledgerQuery = `
+SELECT players_tables_transactions.id,
+       players_tables.player_id,
+       players.display_name,
+       players_tables.table_uuid,
+       tables.name,
+       players_tables_transactions.game_id,
+       games.game_type::text,
+       players_tables_transactions.adjustment,
+       players_tables_transactions.previous_balance,
+       players_tables_transactions.current_balance,
+       players_tables_transactions.reason,
+       players_tables_transactions.created
+FROM players_tables_transactions
+INNER JOIN players_tables ON players_tables_transactions.players_tables_id = players_tables.id
+INNER JOIN players ON players_tables.player_id = players.id
+INNER JOIN tables ON players_tables.table_uuid = tables.uuid
+LEFT JOIN games ON players_tables_transactions.game_id = games.id
+`
+
+const ledgerRangeQuery = `
+  AND ($2::timestamp IS NULL OR players_tables_transactions.created >= $2)
+  AND ($3::timestamp IS NULL OR players_tables_transactions.created < $3)
+ORDER BY players_tables_transactions.id`
+
+// GetLedger returns every change to the balances of the players at the table, oldest first
+func (t *Table) GetLedger(ctx context.Context, r LedgerRange) ([]*LedgerEntry, error) {
+	const query = ledgerQuery + `WHERE players_tables.table_uuid = $1` + ledgerRangeQuery
+
+	from, to := r.args()
+	return getLedger(ctx, query, t.UUID, from, to)
+}
+
+// GetLedger returns every change to the player's balances at all of their tables, oldest first
+func (p *Player) GetLedger(ctx context.Context, r LedgerRange) ([]*LedgerEntry, error) {
+	const query = ledgerQuery + `WHERE players_tables.player_id = $1` + ledgerRangeQuery
+
+	from, to := r.args()
+	return getLedger(ctx, query, p.ID, from, to)
+}
+
+func getLedger(ctx context.Context, query string, args ...interface{}) ([]*LedgerEntry, error) {
+	rows, err := db.Instance().QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	entries := make([]*LedgerEntry, 0)
+	for rows.Next() {
+		var e LedgerEntry
+		var playerName, tableName, gameType, reason sql.NullString
+		var gameID sql.NullInt64
+
+		if err := rows.Scan(&e.ID, &e.PlayerID, &playerName, &e.TableUUID, &tableName, &gameID, &gameType,
+			&e.Adjustment, &e.PreviousBalance, &e.CurrentBalance, &reason, &e.Created); err != nil {
+			return nil, err
+		}
+
+		e.PlayerName = playerName.String
+		e.TableName = tableName.String
+		e.GameID = gameID.Int64
+		e.GameType = gameType.String
+		e.Reason = reason.String
+		entries = append(entries, &e)
+	}
+
+	return entries, rows.Err()
+}
@@ -0,0 +1,53 @@
+package model
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTable_GetLedger(t *testing.T) {
+	p1, tbl := playerAndTable()
+	p2 := player()
+	pt1, _ := p1.GetPlayerTable(cbg, tbl)
+	pt2, _ := p2.Join(cbg, tbl)
+
+	game, _ := tbl.CreateGame(cbg, "bourre")
+	assert.NoError(t, pt1.AdjustBalance(cbg, 25, "won pot", game))
+	assert.NoError(t, pt2.AdjustBalance(cbg, -25, "lost pot", game))
+	assert.NoError(t, RecordTransfer(cbg, pt2, pt1, 25))
+
+	entries, err := tbl.GetLedger(cbg, LedgerRange{})
+	assert.NoError(t, err)
+	if assert.Equal(t, 4, len(entries)) {
+		assert.Equal(t, p1.ID, entries[0].PlayerID)
+		assert.Equal(t, p1.DisplayName, entries[0].PlayerName)
+		assert.Equal(t, tbl.Name, entries[0].TableName)
+		assert.Equal(t, game.ID, entries[0].GameID)
+		assert.Equal(t, "bourre", entries[0].GameType)
+		assert.Equal(t, 25, entries[0].Adjustment)
+		assert.Equal(t, 0, entries[0].PreviousBalance)
+		assert.Equal(t, 25, entries[0].CurrentBalance)
+		assert.Equal(t, "won pot", entries[0].Reason)
+
+		assert.Equal(t, int64(0), entries[2].GameID)
+		assert.Equal(t, "", entries[2].GameType)
+	}
+
+	entries, err = tbl.GetLedger(cbg, LedgerRange{From: time.Now().Add(time.Hour)})
+	assert.NoError(t, err)
+	assert.Empty(t, entries)
+
+	entries, err = tbl.GetLedger(cbg, LedgerRange{From: time.Now().Add(-time.Hour), To: time.Now().Add(time.Hour)})
+	assert.NoError(t, err)
+	assert.Equal(t, 4, len(entries))
+
+	entries, err = p2.GetLedger(cbg, LedgerRange{})
+	assert.NoError(t, err)
+	if assert.Equal(t, 2, len(entries)) {
+		assert.Equal(t, -25, entries[0].Adjustment)
+		assert.Equal(t, 25, entries[1].Adjustment)
+		assert.Equal(t, 0, entries[1].CurrentBalance)
+	}
+}
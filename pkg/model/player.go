@@ -229,22 +229,43 @@ WHERE players_tables.player_id = $1 AND players_tables.table_uuid = $2`
 	return pt, nil
 }
 
-// Join joins the table
+// Join joins the table in the lowest seat that is free
+// If every seat is taken, the player joins without a seat and is not dealt in until they take one
 func (p *Player) Join(ctx context.Context, table *Table) (*PlayerTable, error) {
+	return p.JoinAtSeat(ctx, table, 0)
+}
+
+// JoinAtSeat joins the table in the seat, or in the lowest seat that is free if the seat is zero
+// ErrSeatTaken is returned if another player has the seat
+func (p *Player) JoinAtSeat(ctx context.Context, table *Table, seat int) (*PlayerTable, error) {
+	if seat != 0 {
+		if err := ValidateSeat(seat); err != nil {
+			return nil, err
+		}
+	}
+
 	const query = `
 WITH pt AS (
-	INSERT INTO players_tables (player_id, table_uuid)
-	VALUES ($1, $2)
+	INSERT INTO players_tables (player_id, table_uuid, seat)
+	VALUES ($1, $2, COALESCE(NULLIF($3::int, 0), (
+		SELECT MIN(s)
+		FROM generate_series(1, $4::int) AS s
+		WHERE s NOT IN (SELECT seat FROM players_tables WHERE table_uuid = $2 AND seat IS NOT NULL)
+	)))
 	RETURNING *
 )
 SELECT ` + playerColumns + `, ` + playerTableColumns + `
 FROM pt AS players_tables
 INNER JOIN players ON players_tables.player_id = players.id
 `
-	row := db.Instance().QueryRowContext(ctx, query, p.ID, table.UUID)
+	row := db.Instance().QueryRowContext(ctx, query, p.ID, table.UUID, seat, TableSeats)
 
 	pt, err := getPlayerTableByRow(row)
 	if err != nil {
+		if isSeatTakenError(err) {
+			return nil, ErrSeatTaken
+		}
+
 		if err, ok := err.(*pq.Error); ok && err.Code == pqDuplicateKeyErrorCode {
 			return nil, ErrDuplicateKey
 		}
@@ -2,8 +2,12 @@ package model
 
 import (
 	"context"
+	"fmt"
 	"mondaynightpoker-server/pkg/db"
+	"strings"
 	"time"
+
+	"github.com/lib/pq"
 )
 
 const playerTableColumns = `
@@ -18,6 +22,8 @@ players_tables.balance,
 players_tables.table_stake,
 players_tables.active,
 players_tables.is_blocked,
+players_tables.credit_limit,
+players_tables.seat,
 players_tables.created,
 players_tables.updated`
 
@@ -37,15 +43,33 @@ type PlayerTable struct {
 	IsBlocked    bool      `json:"isBlocked"`
 	Created      time.Time `json:"created"`
 	Updated      time.Time `json:"updated"`
+
+	// CreditLimit overrides the table's credit limit for the player
+	CreditLimit *int `json:"creditLimit"`
+
+	// Seat is the player's seat at the table, nil if the player does not have a seat
+	Seat *int `json:"seat"`
+
+	// stakeLimit caps the table stake so the player cannot lose more than their credit limit allows
+	stakeLimit *int
 }
 
+// TableSeats is the number of seats at a table
+const TableSeats = 10
+
+// ErrSeatTaken is returned when a player tries to take a seat that another player has
+var ErrSeatTaken = UserError("the seat is taken")
+
+// seatConstraint is the name of the unique index on the seats at a table
+const seatConstraint = "players_tables_seat_idx"
+
 func getPlayerTableByRow(row db.Scanner) (*PlayerTable, error) {
 	var p Player
 	var pt PlayerTable
 
 	if err := row.Scan(&p.ID, &p.Email, &p.DisplayName, &p.IsSiteAdmin, &p.Status, &p.passwordHash, &p.Created, &p.Updated,
 		&pt.ID, &pt.PlayerID, &pt.TableUUID, &pt.IsTableAdmin, &pt.CanStart, &pt.CanRestart, &pt.CanTerminate,
-		&pt.Balance, &pt.TableStake, &pt.Active, &pt.IsBlocked, &pt.Created, &pt.Updated); err != nil {
+		&pt.Balance, &pt.TableStake, &pt.Active, &pt.IsBlocked, &pt.CreditLimit, &pt.Seat, &pt.Created, &pt.Updated); err != nil {
 		return nil, err
 	}
 
@@ -72,6 +96,93 @@ func (p *PlayerTable) AdjustBalance(ctx context.Context, byAmount int, reason st
 	return nil
 }
 
+// RecordTransfer records that a player paid another player at the table to settle their balances
+// The amount is added to the balance of the player who paid, and taken from the balance of the player who was paid
+func RecordTransfer(ctx context.Context, from, to *PlayerTable, amount int) error {
+	if amount <= 0 {
+		return UserError("the amount must be more than ${0}")
+	}
+
+	if from.TableUUID != to.TableUUID || from.ID == to.ID {
+		return UserError("the transfer must be between two players at the same table")
+	}
+
+	tx, err := db.Instance().BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	const query = `SELECT adjust_balance($1, $2, $3, NULL, $4)`
+	if _, err := tx.ExecContext(ctx, query, from.ID, from.Balance, amount, "settlement: paid "+to.displayName()); err != nil {
+		rollback(tx)
+		return err
+	}
+
+	if _, err := tx.ExecContext(ctx, query, to.ID, to.Balance, -amount, "settlement: received from "+from.displayName()); err != nil {
+		rollback(tx)
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+
+	from.Balance += amount
+	to.Balance -= amount
+	return nil
+}
+
+// kinds of balance adjustments a table admin can make
+const (
+	AdjustmentBuyIn      = "buyIn"
+	AdjustmentCashOut    = "cashOut"
+	AdjustmentCorrection = "correction"
+)
+
+// RecordAdjustment records a buy-in, cash-out or correction that a table admin made to the player's balance
+// A buy-in adds the amount to the balance and a cash-out takes it away. A correction can be positive or negative.
+// The reason is required, and the ledger records it along with the admin who made the adjustment
+func (p *PlayerTable) RecordAdjustment(ctx context.Context, admin *Player, kind string, amount int, reason string) error {
+	reason = strings.TrimSpace(reason)
+	if reason == "" {
+		return UserError("a reason is required")
+	}
+
+	var label string
+	switch kind {
+	case AdjustmentBuyIn:
+		label = "buy-in"
+	case AdjustmentCashOut:
+		label = "cash-out"
+	case AdjustmentCorrection:
+		label = "correction"
+	default:
+		return UserError(fmt.Sprintf("unknown adjustment: %s", kind))
+	}
+
+	if kind == AdjustmentCorrection {
+		if amount == 0 {
+			return UserError("the correction cannot be ${0}")
+		}
+	} else if amount <= 0 {
+		return UserError(fmt.Sprintf("the %s must be more than ${0}", label))
+	}
+
+	if kind == AdjustmentCashOut {
+		amount = -amount
+	}
+
+	return p.AdjustBalance(ctx, amount, fmt.Sprintf("%s by %s: %s", label, admin.DisplayName, reason), nil)
+}
+
+func (p *PlayerTable) displayName() string {
+	if p.Player != nil && p.Player.DisplayName != "" {
+		return p.Player.DisplayName
+	}
+
+	return fmt.Sprintf("player %d", p.PlayerID)
+}
+
 // Save will save non-balance values
 func (p *PlayerTable) Save(ctx context.Context) error {
 	const query = `
@@ -83,10 +194,11 @@ SET active = $1,
     can_restart = $5,
     can_terminate = $6,
     is_blocked = $7,
+    credit_limit = $8,
     updated = (NOW() AT TIME ZONE 'utc')
-WHERE id = $8`
+WHERE id = $9`
 
-	_, err := db.Instance().ExecContext(ctx, query, p.Active, p.TableStake, p.IsTableAdmin, p.CanStart, p.CanRestart, p.CanTerminate, p.IsBlocked, p.ID)
+	_, err := db.Instance().ExecContext(ctx, query, p.Active, p.TableStake, p.IsTableAdmin, p.CanStart, p.CanRestart, p.CanTerminate, p.IsBlocked, p.CreditLimit, p.ID)
 	return err
 }
 
@@ -103,11 +215,103 @@ func (p *PlayerTable) GetPlayerID() int64 {
 
 // GetTableStake returns the table stake
 // This method returns the player's balance, unless their balance is below their table stake. In that case,
-// it returns the table stake.
+// it returns the table stake. Either is capped by LimitTableStake
 func (p *PlayerTable) GetTableStake() int {
-	if p.Balance > p.TableStake {
-		return p.Balance
+	stake := p.TableStake
+	if p.Balance > stake {
+		stake = p.Balance
 	}
 
-	return p.TableStake
+	if p.stakeLimit != nil && *p.stakeLimit < stake {
+		return *p.stakeLimit
+	}
+
+	return stake
+}
+
+// EffectiveCreditLimit returns the player's credit limit at the table
+// The player's credit limit overrides the table's. If nil, there is no limit
+func (p *PlayerTable) EffectiveCreditLimit(table *Table) *int {
+	if p.CreditLimit != nil {
+		return p.CreditLimit
+	}
+
+	if table != nil {
+		return table.CreditLimit
+	}
+
+	return nil
+}
+
+// AvailableCredit returns how much the player can lose before they owe more than their credit limit
+// Returns false if the player does not have a credit limit
+func (p *PlayerTable) AvailableCredit(table *Table) (int, bool) {
+	limit := p.EffectiveCreditLimit(table)
+	if limit == nil {
+		return 0, false
+	}
+
+	return p.Balance + *limit, true
+}
+
+// AtCreditLimit returns true if the player cannot lose anything without owing more than their credit limit
+// A credit limit of zero lets a player play with what they have won
+func (p *PlayerTable) AtCreditLimit(table *Table) bool {
+	available, ok := p.AvailableCredit(table)
+	return ok && available <= 0
+}
+
+// LimitTableStake caps the player's table stake at their available credit, so they cannot lose more than
+// their credit limit allows in a single game
+func (p *PlayerTable) LimitTableStake(table *Table) {
+	if available, ok := p.AvailableCredit(table); ok {
+		p.stakeLimit = &available
+	}
+}
+
+// ValidateSeat returns an error if the seat is not at the table
+func ValidateSeat(seat int) error {
+	if seat < 1 || seat > TableSeats {
+		return UserError(fmt.Sprintf("the seat must be between 1 and %d", TableSeats))
+	}
+
+	return nil
+}
+
+// SetSeat moves the player to the seat
+// ErrSeatTaken is returned if another player has the seat
+func (p *PlayerTable) SetSeat(ctx context.Context, seat int) error {
+	if err := ValidateSeat(seat); err != nil {
+		return err
+	}
+
+	const query = `
+UPDATE players_tables
+SET seat = $1, updated = (NOW() AT TIME ZONE 'UTC')
+WHERE id = $2`
+	if _, err := db.Instance().ExecContext(ctx, query, seat, p.ID); err != nil {
+		if isSeatTakenError(err) {
+			return ErrSeatTaken
+		}
+
+		return err
+	}
+
+	p.Seat = &seat
+	return nil
+}
+
+func isSeatTakenError(err error) bool {
+	pqErr, ok := err.(*pq.Error)
+	return ok && pqErr.Code == pqDuplicateKeyErrorCode && pqErr.Constraint == seatConstraint
+}
+
+// ValidateCreditLimit returns an error if the credit limit cannot be used
+// A nil credit limit removes the limit
+func ValidateCreditLimit(creditLimit *int) error {
+	if creditLimit != nil && (*creditLimit < 0 || *creditLimit%25 > 0) {
+		return UserError("the credit limit must be in increments of ${25}")
+	}
+
+	return nil
 }
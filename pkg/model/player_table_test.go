@@ -23,7 +23,9 @@ func TestPlayerTable_Save(t *testing.T) {
 	assert.False(t, pt2.CanRestart)
 	assert.False(t, pt2.CanTerminate)
 	assert.False(t, pt2.IsBlocked)
+	assert.Nil(t, pt2.CreditLimit)
 
+	creditLimit := 5000
 	pt2.Active = false
 	pt2.TableStake = 3000
 	pt2.IsTableAdmin = true
@@ -31,6 +33,7 @@ func TestPlayerTable_Save(t *testing.T) {
 	pt2.CanRestart = true
 	pt2.CanTerminate = true
 	pt2.IsBlocked = true
+	pt2.CreditLimit = &creditLimit
 	assert.NoError(t, pt2.Save(cbg))
 
 	pt2, err = p2.GetPlayerTable(cbg, tbl)
@@ -42,6 +45,9 @@ func TestPlayerTable_Save(t *testing.T) {
 	assert.True(t, pt2.CanRestart)
 	assert.True(t, pt2.CanTerminate)
 	assert.True(t, pt2.IsBlocked)
+	if assert.NotNil(t, pt2.CreditLimit) {
+		assert.Equal(t, 5000, *pt2.CreditLimit)
+	}
 }
 
 func TestPlayerTable_AdjustBalance(t *testing.T) {
@@ -72,6 +78,61 @@ func TestPlayerTable_AdjustBalance(t *testing.T) {
 	assert.Equal(t, -10, pt2.Balance)
 }
 
+func TestRecordTransfer(t *testing.T) {
+	a := assert.New(t)
+
+	p1, tbl := playerAndTable()
+	p2 := player()
+	_, _ = p2.Join(cbg, tbl)
+
+	pt1, _ := p1.GetPlayerTable(cbg, tbl)
+	pt2, _ := p2.GetPlayerTable(cbg, tbl)
+	a.NoError(pt1.AdjustBalance(cbg, -500, "lost pot", nil))
+	a.NoError(pt2.AdjustBalance(cbg, 500, "won pot", nil))
+
+	a.EqualError(RecordTransfer(cbg, pt1, pt2, 0), "the amount must be more than ${0}")
+	a.EqualError(RecordTransfer(cbg, pt1, pt1, 100), "the transfer must be between two players at the same table")
+
+	a.NoError(RecordTransfer(cbg, pt1, pt2, 200))
+	a.Equal(-300, pt1.Balance)
+	a.Equal(300, pt2.Balance)
+
+	pt1, _ = p1.GetPlayerTable(cbg, tbl)
+	pt2, _ = p2.GetPlayerTable(cbg, tbl)
+	a.Equal(-300, pt1.Balance)
+	a.Equal(300, pt2.Balance)
+
+	// the balance changed since it was loaded
+	pt1.Balance = 0
+	a.Error(RecordTransfer(cbg, pt1, pt2, 300))
+}
+
+func TestPlayerTable_RecordAdjustment(t *testing.T) {
+	a := assert.New(t)
+
+	p1, tbl := playerAndTable()
+	pt, _ := p1.GetPlayerTable(cbg, tbl)
+
+	a.EqualError(pt.RecordAdjustment(cbg, p1, AdjustmentBuyIn, 500, " "), "a reason is required")
+	a.EqualError(pt.RecordAdjustment(cbg, p1, AdjustmentBuyIn, 0, "cash"), "the buy-in must be more than ${0}")
+	a.EqualError(pt.RecordAdjustment(cbg, p1, AdjustmentCashOut, -100, "cash"), "the cash-out must be more than ${0}")
+	a.EqualError(pt.RecordAdjustment(cbg, p1, AdjustmentCorrection, 0, "typo"), "the correction cannot be ${0}")
+	a.EqualError(pt.RecordAdjustment(cbg, p1, "refund", 100, "typo"), "unknown adjustment: refund")
+
+	a.NoError(pt.RecordAdjustment(cbg, p1, AdjustmentBuyIn, 2000, "cash"))
+	a.NoError(pt.RecordAdjustment(cbg, p1, AdjustmentCashOut, 500, "venmo"))
+	a.NoError(pt.RecordAdjustment(cbg, p1, AdjustmentCorrection, -25, "miscounted the pot"))
+	a.Equal(1475, pt.Balance)
+
+	entries, err := tbl.GetLedger(cbg, LedgerRange{})
+	a.NoError(err)
+	if a.Equal(3, len(entries)) {
+		a.Equal("buy-in by "+p1.DisplayName+": cash", entries[0].Reason)
+		a.Equal(-500, entries[1].Adjustment)
+		a.Equal("correction by "+p1.DisplayName+": miscounted the pot", entries[2].Reason)
+	}
+}
+
 func TestPlayerTable_IsPlaying(t *testing.T) {
 	pt := &PlayerTable{
 		Active:    true,
@@ -95,3 +156,65 @@ func TestPlayerTable_accessors(t *testing.T) {
 	pt.Balance = 10
 	assert.Equal(t, 10, pt.GetTableStake())
 }
+
+func TestPlayerTable_AtCreditLimit(t *testing.T) {
+	a := assert.New(t)
+
+	tableLimit := 1000
+	playerLimit := 2000
+	tbl := &Table{}
+	pt := &PlayerTable{Balance: -1500}
+
+	a.Nil(pt.EffectiveCreditLimit(tbl))
+	a.False(pt.AtCreditLimit(tbl))
+	a.False(pt.AtCreditLimit(nil))
+
+	tbl.CreditLimit = &tableLimit
+	a.True(pt.AtCreditLimit(tbl))
+
+	// the player's limit overrides the table's
+	pt.CreditLimit = &playerLimit
+	a.Equal(&playerLimit, pt.EffectiveCreditLimit(tbl))
+	a.False(pt.AtCreditLimit(tbl))
+
+	// a player who owes exactly their limit has no credit left
+	pt.Balance = -2000
+	a.True(pt.AtCreditLimit(tbl))
+
+	pt.Balance = -2025
+	a.True(pt.AtCreditLimit(tbl))
+}
+
+func TestPlayerTable_LimitTableStake(t *testing.T) {
+	a := assert.New(t)
+
+	creditLimit := 500
+	pt := &PlayerTable{Balance: -200, TableStake: 1000}
+
+	// without a credit limit, the table stake is not capped
+	pt.LimitTableStake(&Table{})
+	a.Equal(1000, pt.GetTableStake())
+
+	pt.CreditLimit = &creditLimit
+	available, ok := pt.AvailableCredit(nil)
+	a.True(ok)
+	a.Equal(300, available)
+
+	pt.LimitTableStake(nil)
+	a.Equal(300, pt.GetTableStake())
+
+	// a player with winnings can lose them and their credit
+	pt = &PlayerTable{Balance: 2000, TableStake: 1000, CreditLimit: &creditLimit}
+	pt.LimitTableStake(nil)
+	a.Equal(2000, pt.GetTableStake())
+}
+
+func TestValidateCreditLimit(t *testing.T) {
+	a := assert.New(t)
+
+	valid, negative, uneven := 500, -25, 510
+	a.NoError(ValidateCreditLimit(nil))
+	a.NoError(ValidateCreditLimit(&valid))
+	a.EqualError(ValidateCreditLimit(&negative), "the credit limit must be in increments of ${25}")
+	a.EqualError(ValidateCreditLimit(&uneven), "the credit limit must be in increments of ${25}")
+}
@@ -0,0 +1,243 @@
+package model
+
+import (
+	"context"
+	"database/sql"
+	"mondaynightpoker-server/pkg/db"
+)
+
+// PlayerStats are a player's statistics, summed over the games they played
+// The rates are derived from the counts when the statistics are read
+type PlayerStats struct {
+	GamesPlayed int `json:"gamesPlayed"`
+	// Net is the amount won less the amount lost
+	Net        int `json:"net"`
+	BiggestPot int `json:"biggestPot"`
+
+	// the statistics below are only recorded for games with a hand history
+	Showdowns    int `json:"showdowns"`
+	ShowdownsWon int `json:"showdownsWon"`
+	Hands        int `json:"hands"`
+	VPIPHands    int `json:"vpipHands"`
+	PFRHands     int `json:"pfrHands"`
+	BetsRaises   int `json:"betsRaises"`
+	Calls        int `json:"calls"`
+
+	// ShowdownWinRate is the fraction of showdowns won
+	ShowdownWinRate float64 `json:"showdownWinRate"`
+	// VPIP is the fraction of hands the player voluntarily put money into the pot before the flop
+	VPIP float64 `json:"vpip"`
+	// PFR is the fraction of hands the player bet or raised before the flop
+	PFR float64 `json:"pfr"`
+	// Aggression is the number of bets and raises for every call
+	Aggression float64 `json:"aggression"`
+}
+
+// GameTypeStats are the player's statistics in one type of game
+type GameTypeStats struct {
+	GameType string `json:"gameType"`
+	PlayerStats
+}
+
+// TableStats are the player's statistics at one table
+type TableStats struct {
+	TableUUID string `json:"tableUuid"`
+	TableName string `json:"tableName"`
+	PlayerStats
+}
+
+// PlayerProfile is the player's lifetime statistics
+type PlayerProfile struct {
+	PlayerID    int64            `json:"playerId"`
+	DisplayName string           `json:"displayName"`
+	Lifetime    *PlayerStats     `json:"lifetime"`
+	GameTypes   []*GameTypeStats `json:"gameTypes"`
+	Tables      []*TableStats    `json:"tables"`
+}
+
+// LeaderboardEntry is a player's statistics at a table
+type LeaderboardEntry struct {
+	PlayerID    int64  `json:"playerId"`
+	DisplayName string `json:"displayName"`
+	PlayerStats
+}
+
+const playerStatsColumns = `
+       COALESCE(SUM(player_stats.games_played), 0),
+       COALESCE(SUM(player_stats.net), 0),
+       COALESCE(MAX(player_stats.biggest_pot), 0),
+       COALESCE(SUM(player_stats.showdowns), 0),
+       COALESCE(SUM(player_stats.showdowns_won), 0),
+       COALESCE(SUM(player_stats.hands), 0),
+       COALESCE(SUM(player_stats.vpip), 0),
+       COALESCE(SUM(player_stats.pfr), 0),
+       COALESCE(SUM(player_stats.bets_raises), 0),
+       COALESCE(SUM(player_stats.calls), 0)`
+
+func (s *PlayerStats) scanDest() []interface{} {
+	return []interface{}{
+		&s.GamesPlayed, &s.Net, &s.BiggestPot, &s.Showdowns, &s.ShowdownsWon,
+		&s.Hands, &s.VPIPHands, &s.PFRHands, &s.BetsRaises, &s.Calls,
+	}
+}
+
+func (s *PlayerStats) calculateRates() {
+	s.ShowdownWinRate = ratio(s.ShowdownsWon, s.Showdowns)
+	s.VPIP = ratio(s.VPIPHands, s.Hands)
+	s.PFR = ratio(s.PFRHands, s.Hands)
+	s.Aggression = ratio(s.BetsRaises, s.Calls)
+}
+
+func ratio(n, d int) float64 {
+	if d == 0 {
+		return 0
+	}
+
+	return float64(n) / float64(d)
+}
+
+// AddPlayerStats adds the statistics of a game to the statistics of each player at the table
+// stats is keyed by player ID
+func (t *Table) AddPlayerStats(ctx context.Context, gameType string, stats map[int64]*PlayerStats) error {
+	tx, err := db.Instance().BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	const query = `
+INSERT INTO player_stats (player_id, table_uuid, game_type, games_played, net, biggest_pot, showdowns,
+                          showdowns_won, hands, vpip, pfr, bets_raises, calls)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
+ON CONFLICT (player_id, table_uuid, game_type) DO UPDATE
+    SET games_played  = player_stats.games_played + excluded.games_played,
+        net           = player_stats.net + excluded.net,
+        biggest_pot   = GREATEST(player_stats.biggest_pot, excluded.biggest_pot),
+        showdowns     = player_stats.showdowns + excluded.showdowns,
+        showdowns_won = player_stats.showdowns_won + excluded.showdowns_won,
+        hands         = player_stats.hands + excluded.hands,
+        vpip          = player_stats.vpip + excluded.vpip,
+        pfr           = player_stats.pfr + excluded.pfr,
+        bets_raises   = player_stats.bets_raises + excluded.bets_raises,
+        calls         = player_stats.calls + excluded.calls,
+        updated       = (NOW() AT TIME ZONE 'utc')`
+
+	stmt, err := tx.PrepareContext(ctx, query)
+	if err != nil {
+		rollback(tx)
+		return err
+	}
+
+	for playerID, s := range stats {
+		if _, err := stmt.ExecContext(ctx, playerID, t.UUID, gameType, s.GamesPlayed, s.Net, s.BiggestPot, s.Showdowns,
+			s.ShowdownsWon, s.Hands, s.VPIPHands, s.PFRHands, s.BetsRaises, s.Calls); err != nil {
+			rollback(tx)
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// GetStatsProfile returns the player's lifetime statistics, and their statistics by game type and by table
+func (p *Player) GetStatsProfile(ctx context.Context) (*PlayerProfile, error) {
+	profile := &PlayerProfile{
+		PlayerID:    p.ID,
+		DisplayName: p.DisplayName,
+		Lifetime:    &PlayerStats{},
+		GameTypes:   make([]*GameTypeStats, 0),
+		Tables:      make([]*TableStats, 0),
+	}
+
+	const lifetimeQuery = `SELECT` + playerStatsColumns + `
+FROM player_stats
+WHERE player_id = $1`
+	row := db.Instance().QueryRowContext(ctx, lifetimeQuery, p.ID)
+	if err := row.Scan(profile.Lifetime.scanDest()...); err != nil {
+		return nil, err
+	}
+	profile.Lifetime.calculateRates()
+
+	const gameTypesQuery = `SELECT player_stats.game_type,` + playerStatsColumns + `
+FROM player_stats
+WHERE player_id = $1
+GROUP BY player_stats.game_type
+ORDER BY player_stats.game_type`
+	rows, err := db.Instance().QueryContext(ctx, gameTypesQuery, p.ID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var s GameTypeStats
+		if err := rows.Scan(append([]interface{}{&s.GameType}, s.scanDest()...)...); err != nil {
+			return nil, err
+		}
+
+		s.calculateRates()
+		profile.GameTypes = append(profile.GameTypes, &s)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	const tablesQuery = `SELECT tables.uuid, tables.name,` + playerStatsColumns + `
+FROM player_stats
+INNER JOIN tables ON player_stats.table_uuid = tables.uuid
+WHERE player_stats.player_id = $1
+  AND tables.deleted = false
+GROUP BY tables.uuid, tables.name
+ORDER BY tables.name, tables.uuid`
+	tableRows, err := db.Instance().QueryContext(ctx, tablesQuery, p.ID)
+	if err != nil {
+		return nil, err
+	}
+	defer tableRows.Close()
+
+	for tableRows.Next() {
+		var s TableStats
+		var tableName sql.NullString
+		if err := tableRows.Scan(append([]interface{}{&s.TableUUID, &tableName}, s.scanDest()...)...); err != nil {
+			return nil, err
+		}
+
+		s.TableName = tableName.String
+
+		s.calculateRates()
+		profile.Tables = append(profile.Tables, &s)
+	}
+
+	return profile, tableRows.Err()
+}
+
+// GetLeaderboard returns the statistics of every player who has played at the table, ordered by net winnings
+func (t *Table) GetLeaderboard(ctx context.Context) ([]*LeaderboardEntry, error) {
+	const query = `SELECT players.id, players.display_name,` + playerStatsColumns + `
+FROM player_stats
+INNER JOIN players ON player_stats.player_id = players.id
+WHERE player_stats.table_uuid = $1
+GROUP BY players.id, players.display_name
+ORDER BY SUM(player_stats.net) DESC, players.id`
+	rows, err := db.Instance().QueryContext(ctx, query, t.UUID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	entries := make([]*LeaderboardEntry, 0)
+	for rows.Next() {
+		var e LeaderboardEntry
+		var displayName sql.NullString
+		if err := rows.Scan(append([]interface{}{&e.PlayerID, &displayName}, e.scanDest()...)...); err != nil {
+			return nil, err
+		}
+
+		e.DisplayName = displayName.String
+
+		e.calculateRates()
+		entries = append(entries, &e)
+	}
+
+	return entries, rows.Err()
+}
@@ -0,0 +1,69 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTable_AddPlayerStats(t *testing.T) {
+	p1, tbl := playerAndTable()
+	p2 := player()
+	_, _ = p2.Join(cbg, tbl)
+
+	assert.NoError(t, tbl.AddPlayerStats(cbg, "Texas Hold'em", map[int64]*PlayerStats{
+		p1.ID: {GamesPlayed: 1, Net: 150, BiggestPot: 300, Showdowns: 1, ShowdownsWon: 1, Hands: 1, VPIPHands: 1, PFRHands: 1, BetsRaises: 2},
+		p2.ID: {GamesPlayed: 1, Net: -150, Showdowns: 1, Hands: 1, VPIPHands: 1, Calls: 2},
+	}))
+	assert.NoError(t, tbl.AddPlayerStats(cbg, "Texas Hold'em", map[int64]*PlayerStats{
+		p1.ID: {GamesPlayed: 1, Net: -50, Hands: 1, Calls: 1},
+		p2.ID: {GamesPlayed: 1, Net: 50, BiggestPot: 100, Hands: 1, VPIPHands: 1, PFRHands: 1, BetsRaises: 1},
+	}))
+	assert.NoError(t, tbl.AddPlayerStats(cbg, "bourre", map[int64]*PlayerStats{
+		p1.ID: {GamesPlayed: 1, Net: -25},
+		p2.ID: {GamesPlayed: 1, Net: 25, BiggestPot: 25},
+	}))
+
+	profile, err := p1.GetStatsProfile(cbg)
+	assert.NoError(t, err)
+	assert.Equal(t, p1.ID, profile.PlayerID)
+	assert.Equal(t, 3, profile.Lifetime.GamesPlayed)
+	assert.Equal(t, 75, profile.Lifetime.Net)
+	assert.Equal(t, 300, profile.Lifetime.BiggestPot)
+	assert.Equal(t, 1.0, profile.Lifetime.ShowdownWinRate)
+	assert.Equal(t, 0.5, profile.Lifetime.VPIP)
+	assert.Equal(t, 0.5, profile.Lifetime.PFR)
+	assert.Equal(t, 2.0, profile.Lifetime.Aggression)
+	if assert.Equal(t, 2, len(profile.GameTypes)) {
+		assert.Equal(t, "Texas Hold'em", profile.GameTypes[0].GameType)
+		assert.Equal(t, 2, profile.GameTypes[0].GamesPlayed)
+		assert.Equal(t, "bourre", profile.GameTypes[1].GameType)
+		assert.Equal(t, -25, profile.GameTypes[1].Net)
+	}
+	if assert.Equal(t, 1, len(profile.Tables)) {
+		assert.Equal(t, tbl.UUID, profile.Tables[0].TableUUID)
+		assert.Equal(t, tbl.Name, profile.Tables[0].TableName)
+		assert.Equal(t, 75, profile.Tables[0].Net)
+	}
+
+	leaderboard, err := tbl.GetLeaderboard(cbg)
+	assert.NoError(t, err)
+	if assert.Equal(t, 2, len(leaderboard)) {
+		assert.Equal(t, p1.ID, leaderboard[0].PlayerID)
+		assert.Equal(t, p1.DisplayName, leaderboard[0].DisplayName)
+		assert.Equal(t, p2.ID, leaderboard[1].PlayerID)
+		assert.Equal(t, -75, leaderboard[1].Net)
+		assert.Equal(t, 0.0, leaderboard[1].ShowdownWinRate)
+		assert.Equal(t, 0.5, leaderboard[1].PFR)
+	}
+}
+
+func TestPlayer_GetStatsProfile_noGames(t *testing.T) {
+	p := player()
+
+	profile, err := p.GetStatsProfile(cbg)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, profile.Lifetime.GamesPlayed)
+	assert.Empty(t, profile.GameTypes)
+	assert.Empty(t, profile.Tables)
+}
@@ -19,7 +19,9 @@ tables.name,
 tables.player_id,
 tables.created,
 tables.modified,
-tables.deleted`
+tables.deleted,
+tables.credit_limit,
+tables.button_seat`
 
 // Table represents a poker table
 // A table has many players and can have many games
@@ -31,6 +33,13 @@ type Table struct {
 	Created  time.Time `json:"created"`
 	Modified time.Time `json:"modified"`
 	Deleted  bool      `json:"deleted"`
+
+	// CreditLimit is how much a player can owe before they are no longer dealt in
+	// If nil, there is no limit
+	CreditLimit *int `json:"creditLimit"`
+
+	// ButtonSeat is the seat that had the button in the last game, nil if no game has been dealt
+	ButtonSeat *int `json:"buttonSeat"`
 }
 
 // TableWithPlayerEmail is a table with the player email who created it
@@ -68,8 +77,8 @@ RETURNING created, modified, deleted
 	}
 
 	const query2 = `
-INSERT INTO players_tables (player_id, table_uuid, is_table_admin)
-VALUES ($1, $2, true)`
+INSERT INTO players_tables (player_id, table_uuid, is_table_admin, seat)
+VALUES ($1, $2, true, 1)`
 	if _, err = tx.ExecContext(ctx, query2, p.ID, u); err != nil {
 		rollback(tx)
 		return nil, err
@@ -125,6 +134,8 @@ func getTableByRow(row db.Scanner, additionalColumns ...interface{}) (*Table, er
 		&t.Created,
 		&t.Modified,
 		&t.Deleted,
+		&t.CreditLimit,
+		&t.ButtonSeat,
 	}
 
 	if len(additionalColumns) > 0 {
@@ -195,37 +206,36 @@ func (t *Table) Reload(ctx context.Context) error {
 	return nil
 }
 
-// GetActivePlayersShifted returns all the active players at the table with the players shifted by the number of games
-func (t *Table) GetActivePlayersShifted(ctx context.Context) ([]*PlayerTable, error) {
-	players, err := t.GetPlayers(ctx)
-	if err != nil {
-		return nil, err
-	}
-
-	activePlayers := make([]*PlayerTable, 0, len(players))
-	for _, player := range players {
-		if player.Active {
-			activePlayers = append(activePlayers, player)
-		}
-	}
+// GetSeatedPlayers returns the players who have a seat at the table in seat order, starting with the
+// first seat to the left of the button
+func (t *Table) GetSeatedPlayers(ctx context.Context) ([]*PlayerTable, error) {
+	const query = `
+SELECT ` + playerColumns + `, ` + playerTableColumns + `
+FROM players_tables
+INNER JOIN players ON players_tables.player_id = players.id
+WHERE players_tables.table_uuid = $1
+  AND players_tables.seat IS NOT NULL
+ORDER BY players_tables.seat <= COALESCE((SELECT button_seat FROM tables WHERE uuid = $1), 0), players_tables.seat`
 
-	if len(activePlayers) == 0 {
-		return []*PlayerTable{}, nil
-	}
+	return getPlayerTables(ctx, query, t.UUID)
+}
 
-	count, err := t.GetGamesCount(ctx)
-	if err != nil {
-		return nil, err
+// SetButtonSeat moves the button to the seat
+func (t *Table) SetButtonSeat(ctx context.Context, seat int) error {
+	if err := ValidateSeat(seat); err != nil {
+		return err
 	}
 
-	offset := int(count % int64(len(activePlayers)))
-	if offset == 0 {
-		return players, nil
+	const query = `
+UPDATE tables
+SET button_seat = $1
+WHERE uuid = $2`
+	if _, err := db.Instance().ExecContext(ctx, query, seat, t.UUID); err != nil {
+		return err
 	}
 
-	tail := activePlayers[offset:]
-	head := activePlayers[:offset]
-	return append(tail, head...), nil
+	t.ButtonSeat = &seat
+	return nil
 }
 
 // GetPlayers returns all players at the table
@@ -237,7 +247,11 @@ INNER JOIN players ON players_tables.player_id = players.id
 WHERE players_tables.table_uuid = $1
 ORDER BY players_tables.id`
 
-	rows, err := db.Instance().QueryContext(ctx, query, t.UUID)
+	return getPlayerTables(ctx, query, t.UUID)
+}
+
+func getPlayerTables(ctx context.Context, query string, args ...interface{}) ([]*PlayerTable, error) {
+	rows, err := db.Instance().QueryContext(ctx, query, args...)
 	if err != nil {
 		return nil, err
 	}
@@ -288,10 +302,11 @@ func (t *Table) Save(ctx context.Context) error {
 UPDATE tables
 SET name = $1,
     deleted = $2,
+    credit_limit = $3,
     modified = (NOW() AT TIME ZONE 'UTC')
-WHERE uuid = $3`
+WHERE uuid = $4`
 
-	_, err := db.Instance().ExecContext(ctx, query, t.Name, t.Deleted, t.UUID)
+	_, err := db.Instance().ExecContext(ctx, query, t.Name, t.Deleted, t.CreditLimit, t.UUID)
 	return err
 }
 
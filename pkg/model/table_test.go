@@ -65,6 +65,24 @@ func TestTable_Reload(t *testing.T) {
 	assert.Equal(t, "test table", tbl2.Name)
 }
 
+func TestTable_Save_creditLimit(t *testing.T) {
+	_, tbl := playerAndTable()
+	assert.Nil(t, tbl.CreditLimit)
+
+	creditLimit := 2500
+	tbl.CreditLimit = &creditLimit
+	assert.NoError(t, tbl.Save(cbg))
+	assert.NoError(t, tbl.Reload(cbg))
+	if assert.NotNil(t, tbl.CreditLimit) {
+		assert.Equal(t, 2500, *tbl.CreditLimit)
+	}
+
+	tbl.CreditLimit = nil
+	assert.NoError(t, tbl.Save(cbg))
+	assert.NoError(t, tbl.Reload(cbg))
+	assert.Nil(t, tbl.CreditLimit)
+}
+
 func playerAndTable() (*Player, *Table) {
 	p := player()
 	t, err := p.CreateTable(cbg, "test table")
@@ -89,78 +107,96 @@ func TestTable_GetGamesCount(t *testing.T) {
 	assert.Equal(t, int64(1), c)
 }
 
-func TestTable_GetActivePlayersShifted(t *testing.T) {
+func TestTable_GetSeatedPlayers(t *testing.T) {
+	a := assert.New(t)
+
 	p0, tbl := playerAndTable()
 	p1 := player()
 	p2 := player()
 	p3 := player()
-	p4 := player()
 
-	_, _ = p1.Join(cbg, tbl)
-	_, _ = p2.Join(cbg, tbl)
-	_, _ = p3.Join(cbg, tbl)
-	pt4, _ := p4.Join(cbg, tbl)
+	pt1, _ := p1.Join(cbg, tbl)
+	_, _ = p2.JoinAtSeat(cbg, tbl, 5)
+	pt3, _ := p3.Join(cbg, tbl)
 
-	pt4.Active = false
-	_ = pt4.Save(cbg)
+	// the creator of the table has the first seat, and players join in the lowest seat that is free
+	pt0, _ := p0.GetPlayerTable(cbg, tbl)
+	a.Equal(1, *pt0.Seat)
+	a.Equal(2, *pt1.Seat)
+	a.Equal(3, *pt3.Seat)
 
-	players, err := tbl.GetActivePlayersShifted(cbg)
-	assert.NoError(t, err)
-	assert.Equal(t, p0.ID, players[0].PlayerID)
-	assert.Equal(t, p1.ID, players[1].PlayerID)
-	assert.Equal(t, p2.ID, players[2].PlayerID)
-	assert.Equal(t, p3.ID, players[3].PlayerID)
+	players, err := tbl.GetSeatedPlayers(cbg)
+	a.NoError(err)
+	a.Equal([]int64{p0.ID, p1.ID, p3.ID, p2.ID}, playerTableIDs(players))
 
-	_, _ = tbl.CreateGame(cbg, "bourre")
-	players, err = tbl.GetActivePlayersShifted(cbg)
-	assert.NoError(t, err)
-	assert.Equal(t, p1.ID, players[0].PlayerID)
-	assert.Equal(t, p2.ID, players[1].PlayerID)
-	assert.Equal(t, p3.ID, players[2].PlayerID)
-	assert.Equal(t, p0.ID, players[3].PlayerID)
+	// the players are dealt starting to the left of the button
+	a.NoError(tbl.SetButtonSeat(cbg, 3))
+	a.Equal(3, *tbl.ButtonSeat)
+	players, err = tbl.GetSeatedPlayers(cbg)
+	a.NoError(err)
+	a.Equal([]int64{p2.ID, p0.ID, p1.ID, p3.ID}, playerTableIDs(players))
 
-	_, _ = tbl.CreateGame(cbg, "bourre")
-	players, err = tbl.GetActivePlayersShifted(cbg)
-	assert.NoError(t, err)
-	assert.Equal(t, p2.ID, players[0].PlayerID)
-	assert.Equal(t, p3.ID, players[1].PlayerID)
-	assert.Equal(t, p0.ID, players[2].PlayerID)
-	assert.Equal(t, p1.ID, players[3].PlayerID)
+	tbl2, _ := GetTableByUUID(cbg, tbl.UUID)
+	a.Equal(3, *tbl2.ButtonSeat)
 
-	_, _ = tbl.CreateGame(cbg, "bourre")
-	players, err = tbl.GetActivePlayersShifted(cbg)
-	assert.NoError(t, err)
-	assert.Equal(t, p3.ID, players[0].PlayerID)
-	assert.Equal(t, p0.ID, players[1].PlayerID)
-	assert.Equal(t, p1.ID, players[2].PlayerID)
-	assert.Equal(t, p2.ID, players[3].PlayerID)
+	a.NoError(tbl.SetButtonSeat(cbg, 5))
+	players, _ = tbl.GetSeatedPlayers(cbg)
+	a.Equal([]int64{p0.ID, p1.ID, p3.ID, p2.ID}, playerTableIDs(players))
 
-	_, _ = tbl.CreateGame(cbg, "bourre")
-	_, _ = tbl.CreateGame(cbg, "bourre")
-	players, err = tbl.GetActivePlayersShifted(cbg)
-	assert.NoError(t, err)
-	assert.Equal(t, p1.ID, players[0].PlayerID)
-	assert.Equal(t, p2.ID, players[1].PlayerID)
-	assert.Equal(t, p3.ID, players[2].PlayerID)
-	assert.Equal(t, p0.ID, players[3].PlayerID)
+	a.EqualError(tbl.SetButtonSeat(cbg, 11), "the seat must be between 1 and 10")
 }
 
-func TestTable_GetActivePlayersShifted_noActivePlayers(t *testing.T) {
+func TestPlayerTable_SetSeat(t *testing.T) {
+	a := assert.New(t)
+
 	p0, tbl := playerAndTable()
 	p1 := player()
 
 	pt0, _ := p0.GetPlayerTable(cbg, tbl)
 	pt1, _ := p1.Join(cbg, tbl)
 
-	pt0.Active = false
-	_ = pt0.Save(cbg)
+	a.Equal(ErrSeatTaken, pt1.SetSeat(cbg, 1))
+	a.EqualError(pt1.SetSeat(cbg, 0), "the seat must be between 1 and 10")
 
-	pt1.Active = false
-	_ = pt1.Save(cbg)
+	a.NoError(pt1.SetSeat(cbg, 10))
+	a.Equal(10, *pt1.Seat)
 
-	players, err := tbl.GetActivePlayersShifted(cbg)
-	assert.NoError(t, err)
-	assert.Equal(t, []*PlayerTable{}, players)
+	players, _ := tbl.GetSeatedPlayers(cbg)
+	a.Equal([]int64{p0.ID, p1.ID}, playerTableIDs(players))
+
+	a.NoError(pt0.SetSeat(cbg, 2))
+	_, err := player().JoinAtSeat(cbg, tbl, 2)
+	a.Equal(ErrSeatTaken, err)
+	_, err = p1.JoinAtSeat(cbg, tbl, 3)
+	a.Equal(ErrDuplicateKey, err)
+}
+
+func TestPlayer_Join_tableFull(t *testing.T) {
+	a := assert.New(t)
+
+	_, tbl := playerAndTable()
+	for i := 1; i < TableSeats; i++ {
+		pt, err := player().Join(cbg, tbl)
+		a.NoError(err)
+		a.Equal(i+1, *pt.Seat)
+	}
+
+	// the player joins without a seat
+	pt, err := player().Join(cbg, tbl)
+	a.NoError(err)
+	a.Nil(pt.Seat)
+
+	players, _ := tbl.GetSeatedPlayers(cbg)
+	a.Equal(TableSeats, len(players))
+}
+
+func playerTableIDs(players []*PlayerTable) []int64 {
+	ids := make([]int64, len(players))
+	for i, pt := range players {
+		ids[i] = pt.PlayerID
+	}
+
+	return ids
 }
 
 func TestGetTables(t *testing.T) {
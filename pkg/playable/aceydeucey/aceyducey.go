@@ -14,6 +14,9 @@ import (
 // Should we make this configurable? Maybe double the ante?
 const betTheGapAmount = 50
 
+// minimumBet is the smallest bet, a participant who has less left cannot take a turn
+const minimumBet = 25
+
 // Game is a game of Acey Deucey
 type Game struct {
 	options             Options
@@ -34,8 +37,10 @@ func (g *Game) Interval() time.Duration {
 }
 
 // NewGame returns a new game
-func NewGame(logger logrus.FieldLogger, playerIDs []int64, options Options) (*Game, error) {
-	if len(playerIDs) < 2 {
+// A participant antes at most their table stake, and their bets are limited to what they have left. A participant
+// who cannot place the minimum bet skips their turn
+func NewGame(logger logrus.FieldLogger, players []playable.Player, options Options) (*Game, error) {
+	if len(players) < 2 {
 		return nil, errors.New("game requires at least two players")
 	}
 
@@ -43,19 +48,25 @@ func NewGame(logger logrus.FieldLogger, playerIDs []int64, options Options) (*Ga
 		return nil, errors.New("ante must be > 0")
 	}
 
-	orderedParticipants := make([]*Participant, len(playerIDs))
-	idToParticipant := make(map[int64]*Participant, len(playerIDs))
-	for i, pid := range playerIDs {
-		p := NewParticipant(pid, options.Ante)
-		idToParticipant[pid] = p
+	orderedParticipants := make([]*Participant, len(players))
+	idToParticipant := make(map[int64]*Participant, len(players))
+	pot := 0
+	for i, player := range players {
+		p := NewParticipant(player.GetPlayerID(), player.GetTableStake(), options.Ante)
+		idToParticipant[p.PlayerID] = p
 		orderedParticipants[i] = p
+		pot -= p.Balance
 	}
 
-	if len(playerIDs) != len(idToParticipant) {
+	if len(players) != len(idToParticipant) {
 		return nil, errors.New("duplicate players detected")
 	}
 
-	d := deck.New()
+	d, err := deck.NewFromSpec(options.deckSpec())
+	if err != nil {
+		return nil, err
+	}
+
 	d.Shuffle()
 
 	a := &Game{
@@ -65,10 +76,14 @@ func NewGame(logger logrus.FieldLogger, playerIDs []int64, options Options) (*Ga
 		deck:                d,
 		logChan:             make(chan []*playable.LogMessage, 256),
 		turnIndex:           0,
-		pot:                 len(playerIDs) * options.Ante,
+		pot:                 pot,
 		logger:              logger,
 	}
 
+	if !a.canTakeTurn(a.getCurrentTurn()) && !a.nextTurn() {
+		return nil, errors.New("no player can afford the minimum bet")
+	}
+
 	a.newRound()
 	return a, nil
 }
@@ -209,9 +224,38 @@ func (g *Game) getCurrentTurn() *Participant {
 	return participant
 }
 
-func (g *Game) nextTurn() {
-	g.turnIndex++
-	g.turnIndex = g.turnIndex % len(g.orderedParticipants)
+// nextTurn advances to the next participant who can afford the minimum bet
+// Returns false if no participant can
+func (g *Game) nextTurn() bool {
+	for range g.orderedParticipants {
+		g.turnIndex++
+		g.turnIndex = g.turnIndex % len(g.orderedParticipants)
+		if g.canTakeTurn(g.getCurrentTurn()) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// canTakeTurn returns true if the participant has enough left to place the minimum bet
+func (g *Game) canTakeTurn(p *Participant) bool {
+	return p.stack() >= minimumBet
+}
+
+// splitPot splits the pot evenly between all participants
+// Any remainder goes to the first participants
+func (g *Game) splitPot() {
+	share := g.pot / len(g.orderedParticipants)
+	remainder := g.pot % len(g.orderedParticipants)
+	for i, p := range g.orderedParticipants {
+		p.Balance += share
+		if i < remainder {
+			p.Balance++
+		}
+	}
+
+	g.pot = 0
 }
 
 // isGameOver returns true if the pot is empty
@@ -227,7 +271,7 @@ func (g *Game) newRound() {
 	}
 
 	turn := g.getCurrentTurn()
-	r := NewRound(g.options, turn.PlayerID, g.deck, g.pot)
+	r := NewRound(g.options, turn.PlayerID, g.deck, g.pot, turn.stack())
 	r.logChan = g.logChan
 	g.rounds = append(g.rounds, r)
 
@@ -245,10 +289,13 @@ func (g *Game) endRound() error {
 	g.pot = currentRound.Pot
 	participant.Balance += currentRound.ParticipantAdjustments()
 	if g.pot > 0 {
-		g.nextTurn()
-		g.newRound()
+		if g.nextTurn() {
+			g.newRound()
+			return nil
+		}
 
-		return nil
+		currentRound.sendLogMessage(fmt.Sprintf("Nobody has enough left to bet, the pot of ${%d} is split", g.pot), nil, 0)
+		g.splitPot()
 	}
 
 	currentRound.setNextState(RoundStateComplete, time.Second*2)
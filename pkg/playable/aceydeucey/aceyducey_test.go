@@ -10,22 +10,50 @@ import (
 	"time"
 )
 
+// testTableStake is more than any test bets, so the bets are only limited by the pot unless a test lowers the stake
+const testTableStake = 10000
+
+type testParticipant struct {
+	playerID   int64
+	tableStake int
+}
+
+func (t *testParticipant) GetPlayerID() int64 {
+	return t.playerID
+}
+
+func (t *testParticipant) GetTableStake() int {
+	return t.tableStake
+}
+
+func setupParticipants(playerIDs ...int64) []playable.Player {
+	p := make([]playable.Player, len(playerIDs))
+	for i, id := range playerIDs {
+		p[i] = &testParticipant{
+			playerID:   id,
+			tableStake: testTableStake,
+		}
+	}
+
+	return p
+}
+
 func TestNewGame(t *testing.T) {
 	a := assert.New(t)
 
-	game, err := NewGame(logrus.StandardLogger(), []int64{1}, Options{})
+	game, err := NewGame(logrus.StandardLogger(), setupParticipants(1), Options{})
 	a.Nil(game)
 	a.EqualError(err, "game requires at least two players")
 
-	game, err = NewGame(logrus.StandardLogger(), []int64{1, 2}, Options{})
+	game, err = NewGame(logrus.StandardLogger(), setupParticipants(1, 2), Options{})
 	a.Nil(game)
 	a.EqualError(err, "ante must be > 0")
 
-	game, err = NewGame(logrus.StandardLogger(), []int64{1, 2, 1}, Options{Ante: 25})
+	game, err = NewGame(logrus.StandardLogger(), setupParticipants(1, 2, 1), Options{Ante: 25})
 	a.Nil(game)
 	a.EqualError(err, "duplicate players detected")
 
-	game, err = NewGame(logrus.StandardLogger(), []int64{1, 2}, Options{Ante: 25})
+	game, err = NewGame(logrus.StandardLogger(), setupParticipants(1, 2), Options{Ante: 25})
 	a.NotNil(game)
 	a.NoError(err)
 
@@ -38,7 +66,7 @@ func TestNewGame(t *testing.T) {
 
 func TestAceyDeucey_getCurrentTurn(t *testing.T) {
 	a := assert.New(t)
-	game, _ := NewGame(logrus.StandardLogger(), []int64{1, 2, 3}, DefaultOptions())
+	game, _ := NewGame(logrus.StandardLogger(), setupParticipants(1, 2, 3), DefaultOptions())
 	a.Equal(game.participants[1], game.getCurrentTurn())
 	a.Equal(game.participants[1], game.getCurrentTurn())
 	game.nextTurn()
@@ -52,7 +80,7 @@ func TestAceyDeucey_getCurrentTurn(t *testing.T) {
 func TestAceyDeucey_isGameOver(t *testing.T) {
 	a := assert.New(t)
 
-	game, err := NewGame(logrus.StandardLogger(), []int64{1, 2}, DefaultOptions())
+	game, err := NewGame(logrus.StandardLogger(), setupParticipants(1, 2), DefaultOptions())
 	a.NoError(err)
 	a.NotNil(game)
 
@@ -73,7 +101,7 @@ func TestGame_Delay(t *testing.T) {
 
 func TestGame_getCurrentTurn(t *testing.T) {
 	a := assert.New(t)
-	game, err := NewGame(logrus.StandardLogger(), []int64{1, 2, 3}, DefaultOptions())
+	game, err := NewGame(logrus.StandardLogger(), setupParticipants(1, 2, 3), DefaultOptions())
 	a.NoError(err)
 
 	a.Equal(game.participants[1], game.getCurrentTurn())
@@ -90,7 +118,7 @@ func TestGame_basicFlow(t *testing.T) {
 
 	opts := DefaultOptions()
 	opts.Ante = 100
-	game, err := NewGame(logrus.StandardLogger(), []int64{1, 2, 3}, opts)
+	game, err := NewGame(logrus.StandardLogger(), setupParticipants(1, 2, 3), opts)
 	a.NoError(err)
 
 	game.deck.Cards = deck.CardsFromString("14c,3c,2c")
@@ -188,7 +216,7 @@ func TestGame_allowPass(t *testing.T) {
 		AllowPass: true,
 		GameType:  GameTypeStandard,
 	}
-	game, err := NewGame(logrus.StandardLogger(), []int64{1, 2, 3}, opts)
+	game, err := NewGame(logrus.StandardLogger(), setupParticipants(1, 2, 3), opts)
 	a.NoError(err)
 
 	game.deck.Cards = deck.CardsFromString("2c,5c")
@@ -277,7 +305,7 @@ func TestGame_newRound_maxBet(t *testing.T) {
 
 	opts := DefaultOptions()
 	opts.Ante = 100
-	g, _ := NewGame(logrus.StandardLogger(), []int64{1, 2, 3}, opts)
+	g, _ := NewGame(logrus.StandardLogger(), setupParticipants(1, 2, 3), opts)
 
 	a.Equal(150, g.getCurrentRound().getMaxBet())
 
@@ -306,7 +334,7 @@ func TestGame_newRound(t *testing.T) {
 
 	// test no continuous shoe
 	{
-		game, err := NewGame(logrus.StandardLogger(), []int64{1, 2, 3}, opts)
+		game, err := NewGame(logrus.StandardLogger(), setupParticipants(1, 2, 3), opts)
 
 		a.NoError(err)
 		a.NotNil(game)
@@ -320,10 +348,13 @@ func TestGame_newRound(t *testing.T) {
 	// test continuous shoe
 	{
 		opts.GameType = GameTypeContinuousShoe
-		game, err := NewGame(logrus.StandardLogger(), []int64{1, 2, 3}, opts)
+		game, err := NewGame(logrus.StandardLogger(), setupParticipants(1, 2, 3), opts)
 		a.NoError(err)
 		a.NotNil(game)
 
+		// the continuous shoe is made up of six decks
+		a.Equal(312, game.deck.CardsLeft())
+
 		// ensure the deck is shuffled when newRound is called
 		hc := game.deck.HashCode()
 		game.newRound()
@@ -346,3 +377,48 @@ func TestGame_Name(t *testing.T) {
 	game.options.GameType = GameTypeStandard
 	a.Equal("Acey Deucey (With Passing)", game.Name())
 }
+
+func TestGame_tableStake(t *testing.T) {
+	a := assert.New(t)
+	players := setupParticipants(1, 2, 3)
+	players[1].(*testParticipant).tableStake = 10
+
+	game, err := NewGame(logrus.StandardLogger(), players, DefaultOptions())
+	a.NoError(err)
+
+	// player 2 is all-in with the ante and cannot afford a bet
+	a.Equal(60, game.pot)
+	a.Equal(-10, game.participants[2].Balance)
+	a.True(game.nextTurn())
+	a.Equal(game.participants[3], game.getCurrentTurn())
+	a.True(game.nextTurn())
+	a.Equal(game.participants[1], game.getCurrentTurn())
+
+	// the first player cannot bet, so the game starts with the next player
+	players = setupParticipants(1, 2)
+	players[0].(*testParticipant).tableStake = 25
+	game, err = NewGame(logrus.StandardLogger(), players, DefaultOptions())
+	a.NoError(err)
+	a.Equal(int64(2), game.getCurrentRound().PlayerID)
+
+	// nobody can bet
+	players = setupParticipants(1, 2)
+	players[0].(*testParticipant).tableStake = 25
+	players[1].(*testParticipant).tableStake = 25
+	game, err = NewGame(logrus.StandardLogger(), players, DefaultOptions())
+	a.EqualError(err, "no player can afford the minimum bet")
+	a.Nil(game)
+}
+
+func TestGame_splitPot(t *testing.T) {
+	a := assert.New(t)
+	game, err := NewGame(logrus.StandardLogger(), setupParticipants(1, 2, 3), DefaultOptions())
+	a.NoError(err)
+
+	game.pot = 100
+	game.splitPot()
+	a.Equal(0, game.pot)
+	a.Equal(9, game.participants[1].Balance)
+	a.Equal(8, game.participants[2].Balance)
+	a.Equal(8, game.participants[3].Balance)
+}
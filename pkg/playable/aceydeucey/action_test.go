@@ -53,7 +53,7 @@ func TestAction_MarshalJSON(t *testing.T) {
 func TestAceyDeucey_getActionsForParticipant(t *testing.T) {
 	a := assert.New(t)
 
-	game, err := NewGame(logrus.StandardLogger(), []int64{1, 2, 3}, DefaultOptions())
+	game, err := NewGame(logrus.StandardLogger(), setupParticipants(1, 2, 3), DefaultOptions())
 	a.NoError(err)
 
 	game.deck.Cards = deck.CardsFromString("2c,5c,3c")
@@ -80,7 +80,7 @@ func TestAceyDeucey_getActionsForParticipant(t *testing.T) {
 	a.Equal([]Action{ActionPass, ActionBet, ActionBetTheGap}, game.getActionsForParticipant(1))
 
 	// test ace
-	game, err = NewGame(logrus.StandardLogger(), []int64{1, 2, 3}, DefaultOptions())
+	game, err = NewGame(logrus.StandardLogger(), setupParticipants(1, 2, 3), DefaultOptions())
 	a.NoError(err)
 
 	game.deck.Cards = deck.CardsFromString("14s,5c,3c")
@@ -1,5 +1,10 @@
 package aceydeucey
 
+import "mondaynightpoker-server/pkg/deck"
+
+// continuousShoeDecks is the number of decks in the continuous shoe
+const continuousShoeDecks = 6
+
 // Options contains options for creating a new game of Acey Deucey
 type Options struct {
 	Ante      int
@@ -15,3 +20,12 @@ func DefaultOptions() Options {
 		GameType:  GameTypeStandard,
 	}
 }
+
+// deckSpec returns the composition of the deck for the game type
+func (o Options) deckSpec() deck.Spec {
+	if o.GameType == GameTypeContinuousShoe {
+		return deck.ShoeSpec(continuousShoeDecks)
+	}
+
+	return deck.StandardSpec()
+}
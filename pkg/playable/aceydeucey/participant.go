@@ -4,12 +4,25 @@ package aceydeucey
 type Participant struct {
 	PlayerID int64 `json:"playerId"`
 	Balance  int   `json:"balance"`
+
+	tableStake int
 }
 
-// NewParticipant returns a new participant
-func NewParticipant(playerID int64, ante int) *Participant {
+// NewParticipant returns a new participant who paid the ante
+// A participant whose table stake is less than the ante pays what they have
+func NewParticipant(playerID int64, tableStake int, ante int) *Participant {
+	if ante > tableStake {
+		ante = tableStake
+	}
+
 	return &Participant{
-		PlayerID: playerID,
-		Balance:  -1 * ante,
+		PlayerID:   playerID,
+		Balance:    -1 * ante,
+		tableStake: tableStake,
 	}
 }
+
+// stack returns how much the participant has left to bet with
+func (p *Participant) stack() int {
+	return p.tableStake + p.Balance
+}
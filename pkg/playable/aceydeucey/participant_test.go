@@ -6,7 +6,13 @@ import (
 )
 
 func TestNewParticipant(t *testing.T) {
-	p := NewParticipant(1, 5)
+	p := NewParticipant(1, 100, 5)
 	assert.Equal(t, int64(1), p.PlayerID)
 	assert.Equal(t, -5, p.Balance)
+	assert.Equal(t, 95, p.stack())
+
+	// the ante is capped at the table stake
+	p = NewParticipant(1, 3, 5)
+	assert.Equal(t, -3, p.Balance)
+	assert.Equal(t, 0, p.stack())
 }
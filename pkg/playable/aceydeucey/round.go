@@ -30,6 +30,9 @@ type Round struct {
 	HalfPotMax bool
 	logChan    chan []*playable.LogMessage
 
+	// stack is how much the player had left to bet with when the round started
+	stack int
+
 	activeGameIndex int
 	deck            *deck.Deck
 	nextAction      *nextAction
@@ -96,13 +99,15 @@ const (
 )
 
 // NewRound returns a new Round object
-func NewRound(opts Options, playerID int64, d *deck.Deck, startingPot int) *Round {
+// stack is how much the player has left to bet with
+func NewRound(opts Options, playerID int64, d *deck.Deck, startingPot int, stack int) *Round {
 	return &Round{
 		options:  opts,
 		PlayerID: playerID,
 		Games:    []*SingleGame{newSingleGame()},
 		State:    RoundStateStart,
 		Pot:      startingPot,
+		stack:    stack,
 
 		activeGameIndex: 0,
 		deck:            d,
@@ -284,7 +289,13 @@ func (r *Round) dealMiddleCard(card *deck.Card) {
 	firstCardRank := game.firstCardRank()
 
 	if card.Rank == firstCardRank || card.Rank == game.LastCard.Rank {
-		r.finalizeGame(game, SingleGameResultPost, -2*game.Bet.Amount)
+		// a post costs double the bet, but never more than the player has left
+		loss := 2 * game.Bet.Amount
+		if remaining := r.remainingStack(); loss > remaining {
+			loss = remaining
+		}
+
+		r.finalizeGame(game, SingleGameResultPost, -1*loss)
 		return
 	}
 
@@ -501,7 +512,22 @@ func (r *Round) sendLogMessage(message string, card *deck.Card, playerID int64)
 	}
 }
 
+// remainingStack returns how much the player has left to bet with after the games already played this round
+func (r *Round) remainingStack() int {
+	return r.stack + r.ParticipantAdjustments()
+}
+
+// getMaxBet returns the max bet, which is limited by the pot and by what the player has left
 func (r *Round) getMaxBet() int {
+	maxBet := r.getMaxPotBet()
+	if remaining := r.remainingStack(); maxBet > remaining {
+		maxBet = remaining - remaining%minimumBet
+	}
+
+	return maxBet
+}
+
+func (r *Round) getMaxPotBet() int {
 	if r.Pot <= 0 {
 		return 0
 	}
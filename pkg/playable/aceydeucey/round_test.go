@@ -10,7 +10,7 @@ import (
 func TestNewRound(t *testing.T) {
 	a := assert.New(t)
 	d := deck.New()
-	r := NewRound(DefaultOptions(), 1, d, 50)
+	r := NewRound(DefaultOptions(), 1, d, 50, testTableStake)
 
 	a.Equal(50, r.Pot)
 	a.Equal(RoundStateStart, r.State)
@@ -384,14 +384,14 @@ func TestRound_drawCardWithChaos(t *testing.T) {
 		assert.NotNil(t, card)
 	}
 
-	r := NewRound(opts, 0, d, 100)
+	r := NewRound(opts, 0, d, 100, testTableStake)
 	assertDrawCard(r.drawCard())
 	assertDrawCard(r.drawCard())
 	assertDrawCard(r.drawCard())
 	assert.Equal(t, 49, d.CardsLeft())
 
 	opts.GameType = GameTypeChaos
-	r = NewRound(opts, 0, d, 100)
+	r = NewRound(opts, 0, d, 100, testTableStake)
 	assertDrawCard(r.drawCard())
 	assertDrawCard(r.drawCard())
 	assertDrawCard(r.drawCard())
@@ -498,7 +498,7 @@ func createTestRound(pot int, cards string) *Round {
 		d.Cards[i] = card
 	}
 
-	return NewRound(DefaultOptions(), 1, d, pot)
+	return NewRound(DefaultOptions(), 1, d, pot, testTableStake)
 }
 
 func cardsFromArray(c []*deck.Card, indexes ...int) string {
@@ -542,7 +542,7 @@ func TestRound_halfPot(t *testing.T) {
 
 func TestRound_getMaxBet(t *testing.T) {
 	a := assert.New(t)
-	r := &Round{Pot: 200}
+	r := &Round{Pot: 200, stack: testTableStake}
 
 	// test get full pot
 	a.Equal(200, r.getMaxBet())
@@ -574,3 +574,33 @@ func TestRound_Pass(t *testing.T) {
 	a.NoError(r.SetPass())
 	a.Equal(RoundStatePassed, r.State)
 }
+
+func TestRound_getMaxBet_stack(t *testing.T) {
+	a := assert.New(t)
+	r := &Round{Pot: 200, stack: 110}
+
+	// the bet is limited to what the player has left, in increments of ${25}
+	a.Equal(100, r.getMaxBet())
+	r.State = RoundStatePendingBet
+	r.Games = []*SingleGame{newSingleGame()}
+	a.EqualError(r.SetBet(125, false), "bet of ${125} exceeds the max bet of ${100}")
+
+	r.stack = 500
+	a.Equal(200, r.getMaxBet())
+}
+
+func TestRound_postLimitedToStack(t *testing.T) {
+	a := assert.New(t)
+	r := createTestRound(100, "4c,6c,4d")
+	r.stack = 75
+
+	a.NoError(r.DealCard())
+	a.NoError(r.DealCard())
+	a.NoError(r.SetBet(50, false))
+	a.NoError(r.DealCard())
+
+	// the post costs double the bet, but the player only had ${75} left
+	a.Equal(SingleGameResultPost, r.Games[0].Result)
+	a.Equal(-75, r.Games[0].Adjustment)
+	a.Equal(175, r.Pot)
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"mondaynightpoker-server/pkg/deck"
 	"mondaynightpoker-server/pkg/playable"
+	"sort"
 	"time"
 
 	"github.com/google/uuid"
@@ -177,6 +178,10 @@ func (g *Game) endGame() error {
 		messages = append(messages, newLogMessageWithPlayers(res.Winners, "{} tied for most tricks"))
 	}
 
+	for _, playerID := range sortedPlayerIDs(res.Refunds) {
+		messages = append(messages, newLogMessage(playerID, nil, "{} gets back ${%d} that the winner did not cover", res.Refunds[playerID]))
+	}
+
 	if len(res.PaidPot) > 0 {
 		messages = append(messages, newLogMessageWithPlayers(res.PaidPot, "{} pays the pot of ${%d}", res.OldPot))
 	}
@@ -185,10 +190,18 @@ func (g *Game) endGame() error {
 		messages = append(messages, newLogMessageWithPlayers(res.PaidAnte, "{} pays the ante of ${%d}", res.Ante))
 	}
 
+	if len(res.AllIn) > 0 {
+		messages = append(messages, newLogMessageWithPlayers(res.AllIn, "{} is all-in"))
+	}
+
 	if len(res.Booted) > 0 {
 		messages = append(messages, newLogMessageWithPlayers(res.Booted, "{} was booted"))
 	}
 
+	if len(res.SplitPot) > 0 {
+		messages = append(messages, newLogMessageWithPlayers(res.SplitPot, "Not enough players can play for the next pot, {} split the pot of ${%d}", res.SplitAmount))
+	}
+
 	log.Debug("done triggered")
 	if res.ShouldContinue() {
 		log.Debug("new game created")
@@ -254,12 +267,13 @@ func (g *Game) GetEndOfGameDetails() (gameOverDetails *playable.GameOverDetails,
 
 // NewGame returns a new bourré game
 // players should be in the correct order. i.e., any rotation must happen beforehand
-func NewGame(logger logrus.FieldLogger, playerIDs []int64, opts Options) (*Game, error) {
+// A player who cannot cover the ante or the pot is all-in, and only wins up to their stake from each player
+func NewGame(logger logrus.FieldLogger, playablePlayers []playable.Player, opts Options) (*Game, error) {
 	idToPlayer := make(map[int64]*Player)
-	players := make([]*Player, len(playerIDs))
-	for i, pid := range playerIDs {
-		players[i] = NewPlayer(pid)
-		idToPlayer[pid] = players[i]
+	players := make([]*Player, len(playablePlayers))
+	for i, player := range playablePlayers {
+		players[i] = NewPlayer(player.GetPlayerID(), player.GetTableStake())
+		idToPlayer[player.GetPlayerID()] = players[i]
 	}
 
 	g, err := newGame(logger, players, nil, opts)
@@ -293,9 +307,14 @@ func newGame(logger logrus.FieldLogger, players []*Player, foldedPlayers []*Play
 		// if initial pot is > 0, that means we are working off of a previous game. In that case,
 		// we already took care of the players who need to ante
 		if opts.InitialPot == 0 {
-			messages = append(messages, newLogMessage(player.PlayerID, nil, "{} paid the ${%d} ante", opts.Ante))
-			pot += opts.Ante
-			player.balance -= opts.Ante
+			paid := player.payIntoPot(opts.Ante)
+			if paid < opts.Ante {
+				messages = append(messages, newLogMessage(player.PlayerID, nil, "{} is all-in with a ${%d} ante", paid))
+			} else {
+				messages = append(messages, newLogMessage(player.PlayerID, nil, "{} paid the ${%d} ante", paid))
+			}
+
+			pot += paid
 		}
 
 		playerOrder[player] = order
@@ -514,14 +533,17 @@ func (g *Game) buildResults() error {
 	newPot := 0
 	winningAmount := 0
 	booted := make([]*Player, 0)
+	allIn := make([]*Player, 0)
+	splitPot := make([]*Player, 0)
+	splitAmount := 0
+	var refunds map[int64]int
 
 	if len(g.playerOrder) == 1 {
 		for player := range g.playerOrder {
-			player.balance += g.pot
 			winners = append(winners, player)
 		}
 
-		winningAmount = g.pot
+		winningAmount, refunds = g.awardPot(winners[0])
 	} else {
 		maxWins := 0
 
@@ -547,26 +569,49 @@ func (g *Game) buildResults() error {
 				payAnte = []*Player{}
 			}
 
+			// a single winner takes the pot before the next one is paid, otherwise the pot grows
+			if len(winners) == 1 {
+				winningAmount, refunds = g.awardPot(winners[0])
+			}
+
 			for _, player := range payPot {
-				player.balance -= g.pot
-				newPot += g.pot
+				paid := player.payIntoPot(g.pot)
+				if paid < g.pot {
+					allIn = append(allIn, player)
+				}
+
+				newPot += paid
 			}
 
 			for _, player := range payAnte {
-				player.balance -= g.ante
-				newPot += g.ante
+				paid := player.payIntoPot(g.ante)
+				if paid < g.ante {
+					allIn = append(allIn, player)
+				}
+
+				newPot += paid
 			}
 
-			// if more than one winner, pot grows
 			if len(winners) > 1 {
 				newPot += g.pot
-			} else {
-				winningAmount = g.pot
-				winners[0].balance += winningAmount
+			}
+
+			// players who have nothing left cannot play for the next pot
+			booted = append(booted, g.brokePlayers(booted)...)
+
+			// if fewer than two players can play for the next pot, it is split by those who are left
+			if continuing := g.continuingPlayers(booted); len(continuing) < 2 {
+				splitPot = continuing
+				if len(splitPot) == 0 {
+					splitPot = winners
+				}
+
+				splitAmount = newPot
+				splitPotBetween(splitPot, newPot)
+				newPot = 0
 			}
 		} else {
-			winningAmount = g.pot
-			winners[0].balance += winningAmount
+			winningAmount, refunds = g.awardPot(winners[0])
 			booted = append(booted, payAnte...)
 			payAnte = make([]*Player, 0)
 		}
@@ -580,7 +625,11 @@ func (g *Game) buildResults() error {
 		Winners:       winners,
 		Folded:        g.getFoldedPlayers(),
 		Booted:        booted,
+		AllIn:         allIn,
+		SplitPot:      splitPot,
 		WinningAmount: winningAmount,
+		SplitAmount:   splitAmount,
+		Refunds:       refunds,
 		Ante:          g.ante,
 		OldPot:        g.pot,
 		NewPot:        newPot,
@@ -593,6 +642,125 @@ func (g *Game) buildResults() error {
 	return nil
 }
 
+// stake returns how much the player has at risk in the pot: what they paid into it and as much of the
+// pot as they could pay if they took no tricks
+func (g *Game) stake(player *Player) int {
+	atRisk := g.pot
+	if stack := player.stack(); stack < atRisk {
+		atRisk = stack
+	}
+
+	return player.contributed + atRisk
+}
+
+// awardPot pays the pot to the winner
+// A player who is all-in can only win as much from each player as they have at risk themselves. Whatever
+// the winner did not cover is returned to the player who paid it, and a new pot starts
+// Returns the amount won and what was returned to each player, by player ID
+func (g *Game) awardPot(winner *Player) (int, map[int64]int) {
+	stake := g.stake(winner)
+
+	// the pot carried from a previous game may not have been paid by anyone in particular
+	won := g.pot
+	refunds := make(map[int64]int)
+	for _, player := range g.allPlayers() {
+		won -= player.contributed
+		if player.contributed > stake {
+			refunds[player.PlayerID] = player.contributed - stake
+			player.balance += player.contributed - stake
+			won += stake
+		} else {
+			won += player.contributed
+		}
+
+		player.contributed = 0
+	}
+
+	winner.balance += won
+	return won, refunds
+}
+
+// allPlayers returns every player in the game, including those who folded or were booted
+func (g *Game) allPlayers() []*Player {
+	players := g.orderedPlayers()
+	for player := range g.foldedPlayers {
+		players = append(players, player)
+	}
+
+	return players
+}
+
+// sortedPlayerIDs returns the player IDs in the map in order
+func sortedPlayerIDs(amounts map[int64]int) []int64 {
+	playerIDs := make([]int64, 0, len(amounts))
+	for playerID := range amounts {
+		playerIDs = append(playerIDs, playerID)
+	}
+
+	sort.Slice(playerIDs, func(i, j int) bool {
+		return playerIDs[i] < playerIDs[j]
+	})
+
+	return playerIDs
+}
+
+// brokePlayers returns the players who have nothing left and were not already booted
+func (g *Game) brokePlayers(booted []*Player) []*Player {
+	wasBooted := make(map[*Player]bool)
+	for _, player := range booted {
+		wasBooted[player] = true
+	}
+
+	broke := make([]*Player, 0)
+	for _, player := range g.orderedPlayers() {
+		if !wasBooted[player] && player.stack() <= 0 {
+			broke = append(broke, player)
+		}
+	}
+
+	return broke
+}
+
+// continuingPlayers returns the players who can play in the next game, in order
+func (g *Game) continuingPlayers(booted []*Player) []*Player {
+	wasBooted := make(map[*Player]bool)
+	for _, player := range booted {
+		wasBooted[player] = true
+	}
+
+	continuing := make([]*Player, 0)
+	for _, player := range g.orderedPlayers() {
+		if !wasBooted[player] {
+			continuing = append(continuing, player)
+		}
+	}
+
+	return continuing
+}
+
+// orderedPlayers returns the players who are still in the game, in order
+func (g *Game) orderedPlayers() []*Player {
+	players := make([]*Player, len(g.playerOrder))
+	for player, i := range g.playerOrder {
+		players[i] = player
+	}
+
+	return players
+}
+
+// splitPotBetween splits the pot evenly between the players
+// Any remainder goes to the first players
+func splitPotBetween(players []*Player, pot int) {
+	share := pot / len(players)
+	remainder := pot % len(players)
+	for i, player := range players {
+		player.balance += share
+		if i < remainder {
+			player.balance++
+		}
+	}
+}
+
 // isTradeInRound returns true if the trade in round is in progress
 func (g *Game) isTradeInRound() bool {
 	return g.roundNo == 0
@@ -5,14 +5,43 @@ import (
 	"github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/assert"
 	"mondaynightpoker-server/pkg/deck"
+	"mondaynightpoker-server/pkg/playable"
 	"regexp"
 	"strconv"
 	"strings"
 	"testing"
 )
 
+// testTableStake covers the ante and any pot a player has to match in these tests
+const testTableStake = 10000
+
+type testParticipant struct {
+	playerID   int64
+	tableStake int
+}
+
+func (t *testParticipant) GetPlayerID() int64 {
+	return t.playerID
+}
+
+func (t *testParticipant) GetTableStake() int {
+	return t.tableStake
+}
+
+func setupParticipants(playerIDs ...int64) []playable.Player {
+	p := make([]playable.Player, len(playerIDs))
+	for i, id := range playerIDs {
+		p[i] = &testParticipant{
+			playerID:   id,
+			tableStake: testTableStake,
+		}
+	}
+
+	return p
+}
+
 func TestNewGame(t *testing.T) {
-	g, err := NewGame(logrus.StandardLogger(), []int64{10, 20}, Options{})
+	g, err := NewGame(logrus.StandardLogger(), setupParticipants(10, 20), Options{})
 	assert.NoError(t, err)
 	assert.NotNil(t, g)
 
@@ -26,7 +55,7 @@ func TestNewGame(t *testing.T) {
 
 func TestNewGameWithFiveSuitDeck(t *testing.T) {
 	a := assert.New(t)
-	g, err := NewGame(logrus.StandardLogger(), []int64{10, 20}, Options{
+	g, err := NewGame(logrus.StandardLogger(), setupParticipants(10, 20), Options{
 		FiveSuit: true,
 	})
 	a.NoError(err)
@@ -35,14 +64,14 @@ func TestNewGameWithFiveSuitDeck(t *testing.T) {
 }
 
 func Test_newGame(t *testing.T) {
-	g, err := newGame(logrus.StandardLogger(), []*Player{NewPlayer(1)}, nil, Options{})
+	g, err := newGame(logrus.StandardLogger(), []*Player{NewPlayer(1, testTableStake)}, nil, Options{})
 	assert.Nil(t, g)
 	assert.EqualError(t, err, "expected 2–8 players, got 1")
 
 	createPlayers := func(count int) []*Player {
 		players := make([]*Player, 0)
 		for i := 0; i < count; i++ {
-			players = append(players, NewPlayer(1))
+			players = append(players, NewPlayer(1, testTableStake))
 		}
 
 		return players
@@ -580,7 +609,8 @@ func setupGame(trump string, playerHands []string) (*Game, []*Player) {
 		}
 
 		players[i] = &Player{
-			hand: hand,
+			hand:       hand,
+			tableStake: testTableStake,
 		}
 	}
 
@@ -6,18 +6,23 @@ import (
 
 // Player is an individual in the game
 type Player struct {
-	PlayerID int64
-	balance  int
-	hand     []*deck.Card
-	folded   bool
-	winCount int
+	PlayerID   int64
+	balance    int
+	tableStake int
+	hand       []*deck.Card
+	folded     bool
+	winCount   int
+
+	// contributed is how much the player paid into the current pot
+	contributed int
 }
 
 // NewPlayer returns a new player
-func NewPlayer(pid int64) *Player {
+func NewPlayer(pid int64, tableStake int) *Player {
 	return &Player{
-		PlayerID: pid,
-		hand:     make([]*deck.Card, 0),
+		PlayerID:   pid,
+		tableStake: tableStake,
+		hand:       make([]*deck.Card, 0),
 	}
 }
 
@@ -95,3 +100,30 @@ func (p *Player) NewGame() {
 	p.folded = false
 	p.hand = make([]*deck.Card, 0)
 }
+
+// stack returns how much the player has left to play with
+func (p *Player) stack() int {
+	return p.tableStake + p.balance
+}
+
+// pay deducts the amount from the player's balance, but never more than they have left
+// Returns the amount that was actually paid
+func (p *Player) pay(amount int) int {
+	if stack := p.stack(); amount > stack {
+		amount = stack
+	}
+
+	if amount < 0 {
+		amount = 0
+	}
+
+	p.balance -= amount
+	return amount
+}
+
+// payIntoPot pays up to amount into the pot and returns the amount that was actually paid
+func (p *Player) payIntoPot(amount int) int {
+	paid := p.pay(amount)
+	p.contributed += paid
+	return paid
+}
@@ -43,8 +43,8 @@ func cardsToString(cards []*deck.Card) string {
 func TestPlayer_GetValidMoves(t *testing.T) {
 	a := assert.New(t)
 
-	player1 := NewPlayer(1)
-	player2 := NewPlayer(2)
+	player1 := NewPlayer(1, testTableStake)
+	player2 := NewPlayer(2, testTableStake)
 
 	g, err := newGame(logrus.StandardLogger(), []*Player{player1, player2}, nil, Options{})
 	a.NoError(err)
@@ -66,3 +66,14 @@ func TestPlayer_GetValidMoves(t *testing.T) {
 	hand = player1.GetValidMoves(g)
 	a.Equal(deck.Hand(deck.CardsFromString("4h,5h,6h")), hand)
 }
+
+func TestPlayer_pay(t *testing.T) {
+	a := assert.New(t)
+	p := NewPlayer(1, 100)
+
+	a.Equal(75, p.pay(75))
+	a.Equal(25, p.pay(50))
+	a.Equal(-100, p.balance)
+	a.Equal(0, p.stack())
+	a.Equal(0, p.pay(25))
+}
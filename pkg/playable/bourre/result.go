@@ -15,11 +15,17 @@ type Result struct {
 	Winners       []*Player
 	Folded        []*Player
 	Booted        []*Player
+	AllIn         []*Player
+	SplitPot      []*Player
 	WinningAmount int
+	SplitAmount   int
 	Ante          int
 	OldPot        int
 	NewPot        int
 
+	// Refunds is what each player got back from the pot because the winner did not cover it, by player ID
+	Refunds map[int64]int
+
 	logger      logrus.FieldLogger
 	logChan     chan []*playable.LogMessage
 	playerOrder map[*Player]int
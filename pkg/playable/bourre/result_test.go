@@ -4,6 +4,7 @@ import (
 	"mondaynightpoker-server/pkg/deck"
 	"testing"
 
+	"github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -198,3 +199,89 @@ func TestResult_NewGame_Booted(t *testing.T) {
 	}
 	assert.Equal(t, expect, details.BalanceAdjustments)
 }
+
+func TestGame_buildResults_AllIn(t *testing.T) {
+	a := assert.New(t)
+	game, players := setupGame("14S", []string{"2c", "3c", "4c"})
+	game.roundNo = 6
+	game.pot = 150
+	game.ante = 50
+
+	for _, player := range players {
+		player.balance = -50
+	}
+
+	players[0].winCount = 3
+	players[1].winCount = 2
+	players[2].winCount = 0
+	players[2].tableStake = 100
+
+	a.NoError(game.buildResults())
+	res := game.result
+
+	// players[2] only had ${50} left to pay the ${150} pot
+	a.Equal([]*Player{players[2]}, res.PaidPot)
+	a.Equal([]*Player{players[2]}, res.AllIn)
+	a.Equal([]*Player{players[2]}, res.Booted)
+	a.Equal(-100, players[2].balance)
+	a.Equal(-100, players[1].balance)
+	a.Equal(100, players[0].balance)
+	a.Equal(100, res.NewPot)
+	a.Empty(res.SplitPot)
+	a.True(res.ShouldContinue())
+}
+
+func TestGame_buildResults_SplitPot(t *testing.T) {
+	a := assert.New(t)
+	game, players := setupGame("14S", []string{"2c", "3c"})
+	game.roundNo = 6
+	game.pot = 100
+	game.ante = 50
+
+	for _, player := range players {
+		player.balance = -50
+	}
+
+	players[0].winCount = 5
+	players[1].winCount = 0
+	players[1].tableStake = 75
+
+	a.NoError(game.buildResults())
+	res := game.result
+
+	// players[1] cannot play for the next pot, so players[0] takes it
+	a.Equal([]*Player{players[1]}, res.AllIn)
+	a.Equal([]*Player{players[1]}, res.Booted)
+	a.Equal([]*Player{players[0]}, res.SplitPot)
+	a.Equal(25, res.SplitAmount)
+	a.Equal(0, res.NewPot)
+	a.False(res.ShouldContinue())
+	a.Equal(75, players[0].balance)
+	a.Equal(-75, players[1].balance)
+}
+
+func TestGame_buildResults_AllInWinner(t *testing.T) {
+	a := assert.New(t)
+
+	players := []*Player{NewPlayer(1, testTableStake), NewPlayer(2, testTableStake), NewPlayer(3, 5)}
+	game, err := newGame(logrus.StandardLogger(), players, nil, Options{Ante: 25})
+	a.NoError(err)
+	a.Equal(55, game.pot)
+
+	game.roundNo = 6
+	players[0].winCount = 1
+	players[1].winCount = 1
+	players[2].winCount = 3
+
+	a.NoError(game.buildResults())
+	res := game.result
+
+	// players[2] only anted ${5}, so they win ${5} from each player and the rest is returned
+	a.Equal([]*Player{players[2]}, res.Winners)
+	a.Equal(15, res.WinningAmount)
+	a.Equal(map[int64]int{1: 20, 2: 20}, res.Refunds)
+	a.Equal(-5, players[0].balance)
+	a.Equal(-5, players[1].balance)
+	a.Equal(10, players[2].balance)
+	a.False(res.ShouldContinue())
+}
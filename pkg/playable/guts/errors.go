@@ -11,6 +11,9 @@ var ErrNotInDeclarationPhase = errors.New("not in declaration phase")
 // ErrAlreadyDecided is returned when a player has already made their decision
 var ErrAlreadyDecided = errors.New("player has already decided")
 
+// ErrNoMoneyLeft is returned when a player who has lost their table stake tries to go in
+var ErrNoMoneyLeft = errors.New("you have no money left to go in with")
+
 // ErrPlayerNotFound is returned when a player is not found in the game
 var ErrPlayerNotFound = errors.New("player not found")
 
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"mondaynightpoker-server/pkg/deck"
 	"mondaynightpoker-server/pkg/playable"
+	"sort"
 	"time"
 
 	"github.com/google/uuid"
@@ -53,6 +54,9 @@ type Game struct {
 	phase       Phase
 	roundNumber int
 
+	// contributions is how much each participant paid into the current pot
+	contributions map[int64]int
+
 	// Simultaneous declaration tracking
 	pendingDecisions map[int64]bool // Who hasn't decided yet
 	decisions        map[int64]bool // true=In, false=Out
@@ -169,62 +173,117 @@ func (g *Game) GetEndOfGameDetails() (gameOverDetails *playable.GameOverDetails,
 }
 
 // NewGame returns a new guts game
-func NewGame(logger logrus.FieldLogger, playerIDs []int64, opts Options) (*Game, error) {
-	if len(playerIDs) < 2 || len(playerIDs) > 10 {
+// A participant who cannot cover the ante or a penalty is all-in, and only wins up to their stake from each player
+func NewGame(logger logrus.FieldLogger, players []playable.Player, opts Options) (*Game, error) {
+	if len(players) < 2 || len(players) > 10 {
 		return nil, PlayerCountError{
 			Min: 2,
 			Max: 10,
-			Got: len(playerIDs),
+			Got: len(players),
 		}
 	}
 
-	participants := make([]*Participant, len(playerIDs))
+	participants := make([]*Participant, len(players))
 	idToParticipant := make(map[int64]*Participant)
 
-	for i, pid := range playerIDs {
-		p := NewParticipant(pid)
+	for i, player := range players {
+		p := NewParticipant(player.GetPlayerID(), player.GetTableStake())
 		participants[i] = p
-		idToParticipant[pid] = p
+		idToParticipant[p.PlayerID] = p
 	}
 
 	d := deck.New()
 	d.Shuffle()
 
-	pot := 0
-	messages := make([]*playable.LogMessage, 0)
-
-	for _, p := range participants {
-		pot += opts.Ante
-		p.balance -= opts.Ante
-		messages = append(messages, newLogMessage(p.PlayerID, "{} paid the ${%d} ante", opts.Ante))
-	}
-
 	g := &Game{
 		options:          opts,
 		deck:             d,
 		participants:     participants,
 		idToParticipant:  idToParticipant,
-		pot:              pot,
 		phase:            PhaseDealing,
 		roundNumber:      1,
+		contributions:    make(map[int64]int),
 		pendingDecisions: make(map[int64]bool),
 		decisions:        make(map[int64]bool),
 		logger:           logger,
 		logChan:          make(chan []*playable.LogMessage, 256),
 	}
 
-	messages = append(messages, newLogMessage(0, "New game of %s started with a pot of ${%d}", NameFromOptions(opts), pot))
+	messages := g.collectAntes()
+	messages = append(messages, newLogMessage(0, "New game of %s started with a pot of ${%d}", NameFromOptions(opts), g.pot))
 	g.sendLogMessages(messages...)
 
 	return g, nil
 }
 
+// collectAntes adds an ante from every participant to the pot
+// A participant who has less than the ante left is all-in with what they have
+func (g *Game) collectAntes() []*playable.LogMessage {
+	messages := make([]*playable.LogMessage, 0, len(g.participants))
+	for _, p := range g.participants {
+		if p.stack() <= 0 {
+			continue
+		}
+
+		paid := g.payIntoPot(p, g.options.Ante)
+		if paid < g.options.Ante {
+			messages = append(messages, newLogMessage(p.PlayerID, "{} is all-in with a ${%d} ante", paid))
+		} else {
+			messages = append(messages, newLogMessage(p.PlayerID, "{} paid the ${%d} ante", paid))
+		}
+	}
+
+	return messages
+}
+
+// payIntoPot moves up to amount from the participant to the pot and returns the amount paid
+func (g *Game) payIntoPot(p *Participant, amount int) int {
+	paid := p.pay(amount)
+	g.pot += paid
+	g.contributions[p.PlayerID] += paid
+	return paid
+}
+
+// canPlay returns true if the participant has money left to go in with
+func (g *Game) canPlay(p *Participant) bool {
+	return p.stack() > 0
+}
+
+// returnPot gives every participant back what they paid into the pot and ends the game
+func (g *Game) returnPot() {
+	for _, p := range g.participants {
+		p.balance += g.contributions[p.PlayerID]
+	}
+
+	g.sendLogMessages(newLogMessage(0, "Fewer than two players have money left, the pot of ${%d} is returned", g.pot))
+
+	g.pot = 0
+	g.contributions = make(map[int64]int)
+	g.phase = PhaseGameOver
+	g.pendingDealerAction = &pendingDealerAction{
+		Action:       dealerActionEndGame,
+		ExecuteAfter: time.Now().Add(time.Second * 2),
+	}
+}
+
 // Deal will deal cards to each participant
 func (g *Game) Deal() error {
 	if len(g.participants) < 2 {
 		return ErrNotEnoughPlayers
 	}
 
+	playing := 0
+	for _, p := range g.participants {
+		if g.canPlay(p) {
+			playing++
+		}
+	}
+
+	if playing < 2 {
+		g.returnPot()
+		return nil
+	}
+
 	// Clear hands and reset for new round
 	for _, p := range g.participants {
 		p.ClearHand()
@@ -246,14 +305,22 @@ func (g *Game) Deal() error {
 	}
 
 	// Initialize pending decisions
+	// Participants who have nothing left cannot go in
 	g.pendingDecisions = make(map[int64]bool)
 	g.decisions = make(map[int64]bool)
+	messages := []*playable.LogMessage{newLogMessage(0, "Round %d: Cards dealt, declare In or Out", g.roundNumber)}
 	for _, p := range g.participants {
+		if !g.canPlay(p) {
+			g.decisions[p.PlayerID] = false
+			messages = append(messages, newLogMessage(p.PlayerID, "{} has no money left and sits out"))
+			continue
+		}
+
 		g.pendingDecisions[p.PlayerID] = true
 	}
 
 	g.phase = PhaseDeclaration
-	g.sendLogMessages(newLogMessage(0, "Round %d: Cards dealt, declare In or Out", g.roundNumber))
+	g.sendLogMessages(messages...)
 
 	return nil
 }
@@ -264,6 +331,10 @@ func (g *Game) submitDecision(playerID int64, goIn bool) error {
 		return ErrNotInDeclarationPhase
 	}
 
+	if p, ok := g.idToParticipant[playerID]; ok && !g.canPlay(p) {
+		return ErrNoMoneyLeft
+	}
+
 	if !g.pendingDecisions[playerID] {
 		return ErrAlreadyDecided
 	}
@@ -335,14 +406,15 @@ func (g *Game) calculateShowdown() {
 		}
 
 		// Regular mode: player wins automatically
-		player.balance += g.pot
+		won, refunds := g.awardPot([]*Participant{player})
 		result.Winners = []*Participant{player}
 		result.WinningHand = AnalyzeHand(player.hand)
-		result.PotWon = g.pot
+		result.PotWon = won
 		result.SingleWinner = true
 		g.showdownResult = result
 
-		g.sendLogMessages(newLogMessage(player.PlayerID, "{} wins ${%d} (only one in)", g.pot))
+		g.sendLogMessages(newLogMessage(player.PlayerID, "{} wins ${%d} (only one in)", won))
+		g.sendLogMessages(refunds...)
 
 		// Game ends
 		g.phase = PhaseGameOver
@@ -386,29 +458,21 @@ func (g *Game) calculateShowdown() {
 	result.Winners = winners
 	result.Losers = losers
 	result.WinningHand = AnalyzeHand(winners[0].hand)
-	result.PotWon = g.pot
-
-	// Distribute pot to winners
-	winPerPerson := g.pot / len(winners)
-	remainder := g.pot % len(winners)
-	for i, w := range winners {
-		winAmount := winPerPerson
-		if i < remainder {
-			winAmount++ // Distribute remainder
-		}
-		w.balance += winAmount
-	}
 
-	// Calculate penalty (capped at maxOwed)
+	// Calculate penalty (capped at maxOwed) before the pot is distributed
 	penalty := g.calculatePenalty()
+
+	// Distribute pot to winners
+	won, refunds := g.awardPot(winners)
+	result.PotWon = won
 	result.PenaltyPaid = penalty
 
 	// Losers pay penalty into next pot
-	nextPot := 0
+	paid := make(map[int64]int, len(losers))
 	for _, loser := range losers {
-		loser.balance -= penalty
-		nextPot += penalty
+		paid[loser.PlayerID] = g.payIntoPot(loser, penalty)
 	}
+	nextPot := g.pot
 	result.NextPot = nextPot
 
 	g.showdownResult = result
@@ -416,22 +480,23 @@ func (g *Game) calculateShowdown() {
 	// Log results
 	if len(winners) == 1 {
 		g.sendLogMessages(newLogMessage(winners[0].PlayerID, "{} wins ${%d} with %s",
-			g.pot, HandTypeName(result.WinningHand.Type)))
+			result.PotWon, HandTypeName(result.WinningHand.Type)))
 	} else {
 		playerIDs := make([]int64, len(winners))
 		for i, w := range winners {
 			playerIDs[i] = w.PlayerID
 		}
-		g.sendLogMessages(newLogMessageWithPlayers(playerIDs, "{} split the pot of ${%d}", g.pot))
+		g.sendLogMessages(newLogMessageWithPlayers(playerIDs, "{} split the pot of ${%d}", result.PotWon))
 	}
 
+	g.sendLogMessages(refunds...)
+
 	for _, loser := range losers {
-		g.sendLogMessages(newLogMessage(loser.PlayerID, "{} pays penalty of ${%d}", penalty))
+		g.sendLogMessages(g.penaltyLogMessage(loser, paid[loser.PlayerID], penalty))
 	}
 
 	// If there are losers who paid penalties, continue the game
 	if nextPot > 0 {
-		g.pot = nextPot
 		g.pendingDealerAction = &pendingDealerAction{
 			Action:       dealerActionNextRound,
 			ExecuteAfter: time.Now().Add(time.Second * 5),
@@ -447,6 +512,86 @@ func (g *Game) calculateShowdown() {
 	}
 }
 
+// stake returns how much the participant has at risk in the current pot: what they paid into it and
+// as much of the penalty as they could pay if they lost
+func (g *Game) stake(p *Participant) int {
+	atRisk := g.calculatePenalty()
+	if stack := p.stack(); stack < atRisk {
+		atRisk = stack
+	}
+
+	return g.contributions[p.PlayerID] + atRisk
+}
+
+// awardPot pays the pot to the winners and empties it
+// A winner only wins up to their stake from what each participant paid into the pot, the same as a
+// side pot. Whatever no winner covered is returned to the participant who paid it
+// Returns the amount the winners won and a log message for each refund
+func (g *Game) awardPot(winners []*Participant) (int, []*playable.LogMessage) {
+	stakes := make(map[int64]int, len(winners))
+	byStake := append([]*Participant{}, winners...)
+	for _, w := range winners {
+		stakes[w.PlayerID] = g.stake(w)
+	}
+
+	sort.SliceStable(byStake, func(i, j int) bool {
+		return stakes[byStake[i].PlayerID] < stakes[byStake[j].PlayerID]
+	})
+
+	// each level of the pot is split by the winners who staked at least that much
+	won := 0
+	lastLevel := 0
+	for i, w := range byStake {
+		level := stakes[w.PlayerID]
+		amount := 0
+		for _, p := range g.participants {
+			amount += minInt(g.contributions[p.PlayerID], level) - minInt(g.contributions[p.PlayerID], lastLevel)
+		}
+
+		eligible := byStake[i:]
+		for j, e := range eligible {
+			share := amount / len(eligible)
+			if j < amount%len(eligible) {
+				share++ // Distribute remainder
+			}
+			e.balance += share
+		}
+
+		won += amount
+		lastLevel = level
+	}
+
+	messages := make([]*playable.LogMessage, 0)
+	for _, p := range g.participants {
+		if refund := g.contributions[p.PlayerID] - lastLevel; refund > 0 {
+			p.balance += refund
+			messages = append(messages, newLogMessage(p.PlayerID, "{} gets back ${%d} that the winner did not cover", refund))
+		}
+	}
+
+	g.pot = 0
+	g.contributions = make(map[int64]int)
+
+	return won, messages
+}
+
+func minInt(a, b int) int {
+	if a < b {
+		return a
+	}
+
+	return b
+}
+
+// penaltyLogMessage returns the log message for a participant who paid a penalty
+func (g *Game) penaltyLogMessage(p *Participant, paid, penalty int) *playable.LogMessage {
+	if paid < penalty {
+		return newLogMessage(p.PlayerID, "{} is all-in and pays ${%d} of the ${%d} penalty", paid, penalty)
+	}
+
+	return newLogMessage(p.PlayerID, "{} pays penalty of ${%d}", penalty)
+}
+
 // calculatePenalty returns the penalty amount (pot capped at maxOwed)
 func (g *Game) calculatePenalty() int {
 	if g.pot > g.options.MaxOwed {
@@ -523,17 +668,18 @@ func (g *Game) resolveBloodyGuts() {
 	// Player must strictly beat the deck (deck wins on ties)
 	if playerHand.Strength > deckHandResult.Strength {
 		// Player wins
-		player.balance += g.pot
+		won, refunds := g.awardPot([]*Participant{player})
 		result.Winners = []*Participant{player}
 		result.WinningHand = playerHand
-		result.PotWon = g.pot
+		result.PotWon = won
 		result.SingleWinner = true
 		result.DeckWon = false
 
 		g.sendLogMessages(
 			newLogMessage(player.PlayerID, "{} beats the deck with %s and wins ${%d}",
-				HandTypeName(playerHand.Type), g.pot),
+				HandTypeName(playerHand.Type), won),
 		)
+		g.sendLogMessages(refunds...)
 
 		// Game ends
 		g.phase = PhaseGameOver
@@ -549,14 +695,20 @@ func (g *Game) resolveBloodyGuts() {
 		penalty := g.calculatePenalty()
 		result.PenaltyPaid = penalty
 
-		player.balance -= penalty
-		result.NextPot = g.pot + penalty
-		g.pot += penalty
-
-		g.sendLogMessages(
-			newLogMessage(player.PlayerID, "The deck wins with %s! {} pays penalty of ${%d}",
-				HandTypeName(deckHandResult.Type), penalty),
-		)
+		paid := g.payIntoPot(player, penalty)
+		result.NextPot = g.pot
+
+		if paid < penalty {
+			g.sendLogMessages(
+				newLogMessage(player.PlayerID, "The deck wins with %s! {} is all-in and pays ${%d} of the ${%d} penalty",
+					HandTypeName(deckHandResult.Type), paid, penalty),
+			)
+		} else {
+			g.sendLogMessages(
+				newLogMessage(player.PlayerID, "The deck wins with %s! {} pays penalty of ${%d}",
+					HandTypeName(deckHandResult.Type), penalty),
+			)
+		}
 
 		// Continue to next round
 		g.pendingDealerAction = &pendingDealerAction{
@@ -570,11 +722,9 @@ func (g *Game) resolveBloodyGuts() {
 func (g *Game) nextRound() error {
 	// If everyone folded, re-ante
 	if g.showdownResult != nil && g.showdownResult.AllFolded {
-		for _, p := range g.participants {
-			p.balance -= g.options.Ante
-			g.pot += g.options.Ante
-		}
-		g.sendLogMessages(newLogMessage(0, "Everyone re-anted. Pot is now ${%d}", g.pot))
+		messages := g.collectAntes()
+		messages = append(messages, newLogMessage(0, "Everyone re-anted. Pot is now ${%d}", g.pot))
+		g.sendLogMessages(messages...)
 	}
 
 	g.roundNumber++
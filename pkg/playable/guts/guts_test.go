@@ -11,8 +11,36 @@ import (
 	"github.com/stretchr/testify/assert"
 )
 
+// testTableStake covers every ante and penalty in these tests, so a participant is only all-in when a test lowers their stake
+const testTableStake = 10000
+
+type testParticipant struct {
+	playerID   int64
+	tableStake int
+}
+
+func (t *testParticipant) GetPlayerID() int64 {
+	return t.playerID
+}
+
+func (t *testParticipant) GetTableStake() int {
+	return t.tableStake
+}
+
+func setupParticipants(playerIDs ...int64) []playable.Player {
+	p := make([]playable.Player, len(playerIDs))
+	for i, id := range playerIDs {
+		p[i] = &testParticipant{
+			playerID:   id,
+			tableStake: testTableStake,
+		}
+	}
+
+	return p
+}
+
 func TestNewGame(t *testing.T) {
-	g, err := NewGame(logrus.StandardLogger(), []int64{1, 2}, DefaultOptions())
+	g, err := NewGame(logrus.StandardLogger(), setupParticipants(1, 2), DefaultOptions())
 	assert.NoError(t, err)
 	assert.NotNil(t, g)
 
@@ -23,7 +51,7 @@ func TestNewGame(t *testing.T) {
 
 func TestNewGame_PlayerCount(t *testing.T) {
 	// Too few players
-	g, err := NewGame(logrus.StandardLogger(), []int64{1}, DefaultOptions())
+	g, err := NewGame(logrus.StandardLogger(), setupParticipants(1), DefaultOptions())
 	assert.Nil(t, g)
 	assert.EqualError(t, err, "expected 2–10 players, got 1")
 
@@ -32,7 +60,7 @@ func TestNewGame_PlayerCount(t *testing.T) {
 	for i := range playerIDs {
 		playerIDs[i] = int64(i + 1)
 	}
-	g, err = NewGame(logrus.StandardLogger(), playerIDs, DefaultOptions())
+	g, err = NewGame(logrus.StandardLogger(), setupParticipants(playerIDs...), DefaultOptions())
 	assert.Nil(t, g)
 	assert.EqualError(t, err, "expected 2–10 players, got 11")
 
@@ -42,7 +70,7 @@ func TestNewGame_PlayerCount(t *testing.T) {
 		for i := range pids {
 			pids[i] = int64(i + 1)
 		}
-		g, err = NewGame(logrus.StandardLogger(), pids, DefaultOptions())
+		g, err = NewGame(logrus.StandardLogger(), setupParticipants(pids...), DefaultOptions())
 		assert.NoError(t, err, "should allow %d players", count)
 		assert.NotNil(t, g)
 	}
@@ -50,7 +78,7 @@ func TestNewGame_PlayerCount(t *testing.T) {
 
 func TestNewGame_AnteDeducted(t *testing.T) {
 	opts := Options{Ante: 50, MaxOwed: 1000}
-	g, err := NewGame(logrus.StandardLogger(), []int64{1, 2, 3}, opts)
+	g, err := NewGame(logrus.StandardLogger(), setupParticipants(1, 2, 3), opts)
 	assert.NoError(t, err)
 
 	// Each player should have paid ante
@@ -63,7 +91,7 @@ func TestNewGame_AnteDeducted(t *testing.T) {
 }
 
 func TestGame_Deal(t *testing.T) {
-	g, _ := NewGame(logrus.StandardLogger(), []int64{1, 2}, DefaultOptions())
+	g, _ := NewGame(logrus.StandardLogger(), setupParticipants(1, 2), DefaultOptions())
 
 	err := g.Deal()
 	assert.NoError(t, err)
@@ -448,7 +476,7 @@ func TestNameFromOptions(t *testing.T) {
 
 func TestGame_Deal_3Card(t *testing.T) {
 	opts := Options{Ante: 25, MaxOwed: 1000, CardCount: 3}
-	g, err := NewGame(logrus.StandardLogger(), []int64{1, 2}, opts)
+	g, err := NewGame(logrus.StandardLogger(), setupParticipants(1, 2), opts)
 	assert.NoError(t, err)
 
 	err = g.Deal()
@@ -463,7 +491,7 @@ func TestGame_Deal_3Card(t *testing.T) {
 func TestGame_Deal_InvalidCardCount(t *testing.T) {
 	// CardCount of 1 should default to 2
 	opts := Options{Ante: 25, MaxOwed: 1000, CardCount: 1}
-	g, err := NewGame(logrus.StandardLogger(), []int64{1, 2}, opts)
+	g, err := NewGame(logrus.StandardLogger(), setupParticipants(1, 2), opts)
 	assert.NoError(t, err)
 
 	err = g.Deal()
@@ -475,7 +503,7 @@ func TestGame_Deal_InvalidCardCount(t *testing.T) {
 
 	// CardCount of 4 should default to 2
 	opts = Options{Ante: 25, MaxOwed: 1000, CardCount: 4}
-	g, err = NewGame(logrus.StandardLogger(), []int64{1, 2}, opts)
+	g, err = NewGame(logrus.StandardLogger(), setupParticipants(1, 2), opts)
 	assert.NoError(t, err)
 
 	err = g.Deal()
@@ -529,7 +557,7 @@ func setupTestGame3Card(t *testing.T, hands []string) *Game {
 	}
 
 	opts := Options{Ante: 25, MaxOwed: 1000, CardCount: 3}
-	g, err := NewGame(logrus.StandardLogger(), playerIDs, opts)
+	g, err := NewGame(logrus.StandardLogger(), setupParticipants(playerIDs...), opts)
 	if err != nil {
 		t.Fatalf("failed to create game: %v", err)
 	}
@@ -560,7 +588,7 @@ func setupTestGame(t *testing.T, hands []string) *Game {
 		playerIDs[i] = int64(i + 1)
 	}
 
-	g, err := NewGame(logrus.StandardLogger(), playerIDs, DefaultOptions())
+	g, err := NewGame(logrus.StandardLogger(), setupParticipants(playerIDs...), DefaultOptions())
 	if err != nil {
 		t.Fatalf("failed to create game: %v", err)
 	}
@@ -592,7 +620,7 @@ func setupBloodyGutsTestGameWithCardCount(t *testing.T, hands []string, deckCard
 	}
 
 	opts := Options{Ante: 25, MaxOwed: 1000, CardCount: cardCount, BloodyGuts: true}
-	g, err := NewGame(logrus.StandardLogger(), playerIDs, opts)
+	g, err := NewGame(logrus.StandardLogger(), setupParticipants(playerIDs...), opts)
 	if err != nil {
 		t.Fatalf("failed to create game: %v", err)
 	}
@@ -1121,3 +1149,105 @@ func TestBloodyGuts_RevealFieldsClearedOnNextRound(t *testing.T) {
 	assert.Equal(t, int64(0), g.bloodyGutsPlayer)
 	assert.Nil(t, g.deckHand)
 }
+
+func TestGame_TableStake(t *testing.T) {
+	g := setupTestGame(t, []string{"14c,14d", "13c,13d", "2c,7d"})
+	g.idToParticipant[3].tableStake = 100
+
+	// player 3 paid the ante, but only has ${75} left for the ${75} penalty
+	_ = g.submitDecision(1, true)
+	_ = g.submitDecision(2, false)
+	_ = g.submitDecision(3, true)
+	g.calculateShowdown()
+
+	assert.Equal(t, 75, g.showdownResult.PenaltyPaid)
+	assert.Equal(t, -100, g.idToParticipant[3].balance)
+	assert.Equal(t, 75, g.pot)
+
+	// player 3 is out of money and sits out the next round
+	g.pendingDealerAction = nil
+	assert.NoError(t, g.nextRound())
+	assert.False(t, g.pendingDecisions[3])
+	assert.Equal(t, ErrNoMoneyLeft, g.submitDecision(3, true))
+	assert.True(t, g.pendingDecisions[1])
+	assert.True(t, g.pendingDecisions[2])
+}
+
+func TestGame_TableStake_PenaltyAllIn(t *testing.T) {
+	g := setupTestGame(t, []string{"14c,14d", "2c,7d"})
+	g.idToParticipant[2].tableStake = 40
+
+	_ = g.submitDecision(1, true)
+	_ = g.submitDecision(2, true)
+	g.calculateShowdown()
+
+	// the penalty is ${50}, but player 2 only had ${15} left after the ante
+	assert.Equal(t, 50, g.showdownResult.PenaltyPaid)
+	assert.Equal(t, 15, g.showdownResult.NextPot)
+	assert.Equal(t, -40, g.idToParticipant[2].balance)
+	assert.Equal(t, 25, g.idToParticipant[1].balance)
+
+	// only one player has money left, so the pot is returned and the game ends
+	g.pendingDealerAction = nil
+	assert.NoError(t, g.nextRound())
+	assert.Equal(t, PhaseGameOver, g.phase)
+	assert.Equal(t, 0, g.pot)
+	assert.Equal(t, -25, g.idToParticipant[2].balance)
+	assert.Equal(t, 25, g.idToParticipant[1].balance)
+	assert.Equal(t, dealerActionEndGame, g.pendingDealerAction.Action)
+}
+
+func TestGame_TableStake_ShortWinner(t *testing.T) {
+	g := setupTestGame(t, []string{"14c,14d", "13c,12d", "2c,7d"})
+	g.idToParticipant[1].tableStake = 30
+
+	// players 2 and 3 paid a ${100} penalty into the pot in an earlier round
+	for _, id := range []int64{2, 3} {
+		g.idToParticipant[id].balance -= 100
+		g.contributions[id] += 100
+		g.pot += 100
+	}
+
+	_ = g.submitDecision(1, true)
+	_ = g.submitDecision(2, true)
+	_ = g.submitDecision(3, true)
+	g.calculateShowdown()
+
+	// player 1 only had ${30} at risk, so they win at most ${30} from each player
+	assert.Equal(t, 85, g.showdownResult.PotWon)
+	assert.Equal(t, 60, g.idToParticipant[1].balance)
+
+	// the rest of the pot is returned before the losers pay the ${275} penalty
+	assert.Equal(t, 275, g.showdownResult.PenaltyPaid)
+	assert.Equal(t, -25-100+95-275, g.idToParticipant[2].balance)
+	assert.Equal(t, -25-100+95-275, g.idToParticipant[3].balance)
+	assert.Equal(t, 550, g.showdownResult.NextPot)
+}
+
+func TestGame_Showdown_OnePersonIn_ShortWinner(t *testing.T) {
+	g := setupTestGame(t, []string{"14c,14d", "13c,12d"})
+	g.idToParticipant[1].tableStake = 30
+	g.idToParticipant[2].balance -= 100
+	g.contributions[2] += 100
+	g.pot += 100
+
+	_ = g.submitDecision(1, true)
+	_ = g.submitDecision(2, false)
+	g.calculateShowdown()
+
+	assert.Equal(t, 55, g.showdownResult.PotWon)
+	assert.Equal(t, 30, g.idToParticipant[1].balance)
+	assert.Equal(t, -30, g.idToParticipant[2].balance)
+	assert.Equal(t, 0, g.pot)
+}
+
+func TestNewGame_AnteAllIn(t *testing.T) {
+	players := setupParticipants(1, 2)
+	players[1].(*testParticipant).tableStake = 10
+
+	g, err := NewGame(logrus.StandardLogger(), players, DefaultOptions())
+	assert.NoError(t, err)
+	assert.Equal(t, 35, g.pot)
+	assert.Equal(t, -25, g.idToParticipant[1].balance)
+	assert.Equal(t, -10, g.idToParticipant[2].balance)
+}
@@ -4,16 +4,18 @@ import "mondaynightpoker-server/pkg/deck"
 
 // Participant is an individual in the guts game
 type Participant struct {
-	PlayerID int64
-	balance  int
-	hand     []*deck.Card
+	PlayerID   int64
+	balance    int
+	tableStake int
+	hand       []*deck.Card
 }
 
 // NewParticipant returns a new participant
-func NewParticipant(playerID int64) *Participant {
+func NewParticipant(playerID int64, tableStake int) *Participant {
 	return &Participant{
-		PlayerID: playerID,
-		hand:     make([]*deck.Card, 0, 3),
+		PlayerID:   playerID,
+		tableStake: tableStake,
+		hand:       make([]*deck.Card, 0, 3),
 	}
 }
 
@@ -31,3 +33,23 @@ func (p *Participant) Hand() []*deck.Card {
 func (p *Participant) ClearHand() {
 	p.hand = make([]*deck.Card, 0, 3)
 }
+
+// stack returns how much the participant has left to play with
+func (p *Participant) stack() int {
+	return p.tableStake + p.balance
+}
+
+// pay deducts the amount from the participant's balance, but never more than they have left
+// Returns the amount that was actually paid
+func (p *Participant) pay(amount int) int {
+	if stack := p.stack(); amount > stack {
+		amount = stack
+	}
+
+	if amount < 0 {
+		amount = 0
+	}
+
+	p.balance -= amount
+	return amount
+}
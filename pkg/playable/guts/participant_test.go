@@ -8,15 +8,16 @@ import (
 )
 
 func TestNewParticipant(t *testing.T) {
-	p := NewParticipant(123)
+	p := NewParticipant(123, 2000)
 
 	assert.Equal(t, int64(123), p.PlayerID)
 	assert.Equal(t, 0, p.balance)
+	assert.Equal(t, 2000, p.tableStake)
 	assert.Empty(t, p.hand)
 }
 
 func TestParticipant_AddCard(t *testing.T) {
-	p := NewParticipant(1)
+	p := NewParticipant(1, 2000)
 
 	card1 := deck.CardFromString("14c")
 	card2 := deck.CardFromString("13d")
@@ -29,7 +30,7 @@ func TestParticipant_AddCard(t *testing.T) {
 }
 
 func TestParticipant_Hand(t *testing.T) {
-	p := NewParticipant(1)
+	p := NewParticipant(1, 2000)
 
 	card1 := deck.CardFromString("14c")
 	card2 := deck.CardFromString("13d")
@@ -45,7 +46,7 @@ func TestParticipant_Hand(t *testing.T) {
 }
 
 func TestParticipant_ClearHand(t *testing.T) {
-	p := NewParticipant(1)
+	p := NewParticipant(1, 2000)
 
 	p.AddCard(deck.CardFromString("14c"))
 	p.AddCard(deck.CardFromString("13d"))
@@ -54,3 +55,19 @@ func TestParticipant_ClearHand(t *testing.T) {
 	p.ClearHand()
 	assert.Empty(t, p.hand)
 }
+
+func TestParticipant_pay(t *testing.T) {
+	p := NewParticipant(1, 100)
+
+	assert.Equal(t, 75, p.pay(75))
+	assert.Equal(t, -75, p.balance)
+	assert.Equal(t, 25, p.stack())
+
+	// cannot pay more than what is left
+	assert.Equal(t, 25, p.pay(50))
+	assert.Equal(t, -100, p.balance)
+	assert.Equal(t, 0, p.stack())
+
+	assert.Equal(t, 0, p.pay(25))
+	assert.Equal(t, -100, p.balance)
+}
@@ -181,7 +181,7 @@ func TestDiarrheaEdition_EndRound_DoubleDoubleBail(t *testing.T) {
 }
 
 func TestDiarrheaEdition_EndRound_AcePassBack(t *testing.T) {
-	game, err := NewGame(logrus.StandardLogger(), []int64{1, 2, 3}, Options{
+	game, err := NewGame(logrus.StandardLogger(), setupParticipants(1, 2, 3), Options{
 		Ante:    75,
 		Lives:   3,
 		Edition: &DiarrheaEdition{},
@@ -220,7 +220,7 @@ func TestDiarrheaEdition_EndRound_AcePassBack(t *testing.T) {
 }
 
 func TestDiarrheaEdition_EndRound_AceFromDeck(t *testing.T) {
-	game, err := NewGame(logrus.StandardLogger(), []int64{1, 2, 3, 4, 5}, Options{
+	game, err := NewGame(logrus.StandardLogger(), setupParticipants(1, 2, 3, 4, 5), Options{
 		Ante:    75,
 		Lives:   3,
 		Edition: &DiarrheaEdition{},
@@ -273,7 +273,7 @@ func TestDiarrheaEdition_EndRound_AceFromDeck(t *testing.T) {
 }
 
 func TestDiarrheaEdition_EndRound_TripleAce(t *testing.T) {
-	game, err := NewGame(logrus.StandardLogger(), []int64{1, 2}, Options{
+	game, err := NewGame(logrus.StandardLogger(), setupParticipants(1, 2), Options{
 		Ante:    75,
 		Lives:   3,
 		Edition: &DiarrheaEdition{},
@@ -306,7 +306,7 @@ func TestDiarrheaEdition_EndRound_TripleAce(t *testing.T) {
 }
 
 func TestDiarrheaEdition_EndRound_TripleAce_OneLife(t *testing.T) {
-	game, err := NewGame(logrus.StandardLogger(), []int64{1, 2}, Options{
+	game, err := NewGame(logrus.StandardLogger(), setupParticipants(1, 2), Options{
 		Ante:    75,
 		Lives:   1,
 		Edition: &DiarrheaEdition{},
@@ -332,7 +332,7 @@ func TestDiarrheaEdition_EndRound_TripleAce_OneLife(t *testing.T) {
 }
 
 func TestDiarrheaEdition_EndRound_DoubleAce_DoubleD(t *testing.T) {
-	game, err := NewGame(logrus.StandardLogger(), []int64{1, 2, 3, 4}, Options{
+	game, err := NewGame(logrus.StandardLogger(), setupParticipants(1, 2, 3, 4), Options{
 		Ante:    75,
 		Lives:   1,
 		Edition: &DiarrheaEdition{},
@@ -370,7 +370,7 @@ func TestDiarrheaEdition_EndRound_DoubleAce_DoubleD(t *testing.T) {
 }
 
 func TestDiarrheaEdition_EndRound_AceToKing(t *testing.T) {
-	game, err := NewGame(logrus.StandardLogger(), []int64{1, 2, 3, 4}, Options{
+	game, err := NewGame(logrus.StandardLogger(), setupParticipants(1, 2, 3, 4), Options{
 		Ante:    75,
 		Lives:   2,
 		Edition: &DiarrheaEdition{},
@@ -409,7 +409,7 @@ func TestDiarrheaEdition_EndRound_AceToKing(t *testing.T) {
 }
 
 func TestDiarrheaEdition_EndRound_AcePassBack_2(t *testing.T) {
-	game, err := NewGame(logrus.StandardLogger(), []int64{1, 2, 3, 4, 5}, Options{
+	game, err := NewGame(logrus.StandardLogger(), setupParticipants(1, 2, 3, 4, 5), Options{
 		Ante:    75,
 		Lives:   2,
 		Edition: &DiarrheaEdition{},
@@ -488,7 +488,7 @@ func TestDiarrheaEdition_EndRound_AcePassBack_2(t *testing.T) {
 }
 
 func TestDiarrheaEdition_EndRound_AceFromDeck_DoubleD_Safe(t *testing.T) {
-	game, err := NewGame(logrus.StandardLogger(), []int64{1, 2, 3, 4}, Options{
+	game, err := NewGame(logrus.StandardLogger(), setupParticipants(1, 2, 3, 4), Options{
 		Ante:    75,
 		Lives:   3,
 		Edition: &DiarrheaEdition{},
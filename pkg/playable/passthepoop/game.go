@@ -55,8 +55,9 @@ type Game struct {
 }
 
 // NewGame returns a new game
-func NewGame(logger logrus.FieldLogger, playerIDs []int64, options Options) (*Game, error) {
-	if len(playerIDs) < 2 {
+// A player whose table stake is less than the ante is all-in
+func NewGame(logger logrus.FieldLogger, players []playable.Player, options Options) (*Game, error) {
+	if len(players) < 2 {
 		return nil, errors.New("game requires at least two players")
 	}
 
@@ -73,17 +74,26 @@ func NewGame(logger logrus.FieldLogger, playerIDs []int64, options Options) (*Ga
 	d.Shuffle()
 
 	idToParticipants := make(map[int64]*Participant)
-	participants := make([]*Participant, len(playerIDs))
+	participants := make([]*Participant, len(players))
+	playerIDs := make([]int64, len(players))
 	pot := 0
-	for i, id := range playerIDs {
-		pot += options.Ante
+	for i, player := range players {
+		ante := options.Ante
+		if stake := player.GetTableStake(); stake < ante {
+			ante = stake
+		}
+
+		pot += ante
+		playerIDs[i] = player.GetPlayerID()
 		participants[i] = &Participant{
-			PlayerID: id,
-			lives:    options.Lives,
-			balance:  -1 * options.Ante,
-			hasBlock: options.AllowBlocks,
+			PlayerID:   player.GetPlayerID(),
+			lives:      options.Lives,
+			balance:    -1 * ante,
+			tableStake: player.GetTableStake(),
+			ante:       ante,
+			hasBlock:   options.AllowBlocks,
 		}
-		idToParticipants[id] = participants[i]
+		idToParticipants[playerIDs[i]] = participants[i]
 	}
 
 	gameLog := &GameLog{
@@ -115,6 +125,11 @@ func NewGame(logger logrus.FieldLogger, playerIDs []int64, options Options) (*Ga
 	gameLog.AddRound(g.startingHand())
 
 	g.sendLogMessage(0, fmt.Sprintf("New game of Pass the Poop: %s Edition started (ante: ${%d})", g.options.Edition.Name(), g.options.Ante))
+	for _, p := range participants {
+		if p.ante < options.Ante {
+			g.sendLogMessage(p.PlayerID, fmt.Sprintf("{} is all-in with a ${%d} ante", p.ante))
+		}
+	}
 
 	return g, nil
 }
@@ -334,25 +349,39 @@ func (g *Game) EndRound() error {
 }
 
 // endGame will calculate the end of game winner, make final balance adjustments
+// A winner who was all-in only wins up to their own ante from each player, the rest is returned
 // Note: this method assumes we already checked that we can end the game
 func (g *Game) endGame() error {
 	if g.balanceAdjustments != nil {
 		return errors.New("endGame() already called")
 	}
 
-	foundWinner := false
-	adjustments := make(map[int64]int)
-	for id, p := range g.idToParticipant {
+	var winner *Participant
+	for _, p := range g.idToParticipant {
 		if p.lives > 0 {
-			if foundWinner {
+			if winner != nil {
 				return errors.New("too many winners found")
 			}
 
-			foundWinner = true
-			p.balance += g.pot
-			g.gameLog.Winner = p.PlayerID
+			winner = p
 		}
+	}
 
+	if winner != nil {
+		g.gameLog.Winner = winner.PlayerID
+		for _, p := range g.participants {
+			won := p.ante
+			if won > winner.ante {
+				won = winner.ante
+			}
+
+			winner.balance += won
+			p.balance += p.ante - won
+		}
+	}
+
+	adjustments := make(map[int64]int)
+	for id, p := range g.idToParticipant {
 		adjustments[id] = p.balance
 	}
 
@@ -15,27 +15,27 @@ func TestGame_Name(t *testing.T) {
 
 	players := []int64{0, 1}
 
-	game, err = NewGame(logrus.StandardLogger(), players, Options{})
+	game, err = NewGame(logrus.StandardLogger(), setupParticipants(players...), Options{})
 	assert.EqualError(t, err, "ante must be greater than 0")
 	assert.Nil(t, game)
 
-	game, err = NewGame(logrus.StandardLogger(), players, Options{Ante: 25})
+	game, err = NewGame(logrus.StandardLogger(), setupParticipants(players...), Options{Ante: 25})
 	assert.EqualError(t, err, "lives must be greater than 0")
 	assert.Nil(t, game)
 
-	game, err = NewGame(logrus.StandardLogger(), players, DefaultOptions())
+	game, err = NewGame(logrus.StandardLogger(), setupParticipants(players...), DefaultOptions())
 	assert.NoError(t, err)
 	assert.Equal(t, "Pass the Poop, Standard Edition", game.Name())
 
 	opts := DefaultOptions()
 	opts.Edition = &PairsEdition{}
-	game, _ = NewGame(logrus.StandardLogger(), players, opts)
+	game, _ = NewGame(logrus.StandardLogger(), setupParticipants(players...), opts)
 	assert.Equal(t, "Pass the Poop, Pairs Edition", game.Name())
 }
 
 func Test_nextRound(t *testing.T) {
 	ids := []int64{1, 2, 3, 4, 5}
-	game, err := NewGame(logrus.StandardLogger(), ids, DefaultOptions())
+	game, err := NewGame(logrus.StandardLogger(), setupParticipants(ids...), DefaultOptions())
 	assert.NoError(t, err)
 	participants := game.participants
 
@@ -84,7 +84,7 @@ func Test_nextRound(t *testing.T) {
 
 func TestGame_ExecuteTurnForPlayer_AllTrades(t *testing.T) {
 	ids := []int64{1, 2, 3}
-	game, _ := NewGame(logrus.StandardLogger(), ids, DefaultOptions())
+	game, _ := NewGame(logrus.StandardLogger(), setupParticipants(ids...), DefaultOptions())
 	participants := game.participants
 	participants[0].card = card("2c")
 	participants[1].card = card("3c")
@@ -128,7 +128,7 @@ func TestGame_ExecuteTurnForPlayer_WithBlocks(t *testing.T) {
 	// first, make sure that blocks are only allowed in games with blocks
 	opts := DefaultOptions()
 	opts.AllowBlocks = false
-	game, _ := NewGame(logrus.StandardLogger(), []int64{1, 2, 3}, opts)
+	game, _ := NewGame(logrus.StandardLogger(), setupParticipants(1, 2, 3), opts)
 
 	game.idToParticipant[1].card = deck.CardFromString("2c")
 	game.idToParticipant[2].card = deck.CardFromString("3c")
@@ -140,7 +140,7 @@ func TestGame_ExecuteTurnForPlayer_WithBlocks(t *testing.T) {
 	// now test the actual blocks
 
 	opts.AllowBlocks = true
-	game, _ = NewGame(logrus.StandardLogger(), []int64{1, 2, 3, 4}, opts)
+	game, _ = NewGame(logrus.StandardLogger(), setupParticipants(1, 2, 3, 4), opts)
 
 	game.idToParticipant[1].card = deck.CardFromString("2c")
 	game.idToParticipant[2].card = deck.CardFromString("3c")
@@ -159,7 +159,7 @@ func TestGame_ExecuteTurnForPlayer_WithBlocks(t *testing.T) {
 
 func TestGame_ExecuteTurnForPlayer_KingedAndStays(t *testing.T) {
 	ids := []int64{1, 2, 3, 4}
-	game, _ := NewGame(logrus.StandardLogger(), ids, DefaultOptions())
+	game, _ := NewGame(logrus.StandardLogger(), setupParticipants(ids...), DefaultOptions())
 	participants := game.participants
 	participants[0].card = card("10c")
 	participants[1].card = card("2c")
@@ -198,7 +198,7 @@ func TestGame_ExecuteTurnForPlayer_KingedAndStays(t *testing.T) {
 }
 
 func TestGame_ExecuteTurnForPlayer_DealerDeck(t *testing.T) {
-	game, _ := NewGame(logrus.StandardLogger(), []int64{1, 2}, DefaultOptions())
+	game, _ := NewGame(logrus.StandardLogger(), setupParticipants(1, 2), DefaultOptions())
 	execOK, execError := createExecFunctions(t, game)
 
 	execError(1, ActionGoToDeck, "only the dealer may go to the deck")
@@ -227,7 +227,7 @@ func getPlayerIDsFromGame(g *Game) []int64 {
 
 func TestGame_flipAllCards(t *testing.T) {
 	ids := []int64{1, 2, 3, 4}
-	game, _ := NewGame(logrus.StandardLogger(), ids, DefaultOptions())
+	game, _ := NewGame(logrus.StandardLogger(), setupParticipants(ids...), DefaultOptions())
 	game.flipAllCards()
 
 	for i := 0; i < 4; i++ {
@@ -241,7 +241,7 @@ func TestGame_CompleteGame(t *testing.T) {
 
 	seed = 1
 
-	game, err := NewGame(logrus.StandardLogger(), []int64{1, 2, 3}, opts)
+	game, err := NewGame(logrus.StandardLogger(), setupParticipants(1, 2, 3), opts)
 	assert.NoError(t, err)
 	game.participants[0].card = card("2c")
 	game.participants[1].card = card("3c")
@@ -271,7 +271,7 @@ func TestGame_CompleteGame(t *testing.T) {
 }
 
 func TestGame_GetPlayerState(t *testing.T) {
-	game, _ := NewGame(logrus.StandardLogger(), []int64{1, 2, 3}, DefaultOptions())
+	game, _ := NewGame(logrus.StandardLogger(), setupParticipants(1, 2, 3), DefaultOptions())
 	p1 := game.participants[0]
 	p1.card = card("9s")
 	game.participants[1].lives = 0
@@ -303,7 +303,7 @@ func TestGame_GetPlayerState(t *testing.T) {
 }
 
 func TestGame_NextRoundAndEndRound(t *testing.T) {
-	game, _ := NewGame(logrus.StandardLogger(), []int64{1, 2, 3}, DefaultOptions())
+	game, _ := NewGame(logrus.StandardLogger(), setupParticipants(1, 2, 3), DefaultOptions())
 	assert.EqualError(t, game.nextRound(), "you must end the round first")
 
 	execOk, _ := createExecFunctions(t, game)
@@ -346,7 +346,7 @@ func createExecFunctions(t *testing.T, game *Game) (func(playerID int64, action
 }
 
 func TestGame_getActionsForParticipant(t *testing.T) {
-	game, _ := NewGame(logrus.StandardLogger(), []int64{1, 2}, Options{
+	game, _ := NewGame(logrus.StandardLogger(), setupParticipants(1, 2), Options{
 		Ante:    100,
 		Lives:   2,
 		Edition: &StandardEdition{},
@@ -443,7 +443,7 @@ func TestGame_getActionsForParticipant(t *testing.T) {
 }
 
 func TestGame_getActionsForParticipantWithBlocks(t *testing.T) {
-	game, _ := NewGame(logrus.StandardLogger(), []int64{1, 2}, Options{
+	game, _ := NewGame(logrus.StandardLogger(), setupParticipants(1, 2), Options{
 		Ante:        100,
 		Lives:       2,
 		Edition:     &StandardEdition{},
@@ -465,3 +465,41 @@ func TestGame_getActionsForParticipantWithBlocks(t *testing.T) {
 	actions = game.getActionsForParticipant(game.idToParticipant[2])
 	a.Equal([]GameAction{ActionAccept}, actions)
 }
+
+func TestGame_endGame_AllIn(t *testing.T) {
+	a := assert.New(t)
+	players := setupParticipants(1, 2, 3)
+	players[0].(*testParticipant).tableStake = 25
+
+	game, err := NewGame(logrus.StandardLogger(), players, DefaultOptions())
+	a.NoError(err)
+	a.Equal(175, game.pot)
+	a.Equal(-25, game.idToParticipant[1].balance)
+	a.Equal(-75, game.idToParticipant[2].balance)
+
+	// the all-in player wins, but only wins ${25} from each player
+	game.idToParticipant[2].lives = 0
+	game.idToParticipant[3].lives = 0
+	a.NoError(game.endGame())
+	a.Equal(map[int64]int{
+		1: 50,
+		2: -25,
+		3: -25,
+	}, game.balanceAdjustments)
+}
+
+func TestGame_endGame_AllInLoses(t *testing.T) {
+	a := assert.New(t)
+	players := setupParticipants(1, 2)
+	players[0].(*testParticipant).tableStake = 25
+
+	game, err := NewGame(logrus.StandardLogger(), players, DefaultOptions())
+	a.NoError(err)
+
+	game.idToParticipant[1].lives = 0
+	a.NoError(game.endGame())
+	a.Equal(map[int64]int{
+		1: -25,
+		2: 25,
+	}, game.balanceAdjustments)
+}
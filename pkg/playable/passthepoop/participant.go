@@ -16,6 +16,12 @@ type Participant struct {
 	// how much the player is up or down
 	balance int
 
+	// tableStake is the most the player can lose
+	tableStake int
+
+	// ante is how much the player paid into the pot, which is less than the ante if they are all-in
+	ante int
+
 	// the current card the player was dealt
 	card *deck.Card
 
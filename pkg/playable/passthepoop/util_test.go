@@ -3,6 +3,7 @@ package passthepoop
 import (
 	"fmt"
 	"mondaynightpoker-server/pkg/deck"
+	"mondaynightpoker-server/pkg/playable"
 	"regexp"
 	"strconv"
 	"strings"
@@ -11,6 +12,34 @@ import (
 	"github.com/stretchr/testify/assert"
 )
 
+// testTableStake covers the ante, so a winner is never limited to their own ante unless a test lowers their stake
+const testTableStake = 10000
+
+type testParticipant struct {
+	playerID   int64
+	tableStake int
+}
+
+func (t *testParticipant) GetPlayerID() int64 {
+	return t.playerID
+}
+
+func (t *testParticipant) GetTableStake() int {
+	return t.tableStake
+}
+
+func setupParticipants(playerIDs ...int64) []playable.Player {
+	p := make([]playable.Player, len(playerIDs))
+	for i, id := range playerIDs {
+		p[i] = &testParticipant{
+			playerID:   id,
+			tableStake: testTableStake,
+		}
+	}
+
+	return p
+}
+
 var cardRx = regexp.MustCompile(`^(?i)([2-9]|1[0-4])([cdhs])$`)
 
 func card(s string) *deck.Card {
@@ -17,6 +17,9 @@ const (
 	Call    Action = "call"
 	Bet     Action = "bet"
 	Raise   Action = "raise"
+
+	Straddle   Action = "straddle"
+	NoStraddle Action = "no-straddle"
 )
 
 var allowedActions = map[Action]bool{
@@ -27,6 +30,9 @@ var allowedActions = map[Action]bool{
 	Call:    true,
 	Bet:     true,
 	Raise:   true,
+
+	Straddle:   true,
+	NoStraddle: true,
 }
 
 // FromString returns an action for the given string
@@ -54,6 +60,10 @@ func (a Action) String() string {
 		return "Bet"
 	case Raise:
 		return "Raise"
+	case Straddle:
+		return "Straddle"
+	case NoStraddle:
+		return "No Straddle"
 	}
 
 	panic("unknown action")
@@ -70,6 +80,25 @@ func (a Action) MarshalJSON() ([]byte, error) {
 	})
 }
 
+// UnmarshalJSON decodes the action from the JSON produced by MarshalJSON
+func (a *Action) UnmarshalJSON(data []byte) error {
+	var v struct {
+		ID string `json:"id"`
+	}
+
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+
+	action, err := FromString(v.ID)
+	if err != nil {
+		return err
+	}
+
+	*a = action
+	return nil
+}
+
 // IsValid returns true if the action is permitted
 func (a Action) IsValid() bool {
 	_, ok := allowedActions[a]
@@ -91,6 +120,10 @@ func (a Action) LogMessage(amount int) string {
 		return fmt.Sprintf("bet ${%d}", amount)
 	case Raise:
 		return fmt.Sprintf("raised to ${%d}", amount)
+	case Straddle:
+		return fmt.Sprintf("straddled ${%d}", amount)
+	case NoStraddle:
+		return "did not straddle"
 	}
 
 	return ""
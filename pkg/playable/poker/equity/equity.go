@@ -0,0 +1,346 @@
+package equity
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"mondaynightpoker-server/internal/rng"
+	"mondaynightpoker-server/pkg/deck"
+	"mondaynightpoker-server/pkg/playable/poker/handanalyzer"
+	"time"
+)
+
+// MaxEnumerations is the most outcomes Calculate() will enumerate before it falls back to a Monte Carlo simulation
+const MaxEnumerations = 100000
+
+// DefaultIterations is the number of deals Calculate() simulates when there are too many outcomes to enumerate
+const DefaultIterations = 10000
+
+// ErrNoPlayers is an error when the scenario does not have any players
+var ErrNoPlayers = errors.New("at least one player is required")
+
+// Player is a player whose equity is calculated
+type Player struct {
+	// Cards are the cards known to be in the player's hand
+	Cards []*deck.Card
+
+	// Draw is the number of cards the player has yet to receive
+	Draw int
+}
+
+// Scenario describes the known cards and the cards still to come
+type Scenario struct {
+	Players []Player
+
+	// Community are the known community cards
+	Community []*deck.Card
+
+	// CommunityDraw is the number of community cards still to come
+	CommunityDraw int
+
+	// Dead are known cards that cannot be drawn, i.e., discards
+	Dead []*deck.Card
+
+	// Deck is the composition of the deck before any cards were dealt
+	// If nil, a standard 52-card deck is used
+	Deck []*deck.Card
+
+	// HandSize is the size of the hand passed to the hand analyzer, defaults to 5
+	HandSize int
+
+	// IsWild returns true if a card that has yet to be drawn would be wild
+	IsWild func(card *deck.Card) bool
+
+	// Strength returns the strength of the player's cards with the community cards
+	// If nil, handanalyzer.Evaluate() is used with all the cards
+	Strength func(cards, community []*deck.Card) int
+}
+
+// Result is the equity of an individual player
+// Each value is a fraction from 0 to 1
+type Result struct {
+	// Win is how often the player wins outright
+	Win float64 `json:"win"`
+
+	// Tie is how often the player ties for the best hand
+	Tie float64 `json:"tie"`
+
+	// Equity is the share of the pot the player can expect to win
+	Equity float64 `json:"equity"`
+}
+
+// slot is a group of cards drawn from the deck, either for the community or a player
+type slot struct {
+	known []*deck.Card
+	draw  int
+	drawn []*deck.Card
+}
+
+type calculator struct {
+	scenario Scenario
+	deck     []*deck.Card
+	used     []bool
+
+	// community is slots[0], every player is at slots[i+1]
+	slots []*slot
+	hand  []*deck.Card
+
+	strengths []int
+	wins      []float64
+	ties      []float64
+	equity    []float64
+	outcomes  int
+}
+
+// Calculate returns the equity of each player
+// The equity is exact if there are MaxEnumerations or fewer outcomes, otherwise it is estimated
+func Calculate(s Scenario) ([]Result, error) {
+	c, err := newCalculator(s)
+	if err != nil {
+		return nil, err
+	}
+
+	if c.countOutcomes(MaxEnumerations) <= MaxEnumerations {
+		c.enumerate(0, 0, c.slots[0].draw)
+	} else {
+		c.simulate(DefaultIterations, rand.New(rand.NewSource(time.Now().UnixNano()))) // nolint:gosec
+	}
+
+	return c.results(), nil
+}
+
+// Enumerate returns the exact equity of each player by evaluating every possible outcome
+func Enumerate(s Scenario) ([]Result, error) {
+	c, err := newCalculator(s)
+	if err != nil {
+		return nil, err
+	}
+
+	c.enumerate(0, 0, c.slots[0].draw)
+	return c.results(), nil
+}
+
+// MonteCarlo returns the estimated equity of each player by simulating random deals
+func MonteCarlo(s Scenario, iterations int, generator rng.Generator) ([]Result, error) {
+	if iterations <= 0 {
+		return nil, errors.New("iterations must be greater than zero")
+	}
+
+	c, err := newCalculator(s)
+	if err != nil {
+		return nil, err
+	}
+
+	c.simulate(iterations, generator)
+	return c.results(), nil
+}
+
+func newCalculator(s Scenario) (*calculator, error) {
+	if len(s.Players) == 0 {
+		return nil, ErrNoPlayers
+	}
+
+	if s.HandSize == 0 {
+		s.HandSize = 5
+	}
+
+	if s.Deck == nil {
+		s.Deck = deck.New().Cards
+	}
+
+	remaining := make([]*deck.Card, len(s.Deck))
+	copy(remaining, s.Deck)
+
+	known := append([]*deck.Card{}, s.Community...)
+	known = append(known, s.Dead...)
+	for _, p := range s.Players {
+		known = append(known, p.Cards...)
+	}
+
+	for _, card := range known {
+		index := -1
+		for i, c := range remaining {
+			if c.Equal(card) {
+				index = i
+				break
+			}
+		}
+
+		if index == -1 {
+			return nil, fmt.Errorf("%s is not in the deck", card.String())
+		}
+
+		remaining = append(remaining[:index], remaining[index+1:]...)
+	}
+
+	// wild cards are determined by the scenario, not the deck they came from
+	for i, card := range remaining {
+		card = card.Clone()
+		if s.IsWild != nil {
+			card.IsWild = s.IsWild(card)
+		}
+
+		remaining[i] = card
+	}
+
+	slots := make([]*slot, 0, len(s.Players)+1)
+	slots = append(slots, &slot{known: s.Community, draw: s.CommunityDraw})
+	toDraw := s.CommunityDraw
+	for _, p := range s.Players {
+		slots = append(slots, &slot{known: p.Cards, draw: p.Draw})
+		toDraw += p.Draw
+	}
+
+	if toDraw > len(remaining) {
+		return nil, fmt.Errorf("cannot draw %d cards from a deck with %d cards left", toDraw, len(remaining))
+	}
+
+	for _, sl := range slots {
+		if sl.draw < 0 {
+			return nil, errors.New("cannot draw a negative number of cards")
+		}
+
+		sl.drawn = make([]*deck.Card, 0, sl.draw)
+	}
+
+	n := len(s.Players)
+	return &calculator{
+		scenario:  s,
+		deck:      remaining,
+		used:      make([]bool, len(remaining)),
+		slots:     slots,
+		strengths: make([]int, n),
+		wins:      make([]float64, n),
+		ties:      make([]float64, n),
+		equity:    make([]float64, n),
+	}, nil
+}
+
+// countOutcomes returns the number of possible outcomes, or limit+1 if there are more than limit
+func (c *calculator) countOutcomes(limit int) int {
+	outcomes := 1
+	remaining := len(c.deck)
+	for _, sl := range c.slots {
+		for i := 0; i < sl.draw; i++ {
+			// C(n, k) = C(n, k-1) * (n-k+1) / k
+			outcomes = outcomes * (remaining - i) / (i + 1)
+			if outcomes > limit {
+				return limit + 1
+			}
+		}
+
+		remaining -= sl.draw
+	}
+
+	return outcomes
+}
+
+// enumerate draws every combination of need cards for the slot at index, starting with the card at start
+func (c *calculator) enumerate(index, start, need int) {
+	sl := c.slots[index]
+	if need == 0 {
+		if index+1 == len(c.slots) {
+			c.score()
+			return
+		}
+
+		c.enumerate(index+1, 0, c.slots[index+1].draw)
+		return
+	}
+
+	for i := start; i < len(c.deck); i++ {
+		if c.used[i] {
+			continue
+		}
+
+		c.used[i] = true
+		sl.drawn = append(sl.drawn, c.deck[i])
+		c.enumerate(index, i+1, need-1)
+		sl.drawn = sl.drawn[:len(sl.drawn)-1]
+		c.used[i] = false
+	}
+}
+
+// simulate deals random outcomes
+func (c *calculator) simulate(iterations int, generator rng.Generator) {
+	cards := make([]*deck.Card, len(c.deck))
+	for i := 0; i < iterations; i++ {
+		copy(cards, c.deck)
+
+		drawn := 0
+		for _, sl := range c.slots {
+			sl.drawn = sl.drawn[:0]
+			for j := 0; j < sl.draw; j++ {
+				// partial Fisher-Yates shuffle
+				swap := drawn + generator.Intn(len(cards)-drawn)
+				cards[drawn], cards[swap] = cards[swap], cards[drawn]
+				sl.drawn = append(sl.drawn, cards[drawn])
+				drawn++
+			}
+		}
+
+		c.score()
+	}
+}
+
+// score determines the winners of the current outcome
+func (c *calculator) score() {
+	community := c.slots[0]
+	c.hand = append(append(c.hand[:0], community.known...), community.drawn...)
+	nCommunity := len(c.hand)
+
+	best := 0
+	nBest := 0
+	for i, sl := range c.slots[1:] {
+		c.hand = append(append(c.hand[:nCommunity], sl.known...), sl.drawn...)
+
+		var strength int
+		if c.scenario.Strength != nil {
+			strength = c.scenario.Strength(c.hand[nCommunity:len(c.hand):len(c.hand)], c.hand[:nCommunity:nCommunity])
+		} else {
+			_, strength = handanalyzer.Evaluate(c.scenario.HandSize, c.hand)
+		}
+
+		c.strengths[i] = strength
+		if nBest == 0 || strength > best {
+			best = strength
+			nBest = 1
+		} else if strength == best {
+			nBest++
+		}
+	}
+
+	for i, strength := range c.strengths {
+		if strength != best {
+			continue
+		}
+
+		if nBest == 1 {
+			c.wins[i]++
+		} else {
+			c.ties[i]++
+		}
+
+		c.equity[i] += 1 / float64(nBest)
+	}
+
+	c.outcomes++
+}
+
+func (c *calculator) results() []Result {
+	results := make([]Result, len(c.strengths))
+	if c.outcomes == 0 {
+		return results
+	}
+
+	total := float64(c.outcomes)
+	for i := range results {
+		results[i] = Result{
+			Win:    c.wins[i] / total,
+			Tie:    c.ties[i] / total,
+			Equity: c.equity[i] / total,
+		}
+	}
+
+	return results
+}
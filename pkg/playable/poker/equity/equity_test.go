@@ -0,0 +1,191 @@
+package equity
+
+import (
+	"math/rand"
+	"mondaynightpoker-server/pkg/deck"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func holdem(community string, hands ...string) Scenario {
+	players := make([]Player, len(hands))
+	for i, hand := range hands {
+		players[i] = Player{Cards: deck.CardsFromString(hand)}
+	}
+
+	c := deck.CardsFromString(community)
+	return Scenario{
+		Players:       players,
+		Community:     c,
+		CommunityDraw: 5 - len(c),
+	}
+}
+
+func TestEnumerate(t *testing.T) {
+	a := assert.New(t)
+
+	// the ace needs one of the three remaining aces on the river
+	results, err := Enumerate(holdem("2c,7d,9h,13s", "14c,3d", "13c,4d"))
+	a.NoError(err)
+	a.Equal(2, len(results))
+	a.InDelta(3.0/44, results[0].Win, 0.0001)
+	a.Equal(0.0, results[0].Tie)
+	a.InDelta(41.0/44, results[1].Win, 0.0001)
+	a.InDelta(1, results[0].Equity+results[1].Equity, 0.0001)
+
+	// the board plays
+	results, err = Enumerate(holdem("14c,13c,12c,11c,10c", "2d,3d", "4d,5d"))
+	a.NoError(err)
+	a.Equal([]Result{{Tie: 1, Equity: 0.5}, {Tie: 1, Equity: 0.5}}, results)
+
+	// a three-way pot on the flop
+	results, err = Enumerate(holdem("2c,7d,9h", "14c,14d", "13c,13d", "9c,9d"))
+	a.NoError(err)
+	total := 0.0
+	for _, r := range results {
+		total += r.Equity
+	}
+
+	a.InDelta(1, total, 0.0001)
+	a.Greater(results[2].Win, results[0].Win)
+}
+
+func TestEnumerate_dead(t *testing.T) {
+	a := assert.New(t)
+
+	s := holdem("2c,7d,9h,13s", "14c,3d", "13c,4d")
+	s.Dead = deck.CardsFromString("14d,14h")
+	results, err := Enumerate(s)
+	a.NoError(err)
+	a.InDelta(1.0/42, results[0].Win, 0.0001)
+}
+
+func TestEnumerate_deck(t *testing.T) {
+	a := assert.New(t)
+
+	// only aces and kings are left
+	s := holdem("2c,7d,9h,13s", "14c,3d", "13c,4d")
+	s.Deck = deck.CardsFromString("2c,7d,9h,13s,14c,3d,13c,4d,14d,14h,13d")
+	results, err := Enumerate(s)
+	a.NoError(err)
+	a.InDelta(2.0/3, results[0].Win, 0.0001)
+	a.InDelta(1.0/3, results[1].Win, 0.0001)
+}
+
+func TestEnumerate_wilds(t *testing.T) {
+	a := assert.New(t)
+
+	// seven-card stud with one card to come where 9s are wild
+	s := Scenario{
+		Players: []Player{
+			{Cards: deck.CardsFromString("14c,14d,2c,3d,5h,7s"), Draw: 1},
+			{Cards: deck.CardsFromString("13c,13d,13h,4d,6h,8s"), Draw: 1},
+		},
+		Deck: deck.CardsFromString("14c,14d,2c,3d,5h,7s,13c,13d,13h,4d,6h,8s,9c,9d,10c,11d"),
+		IsWild: func(card *deck.Card) bool {
+			return card.Rank == 9
+		},
+	}
+
+	// player 1 needs a wild for a straight, and player 2 must not catch the other wild
+	results, err := Enumerate(s)
+	a.NoError(err)
+	a.InDelta(4.0/12, results[0].Win, 0.0001)
+	a.InDelta(8.0/12, results[1].Win, 0.0001)
+}
+
+func TestEnumerate_strength(t *testing.T) {
+	a := assert.New(t)
+
+	// lowest card wins
+	s := holdem("", "2c", "3c")
+	s.CommunityDraw = 0
+	s.Strength = func(cards, community []*deck.Card) int {
+		return -cards[0].Rank
+	}
+
+	results, err := Enumerate(s)
+	a.NoError(err)
+	a.Equal([]Result{{Win: 1, Equity: 1}, {}}, results)
+}
+
+func TestEnumerate_errors(t *testing.T) {
+	a := assert.New(t)
+
+	_, err := Enumerate(Scenario{})
+	a.Equal(ErrNoPlayers, err)
+
+	_, err = Enumerate(holdem("2c,3c,4c", "2c,5d"))
+	a.EqualError(err, "2♣ is not in the deck")
+
+	s := holdem("", "2c,3c")
+	s.Deck = deck.CardsFromString("2c,3c,4c")
+	_, err = Enumerate(s)
+	a.EqualError(err, "cannot draw 5 cards from a deck with 1 cards left")
+
+	s = holdem("", "2c,3c")
+	s.Players[0].Draw = -1
+	_, err = Enumerate(s)
+	a.EqualError(err, "cannot draw a negative number of cards")
+
+	_, err = MonteCarlo(holdem("", "2c,3c"), 0, rand.New(rand.NewSource(1)))
+	a.EqualError(err, "iterations must be greater than zero")
+}
+
+func TestMonteCarlo(t *testing.T) {
+	a := assert.New(t)
+
+	// aces are about an 80% favorite over kings
+	results, err := MonteCarlo(holdem("", "14c,14d", "13h,13s"), 20000, rand.New(rand.NewSource(1)))
+	a.NoError(err)
+	a.InDelta(0.82, results[0].Equity, 0.02)
+	a.InDelta(0.18, results[1].Equity, 0.02)
+}
+
+func TestCalculate(t *testing.T) {
+	a := assert.New(t)
+
+	// small enough to enumerate
+	s := holdem("2c,7d,9h,13s", "14c,3d", "13c,4d")
+	results, err := Calculate(s)
+	a.NoError(err)
+	exact, _ := Enumerate(s)
+	a.Equal(exact, results)
+
+	// too many outcomes, so it is simulated
+	s = holdem("", "14c,14d", "13h,13s")
+	c, _ := newCalculator(s)
+	a.Equal(MaxEnumerations+1, c.countOutcomes(MaxEnumerations))
+
+	results, err = Calculate(s)
+	a.NoError(err)
+	a.InDelta(0.82, results[0].Equity, 0.03)
+
+	_, err = Calculate(Scenario{})
+	a.Equal(ErrNoPlayers, err)
+}
+
+func Test_countOutcomes(t *testing.T) {
+	a := assert.New(t)
+
+	c, _ := newCalculator(holdem("2c,7d,9h", "14c,3d", "13c,4d"))
+	a.Equal(990, c.countOutcomes(MaxEnumerations))
+
+	c, _ = newCalculator(Scenario{Players: []Player{{Draw: 2}, {Draw: 2}}})
+	a.Equal(1326*1225, c.countOutcomes(10000000))
+}
+
+func BenchmarkCalculate_flop(b *testing.B) {
+	s := holdem("2c,7d,9h", "14c,3d", "13c,4d")
+	for i := 0; i < b.N; i++ {
+		_, _ = Calculate(s)
+	}
+}
+
+func BenchmarkCalculate_preFlop(b *testing.B) {
+	s := holdem("", "14c,14d", "13h,13s")
+	for i := 0; i < b.N; i++ {
+		_, _ = Calculate(s)
+	}
+}
@@ -0,0 +1,550 @@
+package fivecarddraw
+
+import (
+	"errors"
+	"fmt"
+	"github.com/sirupsen/logrus"
+	"mondaynightpoker-server/pkg/deck"
+	"mondaynightpoker-server/pkg/playable"
+	"mondaynightpoker-server/pkg/playable/poker/action"
+	"mondaynightpoker-server/pkg/playable/poker/handanalyzer"
+	"mondaynightpoker-server/pkg/playable/poker/potmanager"
+	"sort"
+	"strings"
+	"time"
+)
+
+const maxParticipants = 7
+
+// maxDraw is the most cards a participant can draw, unless they keep an ace
+const maxDraw = 3
+
+// disqualifiedOffset is subtracted from the strength of any hand that does not qualify to win
+// This keeps the hands in order in case nobody qualifies and the pot must still be awarded
+const disqualifiedOffset = 1 << 30
+
+type round int
+
+const (
+	roundFirstBettingRound round = iota
+	roundDraw
+	roundSecondBettingRound
+	roundRevealWinner
+)
+
+// seed of -1 means truly crypto-random shuffle
+// setting to a global so we can override in a test
+var seed int64 = -1
+
+// Game represents an individual game of Five-Card Draw
+type Game struct {
+	playerIDs       []int64
+	idToParticipant map[int64]*Participant
+	options         Options
+	logger          logrus.FieldLogger
+	logChan         chan []*playable.LogMessage
+	deck            *deck.Deck
+	potManager      *potmanager.PotManager
+	round           round
+	discards        []*deck.Card
+
+	// opener is the participant who made the first bet of the game
+	opener int64
+	// deals is the number of times the cards were dealt
+	deals int
+
+	done    bool
+	winners map[*Participant]int
+
+	endGameAt time.Time
+}
+
+// NewGame returns a new instance of the game
+func NewGame(logger logrus.FieldLogger, players []playable.Player, options Options) (*Game, error) {
+	if options.Ante <= 0 {
+		return nil, errors.New("ante must be greater than zero")
+	}
+
+	if len(players) < 2 {
+		return nil, errors.New("you must have at least two participants")
+	}
+
+	if len(players) > maxParticipants {
+		return nil, fmt.Errorf("you cannot have more than %d participants", maxParticipants)
+	}
+
+	pm := potmanager.New(options.Ante)
+
+	idToParticipant := make(map[int64]*Participant)
+	playerIDs := make([]int64, len(players))
+	for i, player := range players {
+		playerIDs[i] = player.GetPlayerID()
+		participant := newParticipant(player.GetPlayerID(), player.GetTableStake())
+		idToParticipant[player.GetPlayerID()] = participant
+
+		if err := pm.SeatParticipant(participant); err != nil {
+			return nil, err
+		}
+	}
+	pm.FinishSeatingParticipants()
+
+	g := &Game{
+		options:         options,
+		playerIDs:       playerIDs,
+		idToParticipant: idToParticipant,
+		deck:            newShuffledDeck(),
+		potManager:      pm,
+		discards:        []*deck.Card{},
+		logChan:         make(chan []*playable.LogMessage, 256),
+		logger:          logger,
+	}
+
+	g.logChan <- playable.SimpleLogMessageSlice(0, "New game of %s started (ante: ${%d})", g.Name(), g.options.Ante)
+
+	return g, nil
+}
+
+func newShuffledDeck() *deck.Deck {
+	d := deck.New()
+	d.SetSeed(seed)
+	d.Shuffle()
+	return d
+}
+
+// DealCards will deal five cards to each participant
+func (g *Game) DealCards() error {
+	for i := 0; i < 5; i++ {
+		for _, id := range g.playerIDs {
+			p := g.idToParticipant[id]
+			if p.didFold {
+				continue
+			}
+
+			card, err := g.deck.Draw()
+			if err != nil {
+				return err
+			}
+
+			p.hand.AddCard(card)
+		}
+	}
+
+	for _, p := range g.idToParticipant {
+		sort.Sort(p.hand)
+	}
+
+	g.deals++
+	return nil
+}
+
+// GetCurrentTurn returns the current participant who needs to make a decision
+func (g *Game) GetCurrentTurn() *Participant {
+	p, err := g.potManager.GetInTurnParticipant()
+	if err != nil {
+		return nil
+	}
+
+	return g.idToParticipant[p.ID()]
+}
+
+// IsRoundOver returns true if all participants have had a turn
+func (g *Game) IsRoundOver() bool {
+	return g.potManager.IsRoundOver()
+}
+
+// IsGameOver returns true if the game is over
+func (g *Game) IsGameOver() bool {
+	return g.winners != nil
+}
+
+// CanRevealCards returns true if the hands can be shown to everybody
+func (g *Game) CanRevealCards() bool {
+	return g.round >= roundRevealWinner
+}
+
+// NextRound will advance the game to the next round
+func (g *Game) NextRound() error {
+	if !g.IsRoundOver() {
+		return errors.New("round is not over")
+	}
+
+	if g.round == roundRevealWinner {
+		return errors.New("cannot advance the round")
+	}
+
+	if g.round == roundFirstBettingRound && g.options.JacksOrBetter && g.opener == 0 && g.canRedeal() {
+		return g.redeal("nobody could open")
+	}
+
+	g.round++
+
+	if err := g.reset(); err != nil {
+		return err
+	}
+
+	switch g.round {
+	case roundDraw:
+		// decision round allows all-in participants to draw
+		g.potManager.StartDecisionRound()
+	case roundRevealWinner:
+		return g.endGame()
+	}
+
+	return nil
+}
+
+// ParticipantBets handles both bets and raises
+func (g *Game) ParticipantBets(p *Participant, bet int) error {
+	term := strings.ToLower(string(action.Bet))
+
+	currentBet := g.potManager.GetBet()
+	if currentBet > 0 {
+		term = strings.ToLower(string(action.Raise))
+	}
+
+	if g.mustHaveOpeners() && !p.hasJacksOrBetter() {
+		return errors.New("you need a pair of jacks or better to open")
+	}
+
+	if maxBet := g.potManager.GetPotLimitMaxBet(); bet > maxBet {
+		return fmt.Errorf("your %s (${%d}) must not exceed the pot limit (${%d})", term, bet, maxBet)
+	}
+
+	allInAmount := g.potManager.GetParticipantAllInAmount(p)
+
+	// only check the following logic IF the participant is not going all-in
+	if bet != allInAmount {
+		if bet%25 > 0 {
+			return fmt.Errorf("your %s must be in multiples of ${25}", term)
+		}
+
+		if bet < g.options.Ante {
+			return fmt.Errorf("your %s must at least match the ante (${%d})", term, g.options.Ante)
+		}
+
+		minRaiseTo := currentBet + g.potManager.GetRaise()
+		if currentBet > 0 && bet < minRaiseTo {
+			return fmt.Errorf("your raise of ${%d} must be at least equal to double the previous raise of ${%d}", bet-currentBet, g.potManager.GetRaise())
+		}
+	}
+
+	if err := g.potManager.ParticipantBetsOrRaises(p, bet); err != nil {
+		return err
+	}
+
+	if g.opener == 0 {
+		g.opener = p.PlayerID
+	}
+
+	return nil
+}
+
+// ParticipantChecks will check for the participant as long as there's no active bet
+func (g *Game) ParticipantChecks(p *Participant) error {
+	return g.potManager.ParticipantChecks(p)
+}
+
+// ParticipantCalls handles when the player calls the action
+func (g *Game) ParticipantCalls(p *Participant) error {
+	return g.potManager.ParticipantCalls(p)
+}
+
+// ParticipantFolds handles when a player folds their hand
+func (g *Game) ParticipantFolds(p *Participant) error {
+	if err := g.potManager.ParticipantFolds(p); err != nil {
+		return err
+	}
+
+	p.didFold = true
+
+	stillAlive := 0
+	for _, p := range g.idToParticipant {
+		if !p.didFold {
+			stillAlive++
+		}
+	}
+
+	if stillAlive == 0 {
+		panic("too many players folded")
+	} else if stillAlive == 1 {
+		return g.endGame()
+	}
+
+	return nil
+}
+
+// mustHaveOpeners returns true if the next bet opens the game under jacks-or-better rules
+func (g *Game) mustHaveOpeners() bool {
+	return g.options.JacksOrBetter && g.round == roundFirstBettingRound && g.opener == 0
+}
+
+// reset should be called when we enter a new round
+func (g *Game) reset() error {
+	if err := g.potManager.NextRound(); err != nil {
+		return err
+	}
+
+	for _, p := range g.idToParticipant {
+		p.reset()
+	}
+
+	return nil
+}
+
+// canRedeal returns true if at least two participants can afford to ante again
+func (g *Game) canRedeal() bool {
+	count := 0
+	for _, p := range g.idToParticipant {
+		if p.Balance() > 0 {
+			count++
+		}
+	}
+
+	return count >= 2
+}
+
+// redeal collects a new ante, carries the pot over, and deals a fresh hand to each participant
+func (g *Game) redeal(reason string) error {
+	carryOver := g.potManager.GetTotalOnTable()
+
+	pm := potmanager.New(g.options.Ante)
+	for _, id := range g.playerIDs {
+		p := g.idToParticipant[id]
+		p.newDeal()
+
+		if p.Balance() <= 0 {
+			p.didFold = true
+			continue
+		}
+
+		if err := pm.SeatParticipant(p); err != nil {
+			return err
+		}
+	}
+	pm.FinishSeatingParticipants()
+	pm.AddDeadMoney(carryOver)
+
+	g.potManager = pm
+	g.deck = newShuffledDeck()
+	g.discards = []*deck.Card{}
+	g.opener = 0
+	g.round = roundFirstBettingRound
+
+	g.logChan <- playable.SimpleLogMessageSlice(0, "%s, so the cards were redealt (${%d} carried over and everyone paid the ante of ${%d})", reason, carryOver, g.options.Ante)
+
+	return g.DealCards()
+}
+
+func (g *Game) drawCardsForParticipant(p *Participant, cards []*deck.Card) error {
+	if g.round != roundDraw {
+		return errors.New("we are not in the draw round")
+	}
+
+	if g.GetCurrentTurn() != p {
+		return potmanager.ErrParticipantCannotAct
+	}
+
+	uniq := make(map[string]bool)
+	for _, card := range cards {
+		if !p.hand.HasCard(card) {
+			return fmt.Errorf("you do not have %s in your hand", card.String())
+		}
+
+		uniq[card.String()] = true
+	}
+
+	if len(uniq) != len(cards) {
+		return errors.New("invalid draw")
+	}
+
+	if len(cards) > maxDraw {
+		kept := p.hand.Clone()
+		for _, card := range cards {
+			kept.Discard(card)
+		}
+
+		if len(cards) > maxDraw+1 || kept.FirstCard().Rank != deck.Ace {
+			return fmt.Errorf("you may only draw up to %d cards, or %d if you keep an ace", maxDraw, maxDraw+1)
+		}
+	}
+
+	discards := make([]*deck.Card, 0, len(cards))
+	for _, card := range cards {
+		p.hand.Discard(card)
+		discards = append(discards, card)
+
+		if !g.deck.CanDraw(1) {
+			g.deck.ShuffleDiscards(g.discards)
+			g.discards = []*deck.Card{}
+		}
+
+		card, err := g.deck.Draw()
+		if err != nil {
+			return err
+		}
+
+		p.hand.AddCard(card)
+	}
+	g.discards = append(g.discards, discards...)
+
+	sort.Sort(p.hand)
+
+	p.drew = len(cards)
+	return g.potManager.AdvanceDecision()
+}
+
+func (g *Game) getFutureActionsForPlayer(playerID int64) []action.Action {
+	if p, ok := g.idToParticipant[playerID]; !ok {
+		return nil
+	} else if !g.potManager.IsParticipantYetToAct(p) {
+		return nil
+	}
+
+	if g.round == roundDraw {
+		return []action.Action{action.Trade}
+	}
+
+	if g.potManager.GetBet() == 0 {
+		return []action.Action{action.Check, action.Fold}
+	}
+
+	return []action.Action{action.Call, action.Fold}
+}
+
+func (g *Game) getActionsForPlayer(playerID int64) []action.Action {
+	p, ok := g.idToParticipant[playerID]
+	if !ok {
+		// viewer
+		return nil
+	}
+
+	actions := make([]action.Action, 0)
+	if p == g.GetCurrentTurn() {
+		if g.round == roundDraw {
+			actions = append(actions, action.Trade)
+		} else {
+			if bet := g.potManager.GetBet(); bet == 0 {
+				actions = append(actions, action.Check)
+				if !g.mustHaveOpeners() || p.hasJacksOrBetter() {
+					actions = append(actions, action.Bet)
+				}
+				actions = append(actions, action.Fold)
+			} else if g.potManager.GetParticipantAllInAmount(p) < bet {
+				actions = append(actions, action.Call, action.Fold)
+			} else {
+				actions = append(actions, action.Call, action.Raise, action.Fold)
+			}
+		}
+	}
+
+	return actions
+}
+
+// qualifies returns true if the hand is eligible to win the pot
+func (g *Game) qualifies(p *Participant) bool {
+	if !g.options.TripsToWin {
+		return true
+	}
+
+	return p.getHandAnalyzer().GetHand() >= handanalyzer.ThreeOfAKind
+}
+
+// endGame will handle any end of game actions, calculate winners, etc.
+func (g *Game) endGame() error {
+	if g.winners != nil {
+		panic("endGame already called")
+	}
+
+	alive := make([]*Participant, 0, len(g.playerIDs))
+	anyQualified := false
+	for _, id := range g.playerIDs {
+		p := g.idToParticipant[id]
+		if p.didFold {
+			continue
+		}
+
+		alive = append(alive, p)
+		if g.qualifies(p) {
+			anyQualified = true
+		}
+	}
+
+	// the qualifier only applies to a showdown. An uncontested pot is always won
+	if len(alive) > 1 && !anyQualified && g.canRedeal() {
+		return g.redeal("nobody had three-of-a-kind or better")
+	}
+
+	g.potManager.EndGame()
+
+	wm := potmanager.NewWinManager()
+	for _, p := range alive {
+		strength := p.getHandAnalyzer().GetStrength()
+		if len(alive) > 1 && !g.qualifies(p) {
+			strength -= disqualifiedOffset
+		}
+
+		wm.AddParticipant(p, strength)
+	}
+
+	winners := make(map[*Participant]int)
+	payouts, err := g.potManager.PayWinners(wm.GetSortedTiers())
+	if err != nil {
+		return err
+	}
+
+	for participant, amount := range payouts {
+		p := g.idToParticipant[participant.ID()]
+		winners[p] = amount
+	}
+	g.winners = winners
+
+	g.round = roundRevealWinner
+	g.sendEndOfGameLogMessages()
+
+	return nil
+}
+
+func (g *Game) sendEndOfGameLogMessages() {
+	lms := make([]*playable.LogMessage, 0, len(g.idToParticipant))
+	for _, playerID := range g.playerIDs {
+		p := g.idToParticipant[playerID]
+		if amount, ok := g.winners[p]; ok {
+			hand := p.getHandAnalyzer().GetHand().String()
+			lms = append(lms, playable.SimpleLogMessage(p.PlayerID, "{} had a %s and won ${%d} (${%d})", hand, amount, p.balance))
+		}
+	}
+
+	for _, playerID := range g.playerIDs {
+		p := g.idToParticipant[playerID]
+		if _, ok := g.winners[p]; ok {
+			continue
+		}
+
+		if p.didFold {
+			lms = append(lms, playable.SimpleLogMessage(p.PlayerID, "{} folded and lost ${%d}", -1*p.balance))
+		} else {
+			hand := p.getHandAnalyzer().GetHand().String()
+			lms = append(lms, playable.SimpleLogMessage(p.PlayerID, "{} had a %s and lost ${%d}", hand, -1*p.balance))
+		}
+	}
+
+	g.logChan <- lms
+}
+
+// NameFromOptions returns the name for the given options
+func NameFromOptions(options Options) string {
+	extras := make([]string, 0, 2)
+	if options.JacksOrBetter {
+		extras = append(extras, "Jacks or Better")
+	}
+
+	if options.TripsToWin {
+		extras = append(extras, "Trips to Win")
+	}
+
+	if len(extras) == 0 {
+		return "Five-Card Draw"
+	}
+
+	return fmt.Sprintf("Five-Card Draw (%s)", strings.Join(extras, ", "))
+}
@@ -0,0 +1,70 @@
+package fivecarddraw
+
+import (
+	"github.com/stretchr/testify/assert"
+	"mondaynightpoker-server/pkg/deck"
+	"mondaynightpoker-server/pkg/playable"
+	"mondaynightpoker-server/pkg/playable/poker/action"
+	"testing"
+)
+
+func TestGame_GetPlayerState(t *testing.T) {
+	a := assert.New(t)
+	game := mustNewGame(DefaultOptions(), 1000, 1000)
+	a.NoError(game.DealCards())
+	setHands(game, "14s,14h,2c,5d,9h", "13s,13h,13d,4c,7c")
+
+	resp, err := game.GetPlayerState(1)
+	a.NoError(err)
+	a.Equal("five-card-draw", resp.Value)
+
+	state := resp.Data.(*State)
+	a.Equal("14s,14h,2c,5d,9h", deck.CardsToString(state.Participant.Hand))
+	a.Equal("Pair", state.Participant.HandRank)
+	a.Equal([]action.Action{action.Check, action.Bet, action.Fold}, state.Actions)
+	a.Nil(state.GameState.Participants[1].Hand, "cannot see other hands")
+	a.Equal(int64(2), state.GameState.DealerID)
+
+	resp, err = game.GetPlayerState(3)
+	a.NoError(err)
+	state = resp.Data.(*State)
+	a.Nil(state.Participant)
+	a.Nil(state.Actions)
+}
+
+func TestGame_Action(t *testing.T) {
+	a := assert.New(t)
+	game := mustNewGame(DefaultOptions(), 1000, 1000)
+	a.NoError(game.DealCards())
+
+	_, _, err := game.Action(3, &playable.PayloadIn{Action: "check"})
+	a.EqualError(err, "participant is not in the game")
+
+	_, _, err = game.Action(1, &playable.PayloadIn{Action: "bet"})
+	a.EqualError(err, "amount must be > 0")
+
+	resp, update, err := game.Action(1, &playable.PayloadIn{Action: "bet", AdditionalData: playable.AdditionalData{"amount": float64(50)}})
+	a.NoError(err)
+	a.True(update)
+	a.Equal(playable.OK(), resp)
+
+	_, _, err = game.Action(2, &playable.PayloadIn{Action: "call"})
+	a.NoError(err)
+
+	update, err = game.Tick()
+	a.NoError(err)
+	a.True(update)
+	a.Equal(roundDraw, game.round)
+
+	card := game.idToParticipant[1].hand[0]
+	_, _, err = game.Action(1, &playable.PayloadIn{Action: "trade", Cards: []*deck.Card{card}})
+	a.NoError(err)
+	a.False(game.idToParticipant[1].hand.HasCard(card))
+
+	_, _, err = game.Action(2, &playable.PayloadIn{Action: "trade"})
+	a.NoError(err)
+	a.Equal(0, game.idToParticipant[2].drew)
+
+	_, _, err = game.Action(1, &playable.PayloadIn{Action: "unknown"})
+	a.EqualError(err, "unknown action: unknown")
+}
@@ -0,0 +1,311 @@
+package fivecarddraw
+
+import (
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"mondaynightpoker-server/pkg/deck"
+	"mondaynightpoker-server/pkg/playable"
+	"mondaynightpoker-server/pkg/playable/poker/potmanager"
+	"testing"
+)
+
+type testParticipant struct {
+	playerID   int64
+	tableStake int
+}
+
+func (t *testParticipant) GetPlayerID() int64 {
+	return t.playerID
+}
+
+func (t *testParticipant) GetTableStake() int {
+	return t.tableStake
+}
+
+func newParticipants(tableStakes ...int) []playable.Player {
+	p := make([]playable.Player, len(tableStakes))
+	for i, tableStake := range tableStakes {
+		p[i] = &testParticipant{
+			playerID:   int64(i + 1),
+			tableStake: tableStake,
+		}
+	}
+
+	return p
+}
+
+func mustNewGame(options Options, tableStakes ...int) *Game {
+	game, err := NewGame(logrus.StandardLogger(), newParticipants(tableStakes...), options)
+	if err != nil {
+		panic(err)
+	}
+
+	return game
+}
+
+// setHands replaces the dealt hands with the supplied hands
+func setHands(game *Game, hands ...string) {
+	for i, hand := range hands {
+		game.idToParticipant[int64(i+1)].hand = deck.CardsFromString(hand)
+	}
+}
+
+func TestNameFromOptions(t *testing.T) {
+	a := assert.New(t)
+	a.Equal("Five-Card Draw", NameFromOptions(DefaultOptions()))
+	a.Equal("Five-Card Draw (Jacks or Better)", NameFromOptions(Options{Ante: 25, JacksOrBetter: true}))
+	a.Equal("Five-Card Draw (Trips to Win)", NameFromOptions(Options{Ante: 25, TripsToWin: true}))
+	a.Equal("Five-Card Draw (Jacks or Better, Trips to Win)", NameFromOptions(Options{Ante: 25, JacksOrBetter: true, TripsToWin: true}))
+}
+
+func TestNewGame(t *testing.T) {
+	a := assert.New(t)
+	participants := newParticipants(50, 50, 50)
+
+	game, err := NewGame(logrus.StandardLogger(), participants, Options{})
+	a.EqualError(err, "ante must be greater than zero")
+	a.Nil(game)
+
+	game, err = NewGame(logrus.StandardLogger(), newParticipants(50), DefaultOptions())
+	a.EqualError(err, "you must have at least two participants")
+	a.Nil(game)
+
+	game, err = NewGame(logrus.StandardLogger(), make([]playable.Player, maxParticipants+1), DefaultOptions())
+	a.EqualError(err, "you cannot have more than 7 participants")
+	a.Nil(game)
+
+	game, err = NewGame(logrus.StandardLogger(), participants, DefaultOptions())
+	a.NoError(err)
+	a.NotNil(game)
+	a.NotEqual(deck.New().HashCode(), game.deck.HashCode())
+	a.Equal(75, game.potManager.Pots().Total())
+	a.Equal(-25, game.idToParticipant[1].balance)
+}
+
+func TestGame_DealCards(t *testing.T) {
+	a := assert.New(t)
+	game := mustNewGame(DefaultOptions(), 100, 100)
+	game.deck = deck.New()
+
+	a.NoError(game.DealCards())
+	a.Equal("2c,4c,6c,8c,10c", deck.CardsToString(game.idToParticipant[1].hand))
+	a.Equal("3c,5c,7c,9c,11c", deck.CardsToString(game.idToParticipant[2].hand))
+	a.Equal(1, game.deals)
+}
+
+func TestGame_drawCardsForParticipant(t *testing.T) {
+	a := assert.New(t)
+	game := mustNewGame(DefaultOptions(), 100, 100, 100)
+	game.deck = deck.New()
+	a.NoError(game.DealCards())
+	setHands(game, "2c,3c,4c,5c,14d", "2d,3d,4d,5d,6s", "2h,3h,4h,5h,6h")
+
+	draw := func(id int64, cards string) error {
+		return game.drawCardsForParticipant(game.idToParticipant[id], deck.CardsFromString(cards))
+	}
+
+	a.EqualError(draw(1, "2c"), "we are not in the draw round")
+
+	a.NoError(game.ParticipantChecks(game.idToParticipant[1]))
+	a.NoError(game.ParticipantChecks(game.idToParticipant[2]))
+	a.NoError(game.ParticipantChecks(game.idToParticipant[3]))
+	a.NoError(game.NextRound())
+	a.Equal(roundDraw, game.round)
+
+	a.EqualError(draw(2, "2d"), "it is not your turn")
+	a.EqualError(draw(1, "2d"), "you do not have 2♢ in your hand")
+	a.EqualError(draw(1, "2c,2c"), "invalid draw")
+	a.EqualError(draw(1, "2c,3c,4c,5c,14d"), "you may only draw up to 3 cards, or 4 if you keep an ace")
+	a.NoError(draw(1, "2c,3c,4c,5c"))
+	a.Equal(4, game.idToParticipant[1].drew)
+	a.Equal(5, len(game.idToParticipant[1].hand))
+	a.True(game.idToParticipant[1].hand.HasCard(deck.CardFromString("14d")))
+
+	a.EqualError(draw(2, "2d,3d,4d,5d"), "you may only draw up to 3 cards, or 4 if you keep an ace")
+	a.NoError(draw(2, "2d,3d,4d"))
+	a.Equal(3, game.idToParticipant[2].drew)
+
+	a.NoError(draw(3, ""))
+	a.Equal(0, game.idToParticipant[3].drew)
+	a.Equal("2h,3h,4h,5h,6h", deck.CardsToString(game.idToParticipant[3].hand))
+	a.True(game.IsRoundOver())
+	a.Equal(7, len(game.discards))
+}
+
+func TestGame_drawCardsForParticipant_usingDiscards(t *testing.T) {
+	a := assert.New(t)
+	game := mustNewGame(DefaultOptions(), 100, 100)
+	game.deck = deck.New()
+	a.NoError(game.DealCards())
+	a.NoError(game.ParticipantChecks(game.idToParticipant[1]))
+	a.NoError(game.ParticipantChecks(game.idToParticipant[2]))
+	a.NoError(game.NextRound())
+
+	game.deck.Cards = deck.CardsFromString("12s,13s")
+	game.deck.SetSeed(1)
+
+	a.NoError(game.drawCardsForParticipant(game.idToParticipant[1], deck.CardsFromString("2c,4c")))
+	a.Equal("6c,8c,10c,12s,13s", deck.CardsToString(game.idToParticipant[1].hand))
+
+	a.NoError(game.drawCardsForParticipant(game.idToParticipant[2], deck.CardsFromString("3c")))
+	a.Equal("3c", deck.CardsToString(game.discards))
+	a.Equal(1, game.deck.CardsLeft())
+	a.Equal(5, len(game.idToParticipant[2].hand))
+}
+
+func TestGame_fullGame(t *testing.T) {
+	a := assert.New(t)
+	game := mustNewGame(DefaultOptions(), 1000, 1000, 1000)
+	a.NoError(game.DealCards())
+	setHands(game, "14s,14h,2c,5d,9h", "13s,13h,13d,4c,7c", "2s,3s,4s,5s,11d")
+	game.deck.Cards = deck.CardsFromString("6s,14d,8c,10c")
+
+	p := func(id int64) *Participant {
+		return game.idToParticipant[id]
+	}
+
+	// first betting round
+	a.Equal(potmanager.ErrParticipantCannotAct, game.ParticipantChecks(p(2)))
+	a.NoError(game.ParticipantBets(p(1), 50))
+	a.NoError(game.ParticipantCalls(p(2)))
+	a.NoError(game.ParticipantCalls(p(3)))
+	a.NoError(game.NextRound())
+	a.Equal(int64(1), game.opener)
+	a.Equal(225, game.potManager.Pots().Total())
+
+	// draw
+	a.EqualError(game.ParticipantChecks(p(1)), potmanager.ErrInDecisionRound.Error())
+	a.NoError(game.drawCardsForParticipant(p(1), deck.CardsFromString("2c,5d,9h")))
+	a.NoError(game.drawCardsForParticipant(p(2), deck.CardsFromString("7c")))
+	a.NoError(game.drawCardsForParticipant(p(3), deck.CardsFromString("11d")))
+	a.Equal("8c,14d,14h,6s,14s", deck.CardsToString(p(1).hand))
+	a.NoError(game.NextRound())
+
+	// second betting round
+	a.NoError(game.ParticipantChecks(p(1)))
+	a.NoError(game.ParticipantBets(p(2), 100))
+	a.NoError(game.ParticipantFolds(p(3)))
+	a.NoError(game.ParticipantCalls(p(1)))
+	a.False(game.IsGameOver())
+	a.NoError(game.NextRound())
+
+	// showdown
+	a.True(game.IsGameOver())
+	a.Equal(map[*Participant]int{p(1): 425}, game.winners)
+	a.Equal(250, p(1).balance)
+	a.Equal(-175, p(2).balance)
+	a.Equal(-75, p(3).balance)
+}
+
+func TestGame_everybodyFolds(t *testing.T) {
+	a := assert.New(t)
+	game := mustNewGame(DefaultOptions(), 1000, 1000, 1000)
+	a.NoError(game.DealCards())
+
+	a.NoError(game.ParticipantBets(game.idToParticipant[1], 25))
+	a.NoError(game.ParticipantFolds(game.idToParticipant[2]))
+	a.False(game.IsGameOver())
+	a.NoError(game.ParticipantFolds(game.idToParticipant[3]))
+	a.True(game.IsGameOver())
+	a.Equal(50, game.idToParticipant[1].balance)
+}
+
+func TestGame_jacksOrBetter(t *testing.T) {
+	a := assert.New(t)
+	opts := DefaultOptions()
+	opts.JacksOrBetter = true
+	game := mustNewGame(opts, 1000, 1000)
+	a.NoError(game.DealCards())
+	setHands(game, "10s,10h,2c,5d,9h", "11s,11h,3c,6d,8h")
+
+	p1 := game.idToParticipant[1]
+	p2 := game.idToParticipant[2]
+
+	a.NotContains(game.getActionsForPlayer(1), "bet")
+	a.EqualError(game.ParticipantBets(p1, 25), "you need a pair of jacks or better to open")
+	a.NoError(game.ParticipantChecks(p1))
+	a.NoError(game.ParticipantChecks(p2))
+
+	// nobody opened, so the cards are redealt
+	a.NoError(game.NextRound())
+	a.Equal(roundFirstBettingRound, game.round)
+	a.Equal(2, game.deals)
+	a.Equal(100, game.potManager.Pots().Total())
+	a.Equal(-50, p1.balance)
+	a.Equal(5, len(p1.hand))
+
+	setHands(game, "10s,10h,2c,5d,9h", "11s,11h,3c,6d,8h")
+	a.NoError(game.ParticipantChecks(p1))
+	a.NoError(game.ParticipantBets(p2, 50))
+	a.Equal(int64(2), game.opener)
+
+	// after the game is opened, anybody can raise
+	a.NoError(game.ParticipantBets(p1, 100))
+	a.NoError(game.ParticipantCalls(p2))
+	a.NoError(game.NextRound())
+	a.Equal(roundDraw, game.round)
+	a.Equal(300, game.potManager.Pots().Total())
+}
+
+func TestGame_tripsToWin(t *testing.T) {
+	a := assert.New(t)
+	opts := DefaultOptions()
+	opts.TripsToWin = true
+	game := mustNewGame(opts, 1000, 1000)
+	a.NoError(game.DealCards())
+
+	p1 := game.idToParticipant[1]
+	p2 := game.idToParticipant[2]
+
+	playToShowdown := func(hand1, hand2 string) {
+		t.Helper()
+		a.NoError(game.ParticipantChecks(p1))
+		a.NoError(game.ParticipantChecks(p2))
+		a.NoError(game.NextRound())
+		a.NoError(game.drawCardsForParticipant(p1, nil))
+		a.NoError(game.drawCardsForParticipant(p2, nil))
+		a.NoError(game.NextRound())
+		setHands(game, hand1, hand2)
+		a.NoError(game.ParticipantChecks(p1))
+		a.NoError(game.ParticipantChecks(p2))
+		a.NoError(game.NextRound())
+	}
+
+	playToShowdown("14s,14h,13c,13d,9h", "12s,12h,3c,6d,8h")
+	a.False(game.IsGameOver())
+	a.Equal(2, game.deals)
+	a.Equal(roundFirstBettingRound, game.round)
+	a.Equal(100, game.potManager.Pots().Total())
+
+	playToShowdown("14s,14h,13c,13d,9h", "12s,12h,12c,6d,8h")
+	a.True(game.IsGameOver())
+	a.Equal(map[*Participant]int{p2: 100}, game.winners)
+	a.Equal(-50, p1.balance)
+	a.Equal(50, p2.balance)
+}
+
+func TestGame_tripsToWin_cannotRedeal(t *testing.T) {
+	a := assert.New(t)
+	opts := DefaultOptions()
+	opts.TripsToWin = true
+	game := mustNewGame(opts, 25, 1000)
+	a.NoError(game.DealCards())
+
+	p1 := game.idToParticipant[1]
+	p2 := game.idToParticipant[2]
+
+	// player 1 is all-in from the ante, so there's no betting
+	a.True(game.IsRoundOver())
+	a.NoError(game.NextRound())
+	a.NoError(game.drawCardsForParticipant(p1, nil))
+	a.NoError(game.drawCardsForParticipant(p2, nil))
+	a.NoError(game.NextRound())
+	a.True(game.IsRoundOver())
+
+	// nobody has trips, but player 1 cannot ante again, so the best hand wins
+	setHands(game, "14s,14h,13c,13d,9h", "12s,12h,3c,6d,8h")
+	a.NoError(game.NextRound())
+	a.True(game.IsGameOver())
+	a.Equal(1, game.deals)
+	a.Equal(map[*Participant]int{p1: 50}, game.winners)
+}
@@ -0,0 +1,21 @@
+package fivecarddraw
+
+// Options provides options for Five-Card Draw
+type Options struct {
+	Ante int
+	// JacksOrBetter requires the first bet to be made with a pair of jacks or better
+	// If nobody can open, the cards are redealt and the pot carries over
+	JacksOrBetter bool
+	// TripsToWin requires a three-of-a-kind or better to win at showdown
+	// If nobody qualifies, the cards are redealt and the pot carries over
+	TripsToWin bool
+}
+
+// DefaultOptions returns the default set of options
+func DefaultOptions() Options {
+	return Options{
+		Ante:          25,
+		JacksOrBetter: false,
+		TripsToWin:    false,
+	}
+}
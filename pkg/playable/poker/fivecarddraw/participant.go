@@ -0,0 +1,93 @@
+package fivecarddraw
+
+import (
+	"mondaynightpoker-server/pkg/deck"
+	"mondaynightpoker-server/pkg/playable/poker/handanalyzer"
+)
+
+// Participant represents an individual participant in Five-Card Draw
+type Participant struct {
+	PlayerID   int64
+	tableStake int
+
+	didFold bool
+	balance int
+	hand    deck.Hand
+
+	// drew is how many cards the participant drew, or -1 if they have not drawn yet
+	drew int
+
+	// currentBet is how much the player has bet in the current round
+	currentBet int
+
+	handAnalyzer         *handanalyzer.HandAnalyzer
+	handAnalyzerCacheKey string
+}
+
+func newParticipant(id int64, tableStake int) *Participant {
+	return &Participant{
+		PlayerID:   id,
+		tableStake: tableStake,
+		didFold:    false,
+		balance:    0,
+		hand:       make(deck.Hand, 0, 5),
+		drew:       -1,
+	}
+}
+
+// reset is called after the round is reset
+func (p *Participant) reset() {
+	p.currentBet = 0
+}
+
+// newDeal resets the participant's hand when the cards are redealt
+func (p *Participant) newDeal() {
+	p.hand = make(deck.Hand, 0, 5)
+	p.drew = -1
+	p.didFold = false
+	p.currentBet = 0
+}
+
+// getHandAnalyzer returns a hand analyzer for the participant's current hand
+func (p *Participant) getHandAnalyzer() *handanalyzer.HandAnalyzer {
+	key := p.hand.String()
+	if p.handAnalyzer == nil || p.handAnalyzerCacheKey != key {
+		p.handAnalyzer = handanalyzer.New(5, p.hand)
+		p.handAnalyzerCacheKey = key
+	}
+
+	return p.handAnalyzer
+}
+
+// hasJacksOrBetter returns true if the participant can open with their hand
+func (p *Participant) hasJacksOrBetter() bool {
+	ha := p.getHandAnalyzer()
+	if ha.GetHand() > handanalyzer.OnePair {
+		return true
+	}
+
+	pair, ok := ha.GetPair()
+	return ok && pair >= deck.Jack
+}
+
+// potmanager.Participant implementation
+
+// ID returns the ID
+func (p *Participant) ID() int64 {
+	return p.PlayerID
+}
+
+// Balance returns the current balance
+func (p *Participant) Balance() int {
+	return p.balance + p.tableStake
+}
+
+// AdjustBalance will adjust the participant's balance
+func (p *Participant) AdjustBalance(amount int) {
+	p.balance += amount
+}
+
+// SetAmountInPlay sets the amount currently in play
+func (p *Participant) SetAmountInPlay(amount int) {
+	p.currentBet = amount
+}
@@ -0,0 +1,27 @@
+package fivecarddraw
+
+import (
+	"github.com/stretchr/testify/assert"
+	"mondaynightpoker-server/pkg/deck"
+	"testing"
+)
+
+func TestParticipant_hasJacksOrBetter(t *testing.T) {
+	a := assert.New(t)
+
+	p := newParticipant(1, 100)
+	p.hand = deck.CardsFromString("10s,10h,2c,5d,9h")
+	a.False(p.hasJacksOrBetter())
+
+	p.hand = deck.CardsFromString("11s,11h,2c,5d,9h")
+	a.True(p.hasJacksOrBetter())
+
+	p.hand = deck.CardsFromString("2s,2h,3c,3d,9h")
+	a.True(p.hasJacksOrBetter())
+
+	p.hand = deck.CardsFromString("2s,3s,4s,5s,9s")
+	a.True(p.hasJacksOrBetter())
+
+	p.hand = deck.CardsFromString("14s,13h,2c,5d,9h")
+	a.False(p.hasJacksOrBetter())
+}
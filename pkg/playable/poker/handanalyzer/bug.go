@@ -0,0 +1,108 @@
+package handanalyzer
+
+import (
+	"mondaynightpoker-server/pkg/deck"
+)
+
+// bugSuits are the suits the bug can represent
+var bugSuits = []deck.Suit{deck.Clubs, deck.Diamonds, deck.Hearts, deck.Spades}
+
+// splitBugs separates the bugs from the rest of the cards
+// The original slice is returned if there are no bugs
+func splitBugs(cards []*deck.Card) (bugs, others deck.Hand) {
+	for _, card := range cards {
+		if card.IsBug() {
+			bugs = append(bugs, card)
+		}
+	}
+
+	if bugs == nil {
+		return nil, cards
+	}
+
+	others = make(deck.Hand, 0, len(cards)-len(bugs))
+	for _, card := range cards {
+		if !card.IsBug() {
+			others = append(others, card)
+		}
+	}
+
+	return bugs, others
+}
+
+// hasNaturalCard returns true if the hand contains the card and it isn't wild
+func hasNaturalCard(hand deck.Hand, card *deck.Card) bool {
+	for _, c := range hand {
+		if !c.IsWild && c.Rank == card.Rank && c.Suit == card.Suit {
+			return true
+		}
+	}
+
+	return false
+}
+
+// isCompletedByBug returns true if the bug may be used as something other than an ace to make the hand
+func (h Hand) isCompletedByBug() bool {
+	switch h {
+	case Straight, ThreeCardPokerStraight, Flush, StraightFlush, RoyalFlush:
+		return true
+	}
+
+	return false
+}
+
+// findBestBugHand substitutes each bug with every card it can represent and returns the best hand
+// A bug can always be an ace. Any other card is only allowed if the result is a straight or flush
+// The bug cannot represent a card that is already in the hand
+func findBestBugHand(size int, cards, bugs deck.Hand, rules Rules) *HandAnalyzer {
+	hand := make(deck.Hand, len(cards), len(cards)+len(bugs))
+	copy(hand, cards)
+
+	current := make([]*deck.Card, len(bugs))
+	var best []*deck.Card
+	bestStrength := 0
+
+	var substitute func(i int, onlyAces bool)
+	substitute = func(i int, onlyAces bool) {
+		if i == len(bugs) {
+			result, strength := EvaluateWithRules(size, hand, rules)
+			if !onlyAces && !result.isCompletedByBug() {
+				return
+			}
+
+			if best == nil || strength > bestStrength {
+				best = append(best[:0], current...)
+				bestStrength = strength
+			}
+
+			return
+		}
+
+		for _, suit := range bugSuits {
+			for rank := rules.lowestRank(); rank <= deck.Ace; rank++ {
+				card := &deck.Card{Rank: rank, Suit: suit}
+				if hasNaturalCard(hand, card) {
+					continue
+				}
+
+				current[i] = card
+				hand = append(hand, current[i])
+				substitute(i+1, onlyAces && rank == deck.Ace)
+				hand = hand[:len(hand)-1]
+			}
+		}
+	}
+
+	substitute(0, true)
+
+	h := NewWithRules(size, append(hand, best...), rules)
+	for i, bug := range bugs {
+		h.assignment = append(h.assignment, WildAssignment{
+			Card: bug,
+			Mode: BugMode,
+			As:   best[i],
+		})
+	}
+
+	return h
+}
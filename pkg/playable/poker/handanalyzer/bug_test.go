@@ -0,0 +1,100 @@
+package handanalyzer
+
+import (
+	"mondaynightpoker-server/pkg/deck"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNew_bug(t *testing.T) {
+	a := assert.New(t)
+
+	for _, test := range []struct {
+		cards    string
+		hand     Hand
+		strength []int
+	}{
+		// aces
+		{"14c,14d,0j,5h,9s", ThreeOfAKind, []int{14, 9, 5}},
+		{"13c,13d,0j,5h,9s", OnePair, []int{13, 14, 9, 5}},
+		{"14c,14d,14h,0j,2s", FourOfAKind, []int{14, 2}},
+		// not wild for anything else
+		{"13c,13d,13h,0j,2s", ThreeOfAKind, []int{13, 14, 2}},
+		{"2c,2d,0j,5h,9s", OnePair, []int{2, 14, 9, 5}},
+		{"13c,13d,2h,2s,0j", TwoPair, []int{13, 2, 14}},
+		// straights
+		{"5c,6d,7h,8s,0j", Straight, []int{9}},
+		{"2c,3d,4h,5s,0j", Straight, []int{6}},
+		{"10c,11d,12h,13s,0j", Straight, []int{14}},
+		// flushes
+		{"2h,5h,7h,9h,0j", Flush, []int{14, 9, 7, 5, 2}},
+		{"2h,5h,7h,14h,0j", Flush, []int{14, 13, 7, 5, 2}},
+		{"10h,11h,12h,13h,0j", RoyalFlush, nil},
+		{"5c,6c,7c,9c,0j", StraightFlush, []int{9}},
+		// flush over a pair of aces
+		{"14c,2h,5h,7h,9h,0j", Flush, []int{14, 9, 7, 5, 2}},
+	} {
+		h := New(5, deck.CardsFromString(test.cards))
+		a.Equal(test.hand, h.GetHand(), test.cards)
+		a.Equal(calculateStrength(test.hand, test.strength), h.GetStrength(), test.cards)
+	}
+}
+
+func TestNew_bugWithWilds(t *testing.T) {
+	a := assert.New(t)
+
+	// the wild is the 4 of diamonds, the bug is the 3
+	h := New(5, deck.CardsFromString("14c,!9d,0j,5h,2s"))
+	a.Equal(Straight, h.GetHand())
+
+	// both bugs and the wild are aces
+	h = New(5, deck.CardsFromString("13c,13d,0j,!13h,0j"))
+	a.Equal(FullHouse, h.GetHand())
+	fh, _ := h.GetFullHouse()
+	a.Equal([]int{14, 13}, fh)
+}
+
+func TestNew_bugAssignment(t *testing.T) {
+	a := assert.New(t)
+
+	bug := deck.NewJoker()
+	h := New(5, append(deck.CardsFromString("5c,6d,7h,8s"), bug))
+	a.Equal(Straight, h.GetHand())
+	a.Equal(1, len(h.assignment))
+	a.Equal(bug, h.assignment[0].Card)
+	a.Equal(BugMode, h.assignment[0].Mode)
+	a.Equal(9, h.assignment[0].As.Rank)
+
+	// the bug plays as an ace in a short deck, too
+	h = NewWithRules(5, deck.CardsFromString("14c,6d,7h,8s,0j"), ShortDeckRules)
+	a.Equal(Straight, h.GetHand())
+	s, _ := h.GetStraight()
+	a.Equal(9, s)
+}
+
+func TestEvaluate_bug(t *testing.T) {
+	a := assert.New(t)
+
+	cards := deck.CardsFromString("14c,14d,0j,5h,9s,3c,4d")
+	_, ok := lookupStrength(5, cards)
+	a.False(ok)
+
+	hand, strength := Evaluate(5, cards)
+	a.Equal(Straight, hand)
+	a.Equal(New(5, cards).GetStrength(), strength)
+}
+
+func Test_splitBugs(t *testing.T) {
+	a := assert.New(t)
+
+	cards := deck.CardsFromString("2c,0j,3c,0j")
+	bugs, others := splitBugs(cards)
+	a.Equal("0j,0j", deck.CardsToString(bugs))
+	a.Equal("2c,3c", deck.CardsToString(others))
+
+	cards = deck.CardsFromString("2c,3c")
+	bugs, others = splitBugs(cards)
+	a.Nil(bugs)
+	a.Equal(deck.Hand(cards), others)
+}
@@ -0,0 +1,199 @@
+package handanalyzer
+
+import (
+	"math/bits"
+	"mondaynightpoker-server/pkg/deck"
+	"sync"
+)
+
+// The evaluator uses precomputed lookup tables to find the strength of a 5, 6, or 7-card hand
+// without any wild cards. A hand either has five or more cards of one suit, in which case the
+// strength only depends on the ranks of that suit, or its strength only depends on how many of
+// each rank it holds. Both are perfect-hashed into a table built from the HandAnalyzer, so the
+// strengths are always identical to New(5, cards).GetStrength()
+
+const (
+	// minEvaluatorCards and maxEvaluatorCards are the hand sizes covered by the lookup tables
+	minEvaluatorCards = 5
+	maxEvaluatorCards = 7
+
+	// numRanks is the number of ranks in a standard deck (2 through Ace)
+	numRanks = 13
+
+	// maxOfRank is the number of cards of a single rank in a standard deck
+	maxOfRank = 4
+
+	// handStrengthBase is the multiplier calculateStrength() uses for the hand
+	handStrengthBase = 15 * 15 * 15 * 15 * 15
+)
+
+var (
+	evaluatorOnce sync.Once
+
+	// rankCombinations[r][n] is the number of ways n cards can be spread across r ranks
+	rankCombinations [numRanks + 1][maxEvaluatorCards + 1]int
+
+	// rankTables[n] is the strength of an n-card hand without a flush, indexed by rankHash()
+	rankTables [maxEvaluatorCards + 1][]int
+
+	// flushTable is the strength of five or more cards of the same suit, indexed by a bitmask of the ranks
+	flushTable [1 << numRanks]int
+)
+
+// Evaluate returns the best hand and its strength
+// This is the same as calling New(size, cards) followed by GetHand() and GetStrength(),
+// but uses precomputed lookup tables for 5, 6, and 7-card hands without any wild cards
+func Evaluate(size int, cards []*deck.Card) (Hand, int) {
+	return EvaluateWithRules(size, cards, StandardRules)
+}
+
+// EvaluateWithRules is the same as Evaluate(), but ranks hands with the provided rules
+// The lookup tables are only used with the standard rules
+func EvaluateWithRules(size int, cards []*deck.Card, rules Rules) (Hand, int) {
+	if rules.isStandard() {
+		if strength, ok := lookupStrength(size, cards); ok {
+			return Hand(strength / handStrengthBase), strength
+		}
+	}
+
+	h := NewWithRules(size, cards, rules)
+	return h.GetHand(), h.GetStrength()
+}
+
+// lookupStrength returns the strength of the hand from the lookup tables
+// Returns false if the hand cannot be evaluated with the tables, i.e., it contains wild cards
+func lookupStrength(size int, cards []*deck.Card) (int, bool) {
+	if size != 5 || len(cards) < minEvaluatorCards || len(cards) > maxEvaluatorCards {
+		return 0, false
+	}
+
+	var counts [numRanks]int
+	var suitMasks [4]uint16
+	for _, card := range cards {
+		if card.IsWild || card.Rank < 2 || card.Rank > deck.Ace {
+			return 0, false
+		}
+
+		suit := suitIndex(card.Suit)
+		if suit < 0 {
+			return 0, false
+		}
+
+		bit := uint16(1) << (card.Rank - 2)
+		if suitMasks[suit]&bit != 0 {
+			// the same card twice, i.e., from a multi-deck shoe
+			return 0, false
+		}
+
+		suitMasks[suit] |= bit
+		counts[card.Rank-2]++
+	}
+
+	evaluatorOnce.Do(buildEvaluatorTables)
+
+	for _, mask := range suitMasks {
+		if bits.OnesCount16(mask) >= 5 {
+			return flushTable[mask], true
+		}
+	}
+
+	return rankTables[len(cards)][rankHash(counts, len(cards))], true
+}
+
+func suitIndex(suit deck.Suit) int {
+	switch suit {
+	case deck.Clubs:
+		return 0
+	case deck.Diamonds:
+		return 1
+	case deck.Hearts:
+		return 2
+	case deck.Spades:
+		return 3
+	default:
+		return -1
+	}
+}
+
+// rankHash returns a unique index from 0 to rankCombinations[numRanks][n]-1 for the rank counts of an n-card hand
+func rankHash(counts [numRanks]int, n int) int {
+	index := 0
+	for rank, count := range counts {
+		remainingRanks := numRanks - rank - 1
+		for c := 0; c < count; c++ {
+			index += rankCombinations[remainingRanks][n-c]
+		}
+
+		n -= count
+	}
+
+	return index
+}
+
+// buildEvaluatorTables populates the lookup tables using the HandAnalyzer
+func buildEvaluatorTables() {
+	rankCombinations[0][0] = 1
+	for r := 1; r <= numRanks; r++ {
+		for n := 0; n <= maxEvaluatorCards; n++ {
+			for c := 0; c <= maxOfRank && c <= n; c++ {
+				rankCombinations[r][n] += rankCombinations[r-1][n-c]
+			}
+		}
+	}
+
+	for n := minEvaluatorCards; n <= maxEvaluatorCards; n++ {
+		rankTables[n] = make([]int, rankCombinations[numRanks][n])
+
+		var counts [numRanks]int
+		forEachRankCount(&counts, 0, n, func() {
+			rankTables[n][rankHash(counts, n)] = New(5, cardsFromRankCounts(counts)).GetStrength()
+		})
+	}
+
+	for mask := 0; mask < len(flushTable); mask++ {
+		if nCards := bits.OnesCount16(uint16(mask)); nCards < 5 || nCards > maxEvaluatorCards {
+			continue
+		}
+
+		cards := make(deck.Hand, 0, maxEvaluatorCards)
+		for rank := 0; rank < numRanks; rank++ {
+			if mask&(1<<rank) != 0 {
+				cards = append(cards, &deck.Card{Rank: rank + 2, Suit: deck.Spades})
+			}
+		}
+
+		flushTable[mask] = New(5, cards).GetStrength()
+	}
+}
+
+// forEachRankCount calls fn for every way n cards can be spread across the ranks starting at rank
+func forEachRankCount(counts *[numRanks]int, rank, n int, fn func()) {
+	if rank == numRanks {
+		if n == 0 {
+			fn()
+		}
+
+		return
+	}
+
+	for c := 0; c <= maxOfRank && c <= n; c++ {
+		counts[rank] = c
+		forEachRankCount(counts, rank+1, n-c, fn)
+	}
+
+	counts[rank] = 0
+}
+
+// cardsFromRankCounts builds a hand with the rank counts
+// Suits are dealt in rotation so no suit has more than two cards and a flush is not possible
+func cardsFromRankCounts(counts [numRanks]int) deck.Hand {
+	suits := []deck.Suit{deck.Clubs, deck.Diamonds, deck.Hearts, deck.Spades}
+	cards := make(deck.Hand, 0, maxEvaluatorCards)
+	for rank, count := range counts {
+		for c := 0; c < count; c++ {
+			cards = append(cards, &deck.Card{Rank: rank + 2, Suit: suits[len(cards)%len(suits)]})
+		}
+	}
+
+	return cards
+}
@@ -0,0 +1,167 @@
+package handanalyzer
+
+import (
+	"math/rand"
+	"mondaynightpoker-server/pkg/deck"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newStandardDeck() deck.Hand {
+	cards := make(deck.Hand, 0, 52)
+	for _, suit := range []deck.Suit{deck.Clubs, deck.Diamonds, deck.Hearts, deck.Spades} {
+		for rank := 2; rank <= deck.Ace; rank++ {
+			cards = append(cards, &deck.Card{Rank: rank, Suit: suit})
+		}
+	}
+
+	return cards
+}
+
+func TestEvaluate_allFiveCardHands(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping exhaustive test in short mode")
+	}
+
+	cards := newStandardDeck()
+	hand := make(deck.Hand, 5)
+	mismatches := 0
+	total := 0
+
+	for a := 0; a < len(cards); a++ {
+		for b := a + 1; b < len(cards); b++ {
+			for c := b + 1; c < len(cards); c++ {
+				for d := c + 1; d < len(cards); d++ {
+					for e := d + 1; e < len(cards); e++ {
+						hand[0], hand[1], hand[2], hand[3], hand[4] = cards[a], cards[b], cards[c], cards[d], cards[e]
+						total++
+
+						h := New(5, hand)
+						got, strength := Evaluate(5, hand)
+						if got != h.GetHand() || strength != h.GetStrength() {
+							mismatches++
+							if mismatches <= 10 {
+								t.Errorf("%s: expected %s (%d), got %s (%d)", hand.String(), h.GetHand(), h.GetStrength(), got, strength)
+							}
+						}
+					}
+				}
+			}
+		}
+	}
+
+	assert.Equal(t, 2598960, total)
+	assert.Equal(t, 0, mismatches)
+}
+
+func TestEvaluate_sixAndSevenCardHands(t *testing.T) {
+	a := assert.New(t)
+
+	r := rand.New(rand.NewSource(1))
+	cards := newStandardDeck()
+	for i := 0; i < 50000; i++ {
+		r.Shuffle(len(cards), func(i, j int) {
+			cards[i], cards[j] = cards[j], cards[i]
+		})
+
+		for _, n := range []int{6, 7} {
+			hand := cards[0:n]
+			h := New(5, hand)
+			got, strength := Evaluate(5, hand)
+			if !a.Equal(h.GetStrength(), strength, hand.String()) || !a.Equal(h.GetHand(), got, hand.String()) {
+				return
+			}
+		}
+	}
+}
+
+func TestEvaluate(t *testing.T) {
+	a := assert.New(t)
+
+	hand, strength := Evaluate(5, deck.CardsFromString("2c,3c,4c,5c,6c,8d,8h"))
+	a.Equal(StraightFlush, hand)
+	a.Equal(7897500, strength)
+
+	hand, _ = Evaluate(5, deck.CardsFromString("14c,13c,12c,11c,10c,9c,8c"))
+	a.Equal(RoyalFlush, hand)
+
+	hand, _ = Evaluate(5, deck.CardsFromString("14c,14d,14h,13c,13d,13h,2s"))
+	a.Equal(FullHouse, hand)
+
+	hand, _ = Evaluate(5, deck.CardsFromString("14c,2d,3h,4s,5c,10d"))
+	a.Equal(Straight, hand)
+}
+
+func TestEvaluate_fallback(t *testing.T) {
+	a := assert.New(t)
+
+	for _, test := range []struct {
+		size  int
+		cards string
+	}{
+		{5, "2c,2d,5h,7s,!9c"},         // wild cards
+		{5, "2c,2d,5h,7s,9c,2c"},       // duplicate cards from a shoe
+		{5, "2c,2d,5h,7s"},             // too few cards
+		{5, "2c,2d,5h,7s,9c,9d,9h,3c"}, // too many cards
+		{3, "2c,2d,5h,7s,9c"},          // three-card poker
+	} {
+		cards := deck.CardsFromString(test.cards)
+		_, ok := lookupStrength(test.size, cards)
+		a.False(ok, test.cards)
+
+		h := New(test.size, cards)
+		hand, strength := Evaluate(test.size, cards)
+		a.Equal(h.GetHand(), hand, test.cards)
+		a.Equal(h.GetStrength(), strength, test.cards)
+	}
+}
+
+func Test_rankHash(t *testing.T) {
+	evaluatorOnce.Do(buildEvaluatorTables)
+
+	a := assert.New(t)
+	a.Equal(6175, rankCombinations[numRanks][5])
+	a.Equal(18395, rankCombinations[numRanks][6])
+	a.Equal(49205, rankCombinations[numRanks][7])
+
+	seen := make(map[int]bool)
+	var counts [numRanks]int
+	forEachRankCount(&counts, 0, 7, func() {
+		index := rankHash(counts, 7)
+		a.False(seen[index])
+		a.Less(index, len(rankTables[7]))
+		seen[index] = true
+	})
+
+	a.Equal(49205, len(seen))
+}
+
+var benchmarkHands = []deck.Hand{
+	deck.CardsFromString("3s,5s,6h,7h,11c,12c,14h"),
+	deck.CardsFromString("2c,3c,4c,5c,6c,8d,8h"),
+	deck.CardsFromString("14c,14d,14h,13c,13d,13h,2s"),
+	deck.CardsFromString("9d,10h,11s,12c,13d,2h,2s"),
+}
+
+func BenchmarkEvaluate(b *testing.B) {
+	evaluatorOnce.Do(buildEvaluatorTables)
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		Evaluate(5, benchmarkHands[i%len(benchmarkHands)])
+	}
+}
+
+func BenchmarkEvaluate_wilds(b *testing.B) {
+	hand := deck.CardsFromString("3s,5s,6h,7h,11c,!12c,!14h")
+	for i := 0; i < b.N; i++ {
+		Evaluate(5, hand)
+	}
+}
+
+func BenchmarkNew_sevenCards(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		New(5, benchmarkHands[i%len(benchmarkHands)]).GetStrength()
+	}
+}
@@ -0,0 +1,441 @@
+package handanalyzer
+
+import (
+	"fmt"
+	"mondaynightpoker-server/pkg/deck"
+	"strconv"
+	"strings"
+)
+
+// Explanation describes how a hand was made
+type Explanation struct {
+	Hand Hand
+
+	// Cards are the cards that make the hand, most significant first
+	// Wild cards are clones that represent the card they were used as, see deck.Card.GetWildRank() and GetWildSuit()
+	// The bug is shown as a wild card
+	Cards deck.Hand
+
+	// Kickers are the ranks of the cards that are only used to break ties
+	Kickers []int
+
+	// ranks are the ranks that determine the strength of the hand
+	ranks []int
+}
+
+// slot is a card that is required to make the hand
+type slot struct {
+	rank int
+	suit deck.Suit
+}
+
+// Explain returns an explanation of the hand
+func (h *HandAnalyzer) Explain() *Explanation {
+	hand := h.GetHand()
+	ranks := h.getRanks()
+
+	e := &Explanation{
+		Hand:  hand,
+		ranks: ranks,
+	}
+
+	var slots []slot
+	addSlots := func(rank, n int) {
+		if rank == 0 {
+			return
+		}
+
+		for i := 0; i < n; i++ {
+			slots = append(slots, slot{rank: rank})
+		}
+	}
+
+	switch hand {
+	case HighCard:
+		for _, rank := range ranks {
+			addSlots(rank, 1)
+		}
+		e.Kickers = ranks[1:]
+	case OnePair:
+		addSlots(ranks[0], 2)
+		for _, rank := range ranks[1:] {
+			addSlots(rank, 1)
+		}
+		e.Kickers = ranks[1:]
+	case TwoPair:
+		addSlots(ranks[0], 2)
+		addSlots(ranks[1], 2)
+		addSlots(ranks[2], 1)
+		e.Kickers = ranks[2:]
+	case ThreeOfAKind, ThreeCardPokerThreeOfAKind:
+		addSlots(ranks[0], 3)
+		for _, rank := range ranks[1:] {
+			addSlots(rank, 1)
+		}
+		e.Kickers = ranks[1:]
+	case FullHouse:
+		addSlots(ranks[0], 3)
+		addSlots(ranks[1], 2)
+	case FourOfAKind:
+		addSlots(ranks[0], 4)
+		addSlots(ranks[1], 1)
+		e.Kickers = ranks[1:]
+	case Straight, ThreeCardPokerStraight:
+		slots = h.straightSlots(ranks[0], "")
+	case Flush:
+		suit := h.bestSuit(ranks)
+		for _, rank := range ranks {
+			slots = append(slots, slot{rank: rank, suit: suit})
+		}
+	case StraightFlush:
+		slots = h.straightSlots(ranks[0], "")
+		slots = h.straightSlots(ranks[0], h.bestSuit(slotRanks(slots)))
+	case RoyalFlush:
+		slots = h.straightSlots(deck.Ace, "")
+		slots = h.straightSlots(deck.Ace, h.bestSuit(slotRanks(slots)))
+	}
+
+	e.Kickers = nonZero(e.Kickers)
+	e.Cards = h.fillSlots(slots)
+	return e
+}
+
+// straightSlots returns the slots for a straight to the high card
+func (h *HandAnalyzer) straightSlots(high int, suit deck.Suit) []slot {
+	slots := make([]slot, 0, h.size)
+	for rank := high; rank > high-h.size; rank-- {
+		if rank == h.rules.lowAceRank() {
+			slots = append(slots, slot{rank: deck.Ace, suit: suit})
+		} else {
+			slots = append(slots, slot{rank: rank, suit: suit})
+		}
+	}
+
+	return slots
+}
+
+// bestSuit returns the suit that has the most cards of the provided ranks
+func (h *HandAnalyzer) bestSuit(ranks []int) deck.Suit {
+	counts := make(map[deck.Suit]int)
+	var best deck.Suit
+	for _, card := range h.cards {
+		for _, rank := range ranks {
+			if card.Rank == rank {
+				counts[card.Suit]++
+				if best == "" || counts[card.Suit] > counts[best] {
+					best = card.Suit
+				}
+
+				break
+			}
+		}
+	}
+
+	// wilds that keep their suit can make the flush on their own
+	if best == "" && len(h.wildCards) > 0 {
+		best = h.wildCards[0].Suit
+	}
+
+	return best
+}
+
+// fillSlots finds a card for each slot
+// Natural cards are used first, then the wild cards
+func (h *HandAnalyzer) fillSlots(slots []slot) deck.Hand {
+	bugs := make(map[*deck.Card]*deck.Card)
+	for _, a := range h.assignment {
+		if a.Mode == BugMode {
+			bugs[a.As] = a.Card
+		}
+	}
+
+	used := make(map[*deck.Card]bool)
+	cards := make(deck.Hand, len(slots))
+	var missing []int
+	for i, s := range slots {
+		for _, card := range h.cards {
+			if used[card] || card.Rank != s.rank || (s.suit != "" && card.Suit != s.suit) {
+				continue
+			}
+
+			used[card] = true
+			if bug, ok := bugs[card]; ok {
+				cards[i] = wildAs(bug, card.Rank, card.Suit)
+			} else {
+				cards[i] = card
+			}
+
+			break
+		}
+
+		if cards[i] == nil {
+			missing = append(missing, i)
+		}
+	}
+
+	for _, i := range missing {
+		s := slots[i]
+		var wild *deck.Card
+		for _, card := range h.wildCards {
+			if used[card] {
+				continue
+			}
+
+			// prefer a wild that keeps its rank or suit
+			if wild == nil || card.Rank == s.rank || card.Suit == s.suit {
+				wild = card
+			}
+		}
+
+		if wild == nil {
+			continue
+		}
+
+		used[wild] = true
+		suit := s.suit
+		if suit == "" {
+			suit = wild.Suit
+		}
+
+		cards[i] = wildAs(wild, s.rank, suit)
+	}
+
+	filled := make(deck.Hand, 0, len(cards))
+	for _, card := range cards {
+		if card != nil {
+			filled = append(filled, card)
+		}
+	}
+
+	return filled
+}
+
+// wildAs returns a clone of the wild card that represents the rank and suit
+func wildAs(wild *deck.Card, rank int, suit deck.Suit) *deck.Card {
+	clone := wild.Clone()
+	clone.IsWild = true
+	_ = clone.SetWildRank(rank)
+	_ = clone.SetWildSuit(suit)
+	return clone
+}
+
+func slotRanks(slots []slot) []int {
+	ranks := make([]int, len(slots))
+	for i, s := range slots {
+		ranks[i] = s.rank
+	}
+
+	return ranks
+}
+
+func nonZero(ranks []int) []int {
+	result := make([]int, 0, len(ranks))
+	for _, rank := range ranks {
+		if rank > 0 {
+			result = append(result, rank)
+		}
+	}
+
+	return result
+}
+
+// Wilds returns the wild cards used to make the hand
+func (e *Explanation) Wilds() deck.Hand {
+	wilds := make(deck.Hand, 0)
+	for _, card := range e.Cards {
+		if card.IsWild {
+			wilds = append(wilds, card)
+		}
+	}
+
+	return wilds
+}
+
+// String returns the hand with its details, i.e., "Pair (kings, A-9-5 kickers)"
+func (e *Explanation) String() string {
+	details := make([]string, 0, 2)
+	if d := e.details(); d != "" {
+		details = append(details, d)
+	}
+
+	for _, wild := range e.Wilds() {
+		as := &deck.Card{Rank: wild.GetWildRank(), Suit: wild.GetWildSuit()}
+		original := wild.Clone()
+		original.IsWild = false
+		details = append(details, fmt.Sprintf("%s as %s", original.String(), as.String()))
+	}
+
+	if len(details) == 0 {
+		return e.Hand.String()
+	}
+
+	return fmt.Sprintf("%s (%s)", e.Hand.String(), strings.Join(details, ", "))
+}
+
+func (e *Explanation) details() string {
+	r := e.ranks
+	switch e.Hand {
+	case HighCard, Flush:
+		return joinRanks(nonZero(r))
+	case OnePair, ThreeOfAKind, ThreeCardPokerThreeOfAKind, FourOfAKind:
+		return withKickers(pluralRankName(r[0]), e.Kickers)
+	case TwoPair:
+		return withKickers(fmt.Sprintf("%s and %s", pluralRankName(r[0]), pluralRankName(r[1])), e.Kickers)
+	case FullHouse:
+		return fmt.Sprintf("%s full of %s", pluralRankName(r[0]), pluralRankName(r[1]))
+	case Straight, ThreeCardPokerStraight, StraightFlush:
+		return fmt.Sprintf("%s high", rankNames[r[0]])
+	}
+
+	return ""
+}
+
+func withKickers(made string, kickers []int) string {
+	switch len(kickers) {
+	case 0:
+		return made
+	case 1:
+		return fmt.Sprintf("%s, %s kicker", made, joinRanks(kickers))
+	}
+
+	return fmt.Sprintf("%s, %s kickers", made, joinRanks(kickers))
+}
+
+// Compare compares two hands and explains the result
+// It returns a positive number if a beats b, a negative number if b beats a, or zero if they tie
+// The reason always describes the winning hand first, i.e., "kicker: A beats Q"
+func Compare(a, b *HandAnalyzer) (int, string) {
+	sa, sb := a.GetStrength(), b.GetStrength()
+	if sa == sb {
+		return 0, "tie"
+	}
+
+	result := 1
+	if sa < sb {
+		result = -1
+		a, b = b, a
+	}
+
+	if a.GetHand() != b.GetHand() {
+		return result, fmt.Sprintf("%s beats %s", a.GetHand().String(), b.GetHand().String())
+	}
+
+	ra, rb := a.getRanks(), b.getRanks()
+	for i := range ra {
+		if i >= len(rb) || ra[i] == rb[i] {
+			continue
+		}
+
+		return result, fmt.Sprintf("%s: %s beats %s", rankLabel(a.GetHand(), i), rankString(ra[i]), rankString(rb[i]))
+	}
+
+	// unreachable as long as the strength is calculated from the ranks
+	return result, fmt.Sprintf("%s beats %s", a.GetHand().String(), b.GetHand().String())
+}
+
+// rankLabel returns what the rank at index i of the hand's ranks describes
+func rankLabel(hand Hand, i int) string {
+	switch hand {
+	case HighCard, Flush:
+		if i == 0 {
+			return "high card"
+		}
+
+		return ordinals[i] + " card"
+	case OnePair:
+		if i == 0 {
+			return "pair"
+		}
+
+		return kickerLabel(i - 1)
+	case TwoPair:
+		switch i {
+		case 0:
+			return "top pair"
+		case 1:
+			return "bottom pair"
+		}
+
+		return kickerLabel(i - 2)
+	case ThreeOfAKind, ThreeCardPokerThreeOfAKind:
+		if i == 0 {
+			return "three of a kind"
+		}
+
+		return kickerLabel(i - 1)
+	case FullHouse:
+		if i == 0 {
+			return "three of a kind"
+		}
+
+		return "pair"
+	case FourOfAKind:
+		if i == 0 {
+			return "four of a kind"
+		}
+
+		return kickerLabel(i - 1)
+	}
+
+	return "high card"
+}
+
+func kickerLabel(i int) string {
+	if i == 0 {
+		return "kicker"
+	}
+
+	return ordinals[i] + " kicker"
+}
+
+var ordinals = []string{"first", "second", "third", "fourth", "fifth"}
+
+var rankNames = map[int]string{
+	deck.LowAce: "ace",
+	2:           "two",
+	3:           "three",
+	4:           "four",
+	5:           "five",
+	6:           "six",
+	7:           "seven",
+	8:           "eight",
+	9:           "nine",
+	10:          "ten",
+	deck.Jack:   "jack",
+	deck.Queen:  "queen",
+	deck.King:   "king",
+	deck.Ace:    "ace",
+}
+
+func pluralRankName(rank int) string {
+	if rank == 6 {
+		return "sixes"
+	}
+
+	return rankNames[rank] + "s"
+}
+
+// rankString returns the short name of the rank, i.e., "K"
+func rankString(rank int) string {
+	switch rank {
+	case deck.Jack:
+		return "J"
+	case deck.Queen:
+		return "Q"
+	case deck.King:
+		return "K"
+	case deck.Ace, deck.LowAce:
+		return "A"
+	}
+
+	return strconv.Itoa(rank)
+}
+
+func joinRanks(ranks []int) string {
+	s := make([]string, len(ranks))
+	for i, rank := range ranks {
+		s[i] = rankString(rank)
+	}
+
+	return strings.Join(s, "-")
+}
@@ -0,0 +1,97 @@
+package handanalyzer
+
+import (
+	"mondaynightpoker-server/pkg/deck"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHandAnalyzer_Explain(t *testing.T) {
+	a := assert.New(t)
+
+	for _, test := range []struct {
+		cards       string
+		explanation string
+		used        string
+		kickers     []int
+	}{
+		{"13c,13d,5h,9s,2c,3d,14h", "Pair (kings, A-9-5 kickers)", "13c,13d,14h,9s,5h", []int{14, 9, 5}},
+		{"7c,7d,8h,8s,13c,2d", "Two pair (eights and sevens, K kicker)", "8h,8s,7c,7d,13c", []int{13}},
+		{"13c,12d,9h,5s,2c", "High card (K-Q-9-5-2)", "13c,12d,9h,5s,2c", []int{12, 9, 5, 2}},
+		{"9c,9d,9h,9s,2c", "Four of a kind (nines, 2 kicker)", "9c,9d,9h,9s,2c", []int{2}},
+		{"6c,6d,6h,2s,2c,2d", "Full house (sixes full of twos)", "6c,6d,6h,2s,2c", nil},
+		{"14c,2c,3c,4c,5c,9d", "Straight flush (five high)", "5c,4c,3c,2c,14c", nil},
+		{"10h,11h,12h,13h,14h", "Royal flush", "14h,13h,12h,11h,10h", nil},
+		{"2h,5h,7h,9h,11h,13c", "Flush (J-9-7-5-2)", "11h,9h,7h,5h,2h", nil},
+		{"5c,6d,7h,8s,!2c", "Straight (nine high, 2♣ as 9♣)", "!2c,8s,7h,6d,5c", nil},
+		{"14c,14d,0j,5h,9s", "Three of a kind (aces, 9-5 kickers, 🃏 as A♡)", "14c,14d,!0j,9s,5h", []int{9, 5}},
+	} {
+		e := New(5, deck.CardsFromString(test.cards)).Explain()
+		a.Equal(test.explanation, e.String(), test.cards)
+		a.Equal(test.used, deck.CardsToString(e.Cards), test.cards)
+		if test.kickers == nil {
+			a.Empty(e.Kickers, test.cards)
+		} else {
+			a.Equal(test.kickers, e.Kickers, test.cards)
+		}
+	}
+}
+
+func TestHandAnalyzer_Explain_wilds(t *testing.T) {
+	a := assert.New(t)
+
+	e := New(5, deck.CardsFromString("14c,!9d,0j,5h,2s")).Explain()
+	a.Equal(Straight, e.Hand)
+
+	wilds := e.Wilds()
+	a.Equal(2, len(wilds))
+	a.Equal(4, wilds[0].GetWildRank())
+	a.Equal(deck.Diamonds, wilds[0].GetWildSuit())
+	a.True(wilds[1].IsJoker())
+	a.Equal(3, wilds[1].GetWildRank())
+
+	// the original cards are not modified
+	a.Equal("14c,!9d,0j,5h,2s", deck.CardsToString(deck.CardsFromString("14c,!9d,0j,5h,2s")))
+
+	// a short-deck straight plays the ace low
+	e = NewWithRules(5, deck.CardsFromString("14c,6d,7h,8s,9c"), ShortDeckRules).Explain()
+	a.Equal("Straight (nine high)", e.String())
+	a.Equal("9c,8s,7h,6d,14c", deck.CardsToString(e.Cards))
+}
+
+func TestCompare(t *testing.T) {
+	a := assert.New(t)
+
+	compare := func(cardsA, cardsB string) (int, string) {
+		return Compare(New(5, deck.CardsFromString(cardsA)), New(5, deck.CardsFromString(cardsB)))
+	}
+
+	result, reason := compare("13c,13d,14h,9s,5c", "13h,13s,12h,9c,5d")
+	a.Equal(1, result)
+	a.Equal("kicker: A beats Q", reason)
+
+	result, reason = compare("13c,13d,2h,9s,5c", "13h,13s,12h,12c,5d")
+	a.Equal(-1, result)
+	a.Equal("Two pair beats Pair", reason)
+
+	result, reason = compare("13c,13d,12h,9s,5c", "13h,13s,12d,9c,4d")
+	a.Equal(1, result)
+	a.Equal("third kicker: 5 beats 4", reason)
+
+	result, reason = compare("8c,8d,7h,7s,5c", "8h,8s,6d,6c,14d")
+	a.Equal(1, result)
+	a.Equal("bottom pair: 7 beats 6", reason)
+
+	result, reason = compare("2h,5h,7h,9h,11h", "3c,5c,7c,9c,11c")
+	a.Equal(-1, result)
+	a.Equal("fifth card: 3 beats 2", reason)
+
+	result, reason = compare("14c,14d,14h,2c,2d", "14s,14d,14h,3c,3d")
+	a.Equal(-1, result)
+	a.Equal("pair: 3 beats 2", reason)
+
+	result, reason = compare("2c,3d,4h,5s,6c", "2d,3h,4s,5c,6d")
+	a.Equal(0, result)
+	a.Equal("tie", reason)
+}
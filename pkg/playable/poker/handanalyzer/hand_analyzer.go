@@ -21,12 +21,23 @@ type HandAnalyzer struct {
 	hand     Hand
 	strength int
 
+	rules Rules
+
 	// assignment tracks wild mode assignments for constrained wild analysis
 	assignment []WildAssignment
 }
 
 // New will return a new HandAnalyzer instance
 func New(size int, cards []*deck.Card) *HandAnalyzer {
+	return NewWithRules(size, cards, StandardRules)
+}
+
+// NewWithRules will return a new HandAnalyzer instance that ranks hands with the provided rules
+func NewWithRules(size int, cards []*deck.Card, rules Rules) *HandAnalyzer {
+	if bugs, others := splitBugs(cards); len(bugs) > 0 {
+		return findBestBugHand(size, others, bugs, rules)
+	}
+
 	// clone to prevent modifying original
 	sortedCards := make(deck.Hand, len(cards))
 	copy(sortedCards, cards)
@@ -50,11 +61,12 @@ func New(size int, cards []*deck.Card) *HandAnalyzer {
 			size:      size,
 			cards:     nonWilds,
 			wildCards: wilds,
+			rules:     rules,
 		}
 		best.analyzeHand()
 	} else {
 		// Try all 2^N wild mode combinations and pick the best
-		best = findBestConstrainedHand(size, nonWilds, wilds)
+		best = findBestConstrainedHand(size, nonWilds, wilds, rules)
 	}
 
 	best.calculateHand()
@@ -62,13 +74,13 @@ func New(size int, cards []*deck.Card) *HandAnalyzer {
 }
 
 // findBestConstrainedHand tries all wild mode combinations and returns the best hand
-func findBestConstrainedHand(size int, nonWilds, wilds deck.Hand) *HandAnalyzer {
+func findBestConstrainedHand(size int, nonWilds, wilds deck.Hand, rules Rules) *HandAnalyzer {
 	combinations := generateWildCombinations(wilds)
 	var best *HandAnalyzer
 	bestStrength := -1
 
 	for _, assignment := range combinations {
-		h := analyzeWithAssignment(size, nonWilds, wilds, assignment)
+		h := analyzeWithAssignment(size, nonWilds, wilds, assignment, rules)
 		h.calculateHand()
 		if s := h.GetStrength(); s > bestStrength {
 			bestStrength = s
@@ -79,12 +91,13 @@ func findBestConstrainedHand(size int, nonWilds, wilds deck.Hand) *HandAnalyzer
 }
 
 // analyzeWithAssignment analyzes a hand with a specific wild mode assignment
-func analyzeWithAssignment(size int, nonWilds, wilds deck.Hand, assignment []WildAssignment) *HandAnalyzer {
+func analyzeWithAssignment(size int, nonWilds, wilds deck.Hand, assignment []WildAssignment, rules Rules) *HandAnalyzer {
 	h := &HandAnalyzer{
 		size:       size,
 		cards:      nonWilds,
 		wildCards:  wilds,
 		assignment: assignment,
+		rules:      rules,
 	}
 
 	h.analyzeHandConstrained()
@@ -114,21 +127,21 @@ func (h *HandAnalyzer) analyzeHandConstrained() {
 
 	// Check straights using the simplified algorithm
 	// Try high ace first
-	h.straight = checkStraightSimple(h.cards, h.assignment, h.size, false, "", deck.HighAce)
+	h.straight = checkStraightSimple(h.cards, h.assignment, h.size, false, "", deck.HighAce, h.rules)
 	if h.straight == 0 {
 		// Try low ace (wheel)
-		h.straight = checkStraightSimple(h.cards, h.assignment, h.size, false, "", deck.LowAce)
+		h.straight = checkStraightSimple(h.cards, h.assignment, h.size, false, "", deck.LowAce, h.rules)
 	}
 
 	// Check straight flushes for each suit
 	for _, suit := range []deck.Suit{deck.Clubs, deck.Diamonds, deck.Hearts, deck.Spades} {
 		// Try high ace
-		sf := checkStraightSimple(h.cards, h.assignment, h.size, true, suit, deck.HighAce)
+		sf := checkStraightSimple(h.cards, h.assignment, h.size, true, suit, deck.HighAce, h.rules)
 		if sf > h.straightFlush {
 			h.straightFlush = sf
 		}
 		// Try low ace
-		sf = checkStraightSimple(h.cards, h.assignment, h.size, true, suit, deck.LowAce)
+		sf = checkStraightSimple(h.cards, h.assignment, h.size, true, suit, deck.LowAce, h.rules)
 		if sf > h.straightFlush {
 			h.straightFlush = sf
 		}
@@ -365,12 +378,18 @@ func (h *HandAnalyzer) GetStrength() int {
 }
 
 func (h *HandAnalyzer) getStrength() int {
+	return calculateStrength(h.rules.rank(h.GetHand()), h.getRanks())
+}
+
+// getRanks returns the ranks that determine the strength of the hand, in order of significance
+// For example, a pair of kings with an ace, nine and five returns [13, 14, 9, 5]
+func (h *HandAnalyzer) getRanks() []int {
 	hand := h.GetHand()
 
 	switch hand {
 	case HighCard:
 		c, _ := h.GetHighCard()
-		return calculateStrength(hand, c)
+		return c
 	case OnePair:
 		pair, _ := h.GetPair()
 		hc := make([]int, 0)
@@ -384,7 +403,7 @@ func (h *HandAnalyzer) getStrength() int {
 				break
 			}
 		}
-		return calculateStrength(hand, append([]int{pair}, hc...))
+		return append([]int{pair}, hc...)
 	case TwoPair:
 		twoPair, _ := h.GetTwoPair()
 		hc := 0
@@ -396,7 +415,7 @@ func (h *HandAnalyzer) getStrength() int {
 			hc = card.Rank
 			break
 		}
-		return calculateStrength(hand, []int{twoPair[0], twoPair[1], hc})
+		return []int{twoPair[0], twoPair[1], hc}
 	case ThreeOfAKind:
 		trips, _ := h.GetThreeOfAKind()
 		hc := make([]int, 0)
@@ -410,22 +429,22 @@ func (h *HandAnalyzer) getStrength() int {
 				break
 			}
 		}
-		return calculateStrength(hand, append([]int{trips}, hc...))
+		return append([]int{trips}, hc...)
 	case Straight:
 		s, _ := h.GetStraight()
-		return calculateStrength(hand, []int{s})
+		return []int{s}
 	case Flush:
 		f, _ := h.GetFlush()
-		return calculateStrength(hand, f)
+		return f
 	case ThreeCardPokerStraight:
 		s, _ := h.getThreeCardPokerStraight()
-		return calculateStrength(hand, []int{s})
+		return []int{s}
 	case ThreeCardPokerThreeOfAKind:
 		t, _ := h.getThreeCardPokerThreeOfAKind()
-		return calculateStrength(hand, []int{t})
+		return []int{t}
 	case FullHouse:
 		fh, _ := h.GetFullHouse()
-		return calculateStrength(hand, fh)
+		return fh
 	case FourOfAKind:
 		fk, _ := h.GetFourOfAKind()
 		found := 0
@@ -447,12 +466,12 @@ func (h *HandAnalyzer) getStrength() int {
 			hc = deck.Ace
 		}
 
-		return calculateStrength(hand, []int{fk, hc})
+		return []int{fk, hc}
 	case StraightFlush:
 		s, _ := h.GetStraightFlush()
-		return calculateStrength(hand, []int{s})
+		return []int{s}
 	case RoyalFlush:
-		return calculateStrength(hand, []int{})
+		return []int{}
 	}
 
 	panic("unknown hand")
@@ -783,6 +802,8 @@ func (h *HandAnalyzer) calculateHand() {
 		h.hand = StraightFlush
 	} else if _, ok := h.GetFourOfAKind(); ok {
 		h.hand = FourOfAKind
+	} else if _, ok := h.GetFlush(); ok && h.rules.FlushBeatsFullHouse {
+		h.hand = Flush
 	} else if _, ok := h.GetFullHouse(); ok {
 		h.hand = FullHouse
 	} else if _, ok := h.getThreeCardPokerThreeOfAKind(); ok {
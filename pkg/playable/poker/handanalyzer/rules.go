@@ -0,0 +1,55 @@
+package handanalyzer
+
+// Rules changes how hands are ranked for decks other than the standard 52-card deck
+type Rules struct {
+	// LowestRank is the lowest rank in the deck
+	// An ace plays below it in the lowest straight, i.e., A-2-3-4-5, or A-6-7-8-9 in a short deck
+	LowestRank int
+
+	// FlushBeatsFullHouse ranks a flush above a full house
+	FlushBeatsFullHouse bool
+}
+
+// StandardRules are the hand rankings for a standard 52-card deck
+var StandardRules = Rules{
+	LowestRank: 2,
+}
+
+// ShortDeckRules are the hand rankings for a 36-card deck without the 2s through 5s
+// A flush is harder to make than a full house with only nine cards of each suit
+var ShortDeckRules = Rules{
+	LowestRank:          6,
+	FlushBeatsFullHouse: true,
+}
+
+func (r Rules) lowestRank() int {
+	if r.LowestRank == 0 {
+		return StandardRules.LowestRank
+	}
+
+	return r.LowestRank
+}
+
+// lowAceRank is the rank an ace takes when it plays low in a straight
+func (r Rules) lowAceRank() int {
+	return r.lowestRank() - 1
+}
+
+// isStandard returns true if the rules rank hands the same as StandardRules
+func (r Rules) isStandard() bool {
+	return r.lowestRank() == StandardRules.LowestRank && !r.FlushBeatsFullHouse
+}
+
+// rank returns the position of the hand used when calculating its strength
+func (r Rules) rank(hand Hand) Hand {
+	if r.FlushBeatsFullHouse {
+		switch hand {
+		case Flush:
+			return FullHouse
+		case FullHouse:
+			return Flush
+		}
+	}
+
+	return hand
+}
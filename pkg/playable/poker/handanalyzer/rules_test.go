@@ -0,0 +1,77 @@
+package handanalyzer
+
+import (
+	"mondaynightpoker-server/pkg/deck"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewWithRules_shortDeck(t *testing.T) {
+	a := assert.New(t)
+
+	// A-6-7-8-9 is the lowest straight
+	h := NewWithRules(5, deck.CardsFromString("14c,6d,7h,8s,9c,12d,12h"), ShortDeckRules)
+	a.Equal(Straight, h.GetHand())
+	s, _ := h.GetStraight()
+	a.Equal(9, s)
+
+	wheel := h.GetStrength()
+	h = NewWithRules(5, deck.CardsFromString("6d,7h,8s,9c,10d"), ShortDeckRules)
+	a.Greater(h.GetStrength(), wheel)
+
+	// A-6-7-8-9 suited is a straight flush
+	h = NewWithRules(5, deck.CardsFromString("14c,6c,7c,8c,9c"), ShortDeckRules)
+	a.Equal(StraightFlush, h.GetHand())
+	s, _ = h.GetStraightFlush()
+	a.Equal(9, s)
+
+	// a flush beats a full house
+	flush := NewWithRules(5, deck.CardsFromString("6c,8c,10c,11c,13c,13d,13h"), ShortDeckRules)
+	a.Equal(Flush, flush.GetHand())
+	fullHouse := NewWithRules(5, deck.CardsFromString("14c,14d,14h,13s,13d"), ShortDeckRules)
+	a.Equal(FullHouse, fullHouse.GetHand())
+	a.Greater(flush.GetStrength(), fullHouse.GetStrength())
+
+	quads := NewWithRules(5, deck.CardsFromString("6c,6d,6h,6s,7c"), ShortDeckRules)
+	a.Greater(quads.GetStrength(), flush.GetStrength())
+
+	straight := NewWithRules(5, deck.CardsFromString("10c,11d,12h,13s,14d"), ShortDeckRules)
+	a.Greater(fullHouse.GetStrength(), straight.GetStrength())
+}
+
+func TestNewWithRules_shortDeckWilds(t *testing.T) {
+	a := assert.New(t)
+
+	// a wild fills in the 6
+	h := NewWithRules(5, deck.CardsFromString("14c,7h,8s,9c,!12d"), ShortDeckRules)
+	a.Equal(Straight, h.GetHand())
+	s, _ := h.GetStraight()
+	a.Equal(9, s)
+
+	// a wild makes a flush instead of a full house
+	h = NewWithRules(5, deck.CardsFromString("6c,8c,12c,12d,14c,14d,!9h"), ShortDeckRules)
+	a.Equal(Flush, h.GetHand())
+
+	h = New(5, deck.CardsFromString("6c,8c,12c,12d,14c,14d,!9h"))
+	a.Equal(FullHouse, h.GetHand())
+}
+
+func TestEvaluateWithRules(t *testing.T) {
+	a := assert.New(t)
+
+	cards := deck.CardsFromString("6c,8c,10c,11c,13c,13d,13h")
+	hand, strength := EvaluateWithRules(5, cards, ShortDeckRules)
+	a.Equal(Flush, hand)
+	a.Equal(NewWithRules(5, cards, ShortDeckRules).GetStrength(), strength)
+
+	hand, _ = EvaluateWithRules(5, deck.CardsFromString("14c,6d,7h,8s,9c"), ShortDeckRules)
+	a.Equal(Straight, hand)
+
+	// the zero value is the standard rules
+	hand, standard := EvaluateWithRules(5, cards, Rules{})
+	a.Equal(Flush, hand)
+	_, expected := Evaluate(5, cards)
+	a.Equal(expected, standard)
+	a.Greater(strength, standard)
+}
@@ -60,7 +60,7 @@ func (s *straightTracker) reclaimWilds(wildsInHand, currentGap int) {
 func (h *HandAnalyzer) checkStraight(card *deck.Card, st *straightTracker, aceValue int, val *int) {
 	cardRank := card.Rank
 	if cardRank == deck.Ace && aceValue == deck.LowAce {
-		cardRank = deck.LowAce
+		cardRank = h.rules.lowAceRank()
 	}
 
 	// currently no streak, so we start from scratch
@@ -137,7 +137,9 @@ func (h *HandAnalyzer) checkStraight(card *deck.Card, st *straightTracker, aceVa
 
 // checkStraightSimple is a simplified straight check using a different algorithm
 // that's easier to reason about with constrained wilds
-func checkStraightSimple(nonWilds deck.Hand, assignment []WildAssignment, size int, isStraightFlush bool, suit deck.Suit, aceValue int) int {
+func checkStraightSimple(nonWilds deck.Hand, assignment []WildAssignment, size int, isStraightFlush bool, suit deck.Suit, aceValue int, rules Rules) int {
+	lowAce := rules.lowAceRank()
+
 	// Build a set of filled ranks
 	filledRanks := make(map[int]bool)
 
@@ -149,7 +151,7 @@ func checkStraightSimple(nonWilds deck.Hand, assignment []WildAssignment, size i
 		rank := card.Rank
 		if rank == deck.Ace && aceValue == deck.LowAce {
 			// For low ace check, add both positions if ace
-			filledRanks[deck.LowAce] = true
+			filledRanks[lowAce] = true
 		} else if rank == deck.Ace {
 			filledRanks[deck.Ace] = true
 		} else {
@@ -175,7 +177,7 @@ func checkStraightSimple(nonWilds deck.Hand, assignment []WildAssignment, size i
 			// Suit-mode: locked to their rank position
 			rank := wa.Card.Rank
 			if rank == deck.Ace && aceValue == deck.LowAce {
-				lockedWildRanks[deck.LowAce] = true
+				lockedWildRanks[lowAce] = true
 			} else if rank == deck.Ace {
 				lockedWildRanks[deck.Ace] = true
 			} else {
@@ -193,12 +195,12 @@ func checkStraightSimple(nonWilds deck.Hand, assignment []WildAssignment, size i
 	// Check all possible starting points for a straight of `size`
 	bestHigh := 0
 
-	// For ace-low, check 1-5, for ace-high check 10-14, etc.
-	minStart := 2
+	// For ace-low, check 1-5 (or 5-9 in a short deck), for ace-high check 10-14, etc.
+	minStart := rules.lowestRank()
 	maxStart := deck.Ace - size + 1
 	if aceValue == deck.LowAce {
-		minStart = deck.LowAce
-		maxStart = 5 - size + 1 // Only check A-2-3-4-5 type straights
+		minStart = lowAce
+		maxStart = lowAce + 5 - size // Only check A-2-3-4-5 type straights
 	}
 
 	for start := maxStart; start >= minStart; start-- {
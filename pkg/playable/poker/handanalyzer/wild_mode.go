@@ -12,12 +12,17 @@ const (
 	RankMode WildMode = iota
 	// SuitMode means the wild keeps its original rank but can represent any suit
 	SuitMode
+	// BugMode means the card is the bug, which can represent an ace, or any card that completes a straight or flush
+	BugMode
 )
 
 // WildAssignment pairs a wild card with its mode
 type WildAssignment struct {
 	Card *deck.Card
 	Mode WildMode
+
+	// As is the card the bug represents, only set in BugMode
+	As *deck.Card
 }
 
 // generateWildCombinations generates all 2^n mode combinations for n wild cards
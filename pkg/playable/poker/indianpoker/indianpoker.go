@@ -0,0 +1,365 @@
+package indianpoker
+
+import (
+	"errors"
+	"fmt"
+	"github.com/sirupsen/logrus"
+	"mondaynightpoker-server/pkg/deck"
+	"mondaynightpoker-server/pkg/playable"
+	"mondaynightpoker-server/pkg/playable/poker/action"
+	"mondaynightpoker-server/pkg/playable/poker/potmanager"
+	"strings"
+	"time"
+)
+
+const maxParticipants = 10
+const minCards = 1
+const maxCards = 3
+
+type round int
+
+const (
+	roundBetting round = iota
+	roundRevealWinner
+)
+
+// seed of -1 means truly crypto-random shuffle
+// setting to a global so we can override in a test
+var seed int64 = -1
+
+// Game represents an individual game of Indian Poker
+// Each participant can see every other participant's cards, but not their own
+type Game struct {
+	playerIDs       []int64
+	idToParticipant map[int64]*Participant
+	options         Options
+	logger          logrus.FieldLogger
+	logChan         chan []*playable.LogMessage
+	deck            *deck.Deck
+	potManager      *potmanager.PotManager
+	round           round
+
+	done    bool
+	winners map[*Participant]int
+
+	endGameAt time.Time
+}
+
+// NewGame returns a new instance of the game
+func NewGame(logger logrus.FieldLogger, players []playable.Player, options Options) (*Game, error) {
+	if options.Ante <= 0 {
+		return nil, errors.New("ante must be greater than zero")
+	}
+
+	if options.Cards < minCards || options.Cards > maxCards {
+		return nil, fmt.Errorf("the number of cards must be between %d and %d", minCards, maxCards)
+	}
+
+	if len(players) < 2 {
+		return nil, errors.New("you must have at least two participants")
+	}
+
+	if len(players) > maxParticipants {
+		return nil, fmt.Errorf("you cannot have more than %d participants", maxParticipants)
+	}
+
+	d := deck.New()
+	d.SetSeed(seed)
+	d.Shuffle()
+
+	pm := potmanager.New(options.Ante)
+
+	idToParticipant := make(map[int64]*Participant)
+	playerIDs := make([]int64, len(players))
+	for i, player := range players {
+		playerIDs[i] = player.GetPlayerID()
+		participant := newParticipant(player.GetPlayerID(), player.GetTableStake())
+		idToParticipant[player.GetPlayerID()] = participant
+
+		if err := pm.SeatParticipant(participant); err != nil {
+			return nil, err
+		}
+	}
+	pm.FinishSeatingParticipants()
+
+	g := &Game{
+		options:         options,
+		playerIDs:       playerIDs,
+		idToParticipant: idToParticipant,
+		deck:            d,
+		potManager:      pm,
+		logChan:         make(chan []*playable.LogMessage, 256),
+		logger:          logger,
+	}
+
+	g.logChan <- playable.SimpleLogMessageSlice(0, "New game of %s started (ante: ${%d})", g.Name(), g.options.Ante)
+
+	return g, nil
+}
+
+// DealCards will deal the cards to each participant
+func (g *Game) DealCards() error {
+	for i := 0; i < g.options.Cards; i++ {
+		for _, id := range g.playerIDs {
+			card, err := g.deck.Draw()
+			if err != nil {
+				return err
+			}
+
+			g.idToParticipant[id].hand.AddCard(card)
+		}
+	}
+
+	return nil
+}
+
+// GetCurrentTurn returns the current participant who needs to make a decision
+func (g *Game) GetCurrentTurn() *Participant {
+	p, err := g.potManager.GetInTurnParticipant()
+	if err != nil {
+		return nil
+	}
+
+	return g.idToParticipant[p.ID()]
+}
+
+// IsRoundOver returns true if all participants have had a turn
+func (g *Game) IsRoundOver() bool {
+	return g.potManager.IsRoundOver()
+}
+
+// IsGameOver returns true if the game is over
+func (g *Game) IsGameOver() bool {
+	return g.winners != nil
+}
+
+// CanRevealCards returns true if every participant can see their own cards
+func (g *Game) CanRevealCards() bool {
+	return g.round >= roundRevealWinner
+}
+
+// NextRound will advance the game to the next round
+func (g *Game) NextRound() error {
+	if !g.IsRoundOver() {
+		return errors.New("round is not over")
+	}
+
+	if g.round == roundRevealWinner {
+		return errors.New("cannot advance the round")
+	}
+
+	g.round++
+
+	if err := g.reset(); err != nil {
+		return err
+	}
+
+	if g.round == roundRevealWinner {
+		return g.endGame()
+	}
+
+	return nil
+}
+
+// ParticipantBets handles both bets and raises
+func (g *Game) ParticipantBets(p *Participant, bet int) error {
+	term := strings.ToLower(string(action.Bet))
+
+	currentBet := g.potManager.GetBet()
+	if currentBet > 0 {
+		term = strings.ToLower(string(action.Raise))
+	}
+
+	if maxBet := g.potManager.GetPotLimitMaxBet(); bet > maxBet {
+		return fmt.Errorf("your %s (${%d}) must not exceed the pot limit (${%d})", term, bet, maxBet)
+	}
+
+	allInAmount := g.potManager.GetParticipantAllInAmount(p)
+
+	// only check the following logic IF the participant is not going all-in
+	if bet != allInAmount {
+		if bet%25 > 0 {
+			return fmt.Errorf("your %s must be in multiples of ${25}", term)
+		}
+
+		if bet < g.options.Ante {
+			return fmt.Errorf("your %s must at least match the ante (${%d})", term, g.options.Ante)
+		}
+
+		minRaiseTo := currentBet + g.potManager.GetRaise()
+		if currentBet > 0 && bet < minRaiseTo {
+			return fmt.Errorf("your raise of ${%d} must be at least equal to double the previous raise of ${%d}", bet-currentBet, g.potManager.GetRaise())
+		}
+	}
+
+	return g.potManager.ParticipantBetsOrRaises(p, bet)
+}
+
+// ParticipantChecks will check for the participant as long as there's no active bet
+func (g *Game) ParticipantChecks(p *Participant) error {
+	return g.potManager.ParticipantChecks(p)
+}
+
+// ParticipantCalls handles when the player calls the action
+func (g *Game) ParticipantCalls(p *Participant) error {
+	return g.potManager.ParticipantCalls(p)
+}
+
+// ParticipantFolds handles when a player folds their hand
+func (g *Game) ParticipantFolds(p *Participant) error {
+	if err := g.potManager.ParticipantFolds(p); err != nil {
+		return err
+	}
+
+	p.didFold = true
+
+	stillAlive := 0
+	for _, p := range g.idToParticipant {
+		if !p.didFold {
+			stillAlive++
+		}
+	}
+
+	if stillAlive == 0 {
+		panic("too many players folded")
+	} else if stillAlive == 1 {
+		return g.endGame()
+	}
+
+	return nil
+}
+
+// reset should be called when we enter a new round
+func (g *Game) reset() error {
+	if err := g.potManager.NextRound(); err != nil {
+		return err
+	}
+
+	for _, p := range g.idToParticipant {
+		p.reset()
+	}
+
+	return nil
+}
+
+// canSeeHand returns true if the viewer is allowed to see the participant's cards
+// A participant can see everybody's cards except their own. Once a participant folds, they may look at their own
+// cards. Anybody who is not in the game cannot see any cards until the winner is revealed, so they cannot tip off a
+// participant.
+func (g *Game) canSeeHand(viewerID int64, p *Participant) bool {
+	if g.CanRevealCards() {
+		return true
+	}
+
+	viewer, ok := g.idToParticipant[viewerID]
+	if !ok {
+		return false
+	}
+
+	if viewer == p {
+		return p.didFold
+	}
+
+	return true
+}
+
+func (g *Game) getFutureActionsForPlayer(playerID int64) []action.Action {
+	if p, ok := g.idToParticipant[playerID]; !ok {
+		return nil
+	} else if !g.potManager.IsParticipantYetToAct(p) {
+		return nil
+	}
+
+	if g.potManager.GetBet() == 0 {
+		return []action.Action{action.Check, action.Fold}
+	}
+
+	return []action.Action{action.Call, action.Fold}
+}
+
+func (g *Game) getActionsForPlayer(playerID int64) []action.Action {
+	p, ok := g.idToParticipant[playerID]
+	if !ok {
+		// viewer
+		return nil
+	}
+
+	actions := make([]action.Action, 0)
+	if p == g.GetCurrentTurn() {
+		if bet := g.potManager.GetBet(); bet == 0 {
+			actions = append(actions, action.Check, action.Bet, action.Fold)
+		} else if g.potManager.GetParticipantAllInAmount(p) < bet {
+			actions = append(actions, action.Call, action.Fold)
+		} else {
+			actions = append(actions, action.Call, action.Raise, action.Fold)
+		}
+	}
+
+	return actions
+}
+
+// endGame will handle any end of game actions, calculate winners, etc.
+func (g *Game) endGame() error {
+	if g.winners != nil {
+		panic("endGame already called")
+	}
+
+	g.potManager.EndGame()
+
+	wm := potmanager.NewWinManager()
+	for _, id := range g.playerIDs {
+		p := g.idToParticipant[id]
+		if p.didFold {
+			continue
+		}
+
+		wm.AddParticipant(p, p.getHandAnalyzer().GetStrength())
+	}
+
+	winners := make(map[*Participant]int)
+	payouts, err := g.potManager.PayWinners(wm.GetSortedTiers())
+	if err != nil {
+		return err
+	}
+
+	for participant, amount := range payouts {
+		p := g.idToParticipant[participant.ID()]
+		winners[p] = amount
+	}
+	g.winners = winners
+
+	g.round = roundRevealWinner
+	g.sendEndOfGameLogMessages()
+
+	return nil
+}
+
+func (g *Game) sendEndOfGameLogMessages() {
+	lms := make([]*playable.LogMessage, 0, len(g.idToParticipant))
+	for _, playerID := range g.playerIDs {
+		p := g.idToParticipant[playerID]
+		msg := playable.SimpleLogMessage(p.PlayerID, "")
+		msg.Cards = p.hand
+
+		if amount, ok := g.winners[p]; ok {
+			msg.Message = fmt.Sprintf("{} had a %s and won ${%d} (${%d})", p.handRank(), amount, p.balance)
+		} else if p.didFold {
+			msg.Message = fmt.Sprintf("{} folded and lost ${%d}", -1*p.balance)
+		} else {
+			msg.Message = fmt.Sprintf("{} had a %s and lost ${%d}", p.handRank(), -1*p.balance)
+		}
+
+		lms = append(lms, msg)
+	}
+
+	g.logChan <- lms
+}
+
+// NameFromOptions returns the name for the given options
+func NameFromOptions(options Options) string {
+	if options.Cards <= 1 {
+		return "Indian Poker"
+	}
+
+	return fmt.Sprintf("%d-Card Indian Poker", options.Cards)
+}
@@ -0,0 +1,93 @@
+package indianpoker
+
+import (
+	"github.com/stretchr/testify/assert"
+	"mondaynightpoker-server/pkg/deck"
+	"mondaynightpoker-server/pkg/playable"
+	"mondaynightpoker-server/pkg/playable/poker/action"
+	"testing"
+)
+
+func TestGame_GetPlayerState(t *testing.T) {
+	a := assert.New(t)
+	game := mustNewGame(DefaultOptions(), 1000, 1000, 1000)
+	a.NoError(game.DealCards())
+	setHands(game, "13s", "14h", "2c")
+
+	getState := func(playerID int64) *State {
+		t.Helper()
+		resp, err := game.GetPlayerState(playerID)
+		a.NoError(err)
+		a.Equal("indian-poker", resp.Value)
+		return resp.Data.(*State)
+	}
+
+	// a participant cannot see their own card, but can see everybody else's
+	state := getState(1)
+	a.Equal(deck.Hand{nil}, state.Participant.Hand)
+	a.Empty(state.Participant.HandRank)
+	a.Equal(deck.Hand{nil}, state.GameState.Participants[0].Hand)
+	a.Equal("14h", deck.CardsToString(state.GameState.Participants[1].Hand))
+	a.Equal("2c", deck.CardsToString(state.GameState.Participants[2].Hand))
+	a.Equal([]action.Action{action.Check, action.Bet, action.Fold}, state.Actions)
+
+	state = getState(2)
+	a.Equal("13s", deck.CardsToString(state.GameState.Participants[0].Hand))
+	a.Equal(deck.Hand{nil}, state.GameState.Participants[1].Hand)
+	a.Equal([]action.Action{action.Check, action.Fold}, state.FutureActions)
+
+	// somebody not in the game cannot see any cards
+	state = getState(4)
+	a.Nil(state.Participant)
+	for _, p := range state.GameState.Participants {
+		a.Equal(deck.Hand{nil}, p.Hand)
+	}
+
+	// once a participant folds, they may look at their own card
+	a.NoError(game.ParticipantFolds(game.idToParticipant[1]))
+	state = getState(1)
+	a.Equal("13s", deck.CardsToString(state.Participant.Hand))
+
+	// after the game, everybody sees everything
+	a.NoError(game.ParticipantChecks(game.idToParticipant[2]))
+	a.NoError(game.ParticipantChecks(game.idToParticipant[3]))
+	a.NoError(game.NextRound())
+	state = getState(4)
+	a.Equal("13s", deck.CardsToString(state.GameState.Participants[0].Hand))
+	a.Equal("14h", deck.CardsToString(state.GameState.Participants[1].Hand))
+	a.Equal(map[int64]int{2: 75}, state.GameState.Winners)
+}
+
+func TestGame_Action(t *testing.T) {
+	a := assert.New(t)
+	game := mustNewGame(DefaultOptions(), 1000, 1000)
+	a.NoError(game.DealCards())
+
+	_, _, err := game.Action(3, &playable.PayloadIn{Action: "check"})
+	a.EqualError(err, "participant is not in the game")
+
+	_, _, err = game.Action(1, &playable.PayloadIn{Action: "trade"})
+	a.EqualError(err, "unknown action: trade")
+
+	resp, update, err := game.Action(1, &playable.PayloadIn{Action: "bet", AdditionalData: playable.AdditionalData{"amount": float64(50)}})
+	a.NoError(err)
+	a.True(update)
+	a.Equal(playable.OK(), resp)
+
+	_, _, err = game.Action(2, &playable.PayloadIn{Action: "call"})
+	a.NoError(err)
+
+	update, err = game.Tick()
+	a.NoError(err)
+	a.True(update)
+	a.True(game.IsGameOver())
+
+	details, isOver := game.GetEndOfGameDetails()
+	a.False(isOver)
+	a.Nil(details)
+
+	game.done = true
+	details, isOver = game.GetEndOfGameDetails()
+	a.True(isOver)
+	a.Equal(0, details.BalanceAdjustments[1]+details.BalanceAdjustments[2])
+}
@@ -0,0 +1,149 @@
+package indianpoker
+
+import (
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"mondaynightpoker-server/pkg/deck"
+	"mondaynightpoker-server/pkg/playable"
+	"testing"
+)
+
+type testParticipant struct {
+	playerID   int64
+	tableStake int
+}
+
+func (t *testParticipant) GetPlayerID() int64 {
+	return t.playerID
+}
+
+func (t *testParticipant) GetTableStake() int {
+	return t.tableStake
+}
+
+func newParticipants(tableStakes ...int) []playable.Player {
+	p := make([]playable.Player, len(tableStakes))
+	for i, tableStake := range tableStakes {
+		p[i] = &testParticipant{
+			playerID:   int64(i + 1),
+			tableStake: tableStake,
+		}
+	}
+
+	return p
+}
+
+func mustNewGame(options Options, tableStakes ...int) *Game {
+	game, err := NewGame(logrus.StandardLogger(), newParticipants(tableStakes...), options)
+	if err != nil {
+		panic(err)
+	}
+
+	return game
+}
+
+func setHands(game *Game, hands ...string) {
+	for i, hand := range hands {
+		game.idToParticipant[int64(i+1)].hand = deck.CardsFromString(hand)
+	}
+}
+
+func TestNameFromOptions(t *testing.T) {
+	a := assert.New(t)
+	a.Equal("Indian Poker", NameFromOptions(DefaultOptions()))
+	a.Equal("3-Card Indian Poker", NameFromOptions(Options{Ante: 25, Cards: 3}))
+}
+
+func TestNewGame(t *testing.T) {
+	a := assert.New(t)
+	participants := newParticipants(50, 50, 50)
+
+	game, err := NewGame(logrus.StandardLogger(), participants, Options{})
+	a.EqualError(err, "ante must be greater than zero")
+	a.Nil(game)
+
+	game, err = NewGame(logrus.StandardLogger(), participants, Options{Ante: 25, Cards: 4})
+	a.EqualError(err, "the number of cards must be between 1 and 3")
+	a.Nil(game)
+
+	game, err = NewGame(logrus.StandardLogger(), newParticipants(50), DefaultOptions())
+	a.EqualError(err, "you must have at least two participants")
+	a.Nil(game)
+
+	game, err = NewGame(logrus.StandardLogger(), make([]playable.Player, maxParticipants+1), DefaultOptions())
+	a.EqualError(err, "you cannot have more than 10 participants")
+	a.Nil(game)
+
+	game, err = NewGame(logrus.StandardLogger(), participants, DefaultOptions())
+	a.NoError(err)
+	a.NotEqual(deck.New().HashCode(), game.deck.HashCode())
+	a.Equal(75, game.potManager.Pots().Total())
+}
+
+func TestGame_DealCards(t *testing.T) {
+	a := assert.New(t)
+	game := mustNewGame(Options{Ante: 25, Cards: 2}, 100, 100)
+	game.deck = deck.New()
+
+	a.NoError(game.DealCards())
+	a.Equal("2c,4c", deck.CardsToString(game.idToParticipant[1].hand))
+	a.Equal("3c,5c", deck.CardsToString(game.idToParticipant[2].hand))
+}
+
+func TestGame_oneCard(t *testing.T) {
+	a := assert.New(t)
+	game := mustNewGame(DefaultOptions(), 1000, 1000, 1000)
+	a.NoError(game.DealCards())
+	setHands(game, "13s", "14h", "2c")
+
+	p := func(id int64) *Participant {
+		return game.idToParticipant[id]
+	}
+
+	a.NoError(game.ParticipantBets(p(1), 50))
+	a.NoError(game.ParticipantCalls(p(2)))
+	a.NoError(game.ParticipantFolds(p(3)))
+	a.NoError(game.NextRound())
+
+	a.True(game.IsGameOver())
+	a.Equal(map[*Participant]int{p(2): 175}, game.winners)
+	a.Equal(-75, p(1).balance)
+	a.Equal(100, p(2).balance)
+	a.Equal(-25, p(3).balance)
+}
+
+func TestGame_tie(t *testing.T) {
+	a := assert.New(t)
+	game := mustNewGame(DefaultOptions(), 1000, 1000)
+	a.NoError(game.DealCards())
+	setHands(game, "13s", "13h")
+
+	a.NoError(game.ParticipantChecks(game.idToParticipant[1]))
+	a.NoError(game.ParticipantChecks(game.idToParticipant[2]))
+	a.NoError(game.NextRound())
+
+	a.True(game.IsGameOver())
+	a.Equal(0, game.idToParticipant[1].balance)
+	a.Equal(0, game.idToParticipant[2].balance)
+}
+
+func TestGame_multipleCards(t *testing.T) {
+	a := assert.New(t)
+
+	game := mustNewGame(Options{Ante: 25, Cards: 2}, 1000, 1000)
+	a.NoError(game.DealCards())
+	setHands(game, "2s,2h", "14s,13s")
+	a.NoError(game.ParticipantChecks(game.idToParticipant[1]))
+	a.NoError(game.ParticipantChecks(game.idToParticipant[2]))
+	a.NoError(game.NextRound())
+	a.Equal(map[*Participant]int{game.idToParticipant[1]: 50}, game.winners)
+
+	// three cards uses three-card poker rules
+	game = mustNewGame(Options{Ante: 25, Cards: 3}, 1000, 1000)
+	a.NoError(game.DealCards())
+	setHands(game, "14s,14h,13c", "2s,3h,4c")
+	a.NoError(game.ParticipantChecks(game.idToParticipant[1]))
+	a.NoError(game.ParticipantChecks(game.idToParticipant[2]))
+	a.NoError(game.NextRound())
+	a.Equal(map[*Participant]int{game.idToParticipant[2]: 50}, game.winners)
+}
@@ -0,0 +1,16 @@
+package indianpoker
+
+// Options provides options for Indian Poker
+type Options struct {
+	Ante int
+	// Cards is how many cards each participant holds on their forehead
+	Cards int
+}
+
+// DefaultOptions returns the default set of options
+func DefaultOptions() Options {
+	return Options{
+		Ante:  25,
+		Cards: 1,
+	}
+}
@@ -0,0 +1,86 @@
+package indianpoker
+
+import (
+	"mondaynightpoker-server/pkg/deck"
+	"mondaynightpoker-server/pkg/playable/poker/handanalyzer"
+)
+
+// Participant represents an individual participant in Indian Poker
+type Participant struct {
+	PlayerID   int64
+	tableStake int
+
+	didFold bool
+	balance int
+	hand    deck.Hand
+
+	// currentBet is how much the player has bet in the current round
+	currentBet int
+
+	handAnalyzer         *handanalyzer.HandAnalyzer
+	handAnalyzerCacheKey string
+}
+
+func newParticipant(id int64, tableStake int) *Participant {
+	return &Participant{
+		PlayerID:   id,
+		tableStake: tableStake,
+		didFold:    false,
+		balance:    0,
+		hand:       make(deck.Hand, 0, 1),
+	}
+}
+
+// reset is called after the round is reset
+func (p *Participant) reset() {
+	p.currentBet = 0
+}
+
+// getHandAnalyzer returns a hand analyzer for the participant's hand
+// One and two card hands are analyzed as a five-card hand so they can only make pairs and high cards. Three card hands
+// follow three-card poker rankings
+func (p *Participant) getHandAnalyzer() *handanalyzer.HandAnalyzer {
+	key := p.hand.String()
+	if p.handAnalyzer == nil || p.handAnalyzerCacheKey != key {
+		size := 5
+		if len(p.hand) == 3 {
+			size = 3
+		}
+
+		p.handAnalyzer = handanalyzer.New(size, p.hand)
+		p.handAnalyzerCacheKey = key
+	}
+
+	return p.handAnalyzer
+}
+
+// handRank returns a description of the participant's hand
+func (p *Participant) handRank() string {
+	if len(p.hand) == 1 {
+		return "High card"
+	}
+
+	return p.getHandAnalyzer().GetHand().String()
+}
+
+// potmanager.Participant implementation
+
+// ID returns the ID
+func (p *Participant) ID() int64 {
+	return p.PlayerID
+}
+
+// Balance returns the current balance
+func (p *Participant) Balance() int {
+	return p.balance + p.tableStake
+}
+
+// AdjustBalance will adjust the participant's balance
+func (p *Participant) AdjustBalance(amount int) {
+	p.balance += amount
+}
+
+// SetAmountInPlay sets the amount currently in play
+func (p *Participant) SetAmountInPlay(amount int) {
+	p.currentBet = amount
+}
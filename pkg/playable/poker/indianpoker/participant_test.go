@@ -0,0 +1,26 @@
+package indianpoker
+
+import (
+	"github.com/stretchr/testify/assert"
+	"mondaynightpoker-server/pkg/deck"
+	"mondaynightpoker-server/pkg/playable/poker/handanalyzer"
+	"testing"
+)
+
+func TestParticipant_getHandAnalyzer(t *testing.T) {
+	a := assert.New(t)
+
+	p := newParticipant(1, 100)
+	p.hand = deck.CardsFromString("14s")
+	a.Equal("High card", p.handRank())
+
+	p.hand = deck.CardsFromString("2s,2h")
+	a.Equal(handanalyzer.OnePair, p.getHandAnalyzer().GetHand())
+	a.Equal("Pair", p.handRank())
+
+	p.hand = deck.CardsFromString("2s,3s")
+	a.Equal(handanalyzer.HighCard, p.getHandAnalyzer().GetHand())
+
+	p.hand = deck.CardsFromString("2s,3h,4s")
+	a.Equal(handanalyzer.ThreeCardPokerStraight, p.getHandAnalyzer().GetHand())
+}
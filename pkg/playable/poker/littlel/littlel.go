@@ -7,6 +7,7 @@ import (
 	"mondaynightpoker-server/pkg/deck"
 	"mondaynightpoker-server/pkg/playable"
 	"mondaynightpoker-server/pkg/playable/poker/action"
+	"mondaynightpoker-server/pkg/playable/poker/handanalyzer"
 	"mondaynightpoker-server/pkg/playable/poker/potmanager"
 	"sort"
 	"strings"
@@ -68,7 +69,10 @@ func NewGameV2(logger logrus.FieldLogger, players []playable.Player, options Opt
 		return nil, fmt.Errorf("you cannot have more than %d participants", maxParticipants)
 	}
 
-	d := deck.New()
+	d, err := deck.NewFromSpec(options.deckSpec())
+	if err != nil {
+		return nil, err
+	}
 	d.SetSeed(seed)
 	d.Shuffle()
 
@@ -441,9 +445,14 @@ func (g *Game) sendEndOfGameLogMessages() {
 	community := g.GetCommunityCards()
 
 	lms := make([]*playable.LogMessage, 0, len(g.idToParticipant))
+	var best *handanalyzer.HandAnalyzer
 	for winner, amount := range g.winners {
-		hand := winner.GetBestHand(community).analyzer.GetHand().String()
-		lms = append(lms, playable.SimpleLogMessage(winner.PlayerID, "{} had a %s and won ${%d} (${%d})", hand, amount, winner.balance))
+		ha := winner.GetBestHand(community).analyzer
+		if best == nil || ha.GetStrength() > best.GetStrength() {
+			best = ha
+		}
+
+		lms = append(lms, playable.SimpleLogMessage(winner.PlayerID, "{} had a %s and won ${%d} (${%d})", ha.Explain().String(), amount, winner.balance))
 	}
 
 	for _, playerID := range g.playerIDs {
@@ -455,8 +464,14 @@ func (g *Game) sendEndOfGameLogMessages() {
 		if p.didFold {
 			lms = append(lms, playable.SimpleLogMessage(p.PlayerID, "{} folded and lost ${%d}", -1*p.balance))
 		} else {
-			hand := p.GetBestHand(community).analyzer.GetHand().String()
-			lms = append(lms, playable.SimpleLogMessage(p.PlayerID, "{} had a %s and lost ${%d}", hand, -1*p.balance))
+			ha := p.GetBestHand(community).analyzer
+			if best == nil {
+				lms = append(lms, playable.SimpleLogMessage(p.PlayerID, "{} had a %s and lost ${%d}", ha.Explain().String(), -1*p.balance))
+				continue
+			}
+
+			_, reason := handanalyzer.Compare(best, ha)
+			lms = append(lms, playable.SimpleLogMessage(p.PlayerID, "{} had a %s and lost ${%d}: %s", ha.Explain().String(), -1*p.balance, reason))
 		}
 	}
 
@@ -470,5 +485,10 @@ func NameFromOptions(options Options) (string, error) {
 		return "", err
 	}
 
-	return fmt.Sprintf("%d-Card Little L (trade: %s)", options.InitialDeal, tradeIns), nil
+	name := fmt.Sprintf("%d-Card Little L (trade: %s)", options.InitialDeal, tradeIns)
+	if options.Joker {
+		name += " with the Bug"
+	}
+
+	return name, nil
 }
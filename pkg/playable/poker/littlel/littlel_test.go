@@ -26,6 +26,17 @@ func (t *testParticipant) GetTableStake() int {
 func TestGame_Name(t *testing.T) {
 	g := &Game{options: DefaultOptions()}
 	assert.Equal(t, "4-Card Little L (trade: 0, 2)", g.Name())
+
+	g.options.Joker = true
+	assert.Equal(t, "4-Card Little L (trade: 0, 2) with the Bug", g.Name())
+}
+
+func TestNewGameV2_joker(t *testing.T) {
+	opts := DefaultOptions()
+	opts.Joker = true
+	game := mustNewGame(opts, 100, 100)
+	assert.Equal(t, 53, len(game.deck.Cards))
+	assert.Equal(t, deck.StandardSpec().WithJokers(1), game.deck.Spec())
 }
 
 func newGame(options Options, tableStakes ...int) (*Game, error) {
@@ -555,7 +566,7 @@ ForLoop:
 	assert.Equal(t, []int64{1}, msg[1].PlayerIDs)
 	assert.Equal(t, "{} folded and lost ${25}", msg[1].Message)
 	assert.Equal(t, []int64{3}, msg[2].PlayerIDs)
-	assert.Equal(t, "{} had a Three of a kind and lost ${25}", msg[2].Message)
+	assert.Equal(t, "{} had a Three of a kind (twos) and lost ${25}: Royal flush beats Three of a kind", msg[2].Message)
 }
 
 func TestGame_getFutureActionsForPlayer(t *testing.T) {
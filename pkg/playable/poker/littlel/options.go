@@ -1,5 +1,7 @@
 package littlel
 
+import "mondaynightpoker-server/pkg/deck"
+
 // Options provides options for the Little L game
 type Options struct {
 	Ante int
@@ -7,6 +9,8 @@ type Options struct {
 	InitialDeal int
 	// TradeIns is how many cards the player may trade-in
 	TradeIns []int
+	// Joker adds a joker to the deck that plays as the bug
+	Joker bool
 }
 
 // DefaultOptions returns the default set of options
@@ -17,3 +21,12 @@ func DefaultOptions() Options {
 		TradeIns:    []int{0, 2},
 	}
 }
+
+// deckSpec returns the composition of the deck
+func (o Options) deckSpec() deck.Spec {
+	if o.Joker {
+		return deck.StandardSpec().WithJokers(1)
+	}
+
+	return deck.StandardSpec()
+}
@@ -13,4 +13,6 @@ type State struct {
 	MaxBet     int             `json:"maxBet"`
 	Pots       potmanager.Pots `json:"pots"`
 	Community  deck.Hand       `json:"community"`
+	// Boards contains every community board in a multi-board game
+	Boards []deck.Hand `json:"boards,omitempty"`
 }
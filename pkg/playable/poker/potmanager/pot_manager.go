@@ -100,6 +100,12 @@ func (p *PotManager) FinishSeatingParticipants() {
 	p.reset()
 }
 
+// AddDeadMoney adds money to the main pot that does not belong to any seated participant's bet
+// This is useful when a pot carries over into a new deal. It must be called after FinishSeatingParticipants
+func (p *PotManager) AddDeadMoney(amount int) {
+	p.pots[0].amount += amount
+}
+
 // ParticipantFolds handles a fold
 func (p *PotManager) ParticipantFolds(pt Participant) error {
 	if p.isInDecisionRound {
@@ -184,6 +190,34 @@ func (p *PotManager) PayBlinds(sbAmt, bbAmt int) (smallBlind Participant, bigBli
 	return sbPip, bbPip
 }
 
+// PayBlindsAndStraddle pays the blinds and a straddle by the participant after the big blind
+// The straddle acts as a third blind, so the straddler is the last to act in the round
+// A straddle requires at least three participants
+func (p *PotManager) PayBlindsAndStraddle(sbAmt, bbAmt, straddleAmt int) (smallBlind Participant, bigBlind Participant, straddle Participant) {
+	if len(p.tableOrder) < 3 {
+		panic("a straddle requires at least three participants")
+	}
+
+	if straddleAmt < bbAmt {
+		panic(fmt.Sprintf("straddle (%d) must be more than big blind (%d)", straddleAmt, bbAmt))
+	}
+
+	sbPip, bbPip := p.PayBlinds(sbAmt, bbAmt)
+	straddlePip := p.tableOrder[2]
+
+	p.actionStartIndex = 3 % len(p.tableOrder)
+	p.adjustParticipant(straddlePip, straddleAmt)
+
+	// a short straddle is all-in and only raises the bet by what was posted
+	p.actionAmount = straddlePip.amountInPlay
+	if p.actionAmount < bbAmt {
+		p.actionAmount = bbAmt
+	}
+	p.actionDiffAmount = p.actionAmount
+
+	return sbPip, bbPip, straddlePip
+}
+
 // ParticipantBetsOrRaises will place a bet or a raise for a participant
 // This method only enforces that the bet or raise is above the previous bet or raise. Any additional logic
 // must be handled by the game.
@@ -376,22 +410,55 @@ func (p *PotManager) GetTotalOnTable() int {
 
 // PayWinners will adjust balance for the winners and return the final payouts
 func (p *PotManager) PayWinners(winners [][]Participant) (map[Participant]int, error) {
+	return p.PaySplitWinners([][][]Participant{winners})
+}
+
+// PaySplitWinners will split every pot evenly between each set of winners (i.e., one per board in a double-board
+// game), then pay each share as PayWinners would. Any uneven amount goes to the first set of winners
+func (p *PotManager) PaySplitWinners(splitWinners [][][]Participant) (map[Participant]int, error) {
 	if !p.isGameOver {
 		return nil, errors.New("game is not over")
 	}
 
+	if len(splitWinners) == 0 {
+		return nil, errors.New("there must be at least one set of winners")
+	}
+
 	p.calculatePot()
 
-	pots := make([]*pot, len(p.pots))
+	payouts := make(map[Participant]int)
+	for i, winners := range splitWinners {
+		pots := make([]*pot, len(p.pots))
+
+		// shallow-copy
+		for j, pot := range p.pots {
+			tmp := *pot
+			tmp.amount = splitPotAmount(pot.amount, len(splitWinners), i)
+			pots[j] = &tmp
+		}
 
-	// shallow-copy
-	for i, pot := range p.pots {
-		tmp := *pot
-		pots[i] = &tmp
+		p.payWinnersFromPots(pots, winners, payouts)
 	}
 
-	payouts := make(map[Participant]int)
+	return payouts, nil
+}
 
+// splitPotAmount returns the share of amount for the index-th of n splits
+func splitPotAmount(amount, n, index int) int {
+	chips := amount / 25
+	share := (chips / n) * 25
+	if index < chips%n {
+		share += 25
+	}
+
+	if index == 0 {
+		share += amount % 25
+	}
+
+	return share
+}
+
+func (p *PotManager) payWinnersFromPots(pots []*pot, winners [][]Participant, payouts map[Participant]int) {
 MainLoop:
 	for _, winnerGroup := range winners {
 		// convert to list of participantInPot objects. Sort by the table order
@@ -435,8 +502,6 @@ MainLoop:
 			}
 		}
 	}
-
-	return payouts, nil
 }
 
 // completeTurn must be called after a participant bets, raises, checks, calls, or folds
@@ -655,6 +655,85 @@ func TestPotManager_PayBlinds(t *testing.T) {
 	}
 }
 
+func TestPotManager_PayBlindsAndStraddle(t *testing.T) {
+	a := assert.New(t)
+
+	// four player test
+	{
+		pm := setupPotManager(0, 500, 500, 500, 500)
+
+		sb, bb, straddle := pm.PayBlindsAndStraddle(25, 50, 100)
+		a.Equal(pm.tableOrder[0], sb)
+		a.Equal(pm.tableOrder[1], bb)
+		a.Equal(pm.tableOrder[2], straddle)
+		a.Equal(400, pm.tableOrder[2].Balance())
+		a.Equal(175, pm.GetTotalOnTable())
+		a.Equal(100, pm.GetBet())
+		a.Equal(100, pm.GetRaise())
+
+		// the action starts after the straddle
+		turn, err := pm.GetInTurnParticipant()
+		a.NoError(err)
+		a.Equal(pm.tableOrder[3].Participant, turn)
+		a.NoError(pm.ParticipantCalls(pm.tableOrder[3]))
+		a.NoError(pm.ParticipantCalls(pm.tableOrder[0]))
+		a.NoError(pm.ParticipantCalls(pm.tableOrder[1]))
+
+		// the straddler acts last
+		turn, err = pm.GetInTurnParticipant()
+		a.NoError(err)
+		a.Equal(pm.tableOrder[2].Participant, turn)
+		a.NoError(pm.ParticipantChecks(pm.tableOrder[2]))
+		a.True(pm.IsRoundOver())
+	}
+
+	// a short-stacked straddler is all-in for less than the straddle
+	{
+		pm := setupPotManager(0, 500, 500, 75, 500)
+
+		pm.PayBlindsAndStraddle(25, 50, 100)
+		a.Equal(0, pm.tableOrder[2].Balance())
+		a.True(pm.tableOrder[2].isAllIn)
+		a.Equal(150, pm.GetTotalOnTable())
+		a.Equal(75, pm.GetBet())
+		a.Equal(75, pm.GetRaise())
+	}
+
+	// a straddle short of the big blind leaves the bet at the big blind
+	{
+		pm := setupPotManager(0, 500, 500, 30, 500)
+
+		pm.PayBlindsAndStraddle(25, 50, 100)
+		a.Equal(0, pm.tableOrder[2].Balance())
+		a.Equal(105, pm.GetTotalOnTable())
+		a.Equal(50, pm.GetBet())
+		a.Equal(50, pm.GetRaise())
+	}
+
+	// three player test, the button straddles
+	{
+		pm := setupPotManager(0, 500, 500, 500)
+
+		_, _, straddle := pm.PayBlindsAndStraddle(25, 50, 100)
+		a.Equal(pm.tableOrder[2], straddle)
+
+		a.NoError(pm.ParticipantCalls(pm.tableOrder[0]))
+		a.NoError(pm.ParticipantBetsOrRaises(pm.tableOrder[1], 200))
+		a.NoError(pm.ParticipantCalls(pm.tableOrder[2]))
+		a.NoError(pm.ParticipantCalls(pm.tableOrder[0]))
+		a.True(pm.IsRoundOver())
+		a.Equal(600, pm.GetTotalOnTable())
+	}
+
+	a.PanicsWithValue("a straddle requires at least three participants", func() {
+		setupPotManager(0, 500, 500).PayBlindsAndStraddle(25, 50, 100)
+	})
+
+	a.PanicsWithValue("straddle (25) must be more than big blind (50)", func() {
+		setupPotManager(0, 500, 500, 500).PayBlindsAndStraddle(25, 50, 25)
+	})
+}
+
 func setupPotManager(ante int, balances ...int) *PotManager {
 	pm := New(ante)
 	for i, balance := range balances {
@@ -666,3 +745,64 @@ func setupPotManager(ante int, balances ...int) *PotManager {
 	pm.FinishSeatingParticipants()
 	return pm
 }
+
+func TestPotManager_AddDeadMoney(t *testing.T) {
+	a := assert.New(t)
+
+	p1 := newTestParticipant(1, 100)
+	p2 := newTestParticipant(2, 25)
+	p3 := newTestParticipant(3, 100)
+
+	pm := New(50)
+	a.NoError(pm.SeatParticipant(p1))
+	a.NoError(pm.SeatParticipant(p2))
+	a.NoError(pm.SeatParticipant(p3))
+	pm.FinishSeatingParticipants()
+	pm.AddDeadMoney(150)
+
+	a.Equal(Pots{
+		{Amount: 225, AllInParticipants: []Participant{p2}},
+		{Amount: 50, AllInParticipants: []Participant{}},
+	}, pm.Pots())
+
+	pm.EndGame()
+	payouts, err := pm.PayWinners([][]Participant{{p2}, {p1, p3}})
+	a.NoError(err)
+	a.Equal(map[Participant]int{p1: 25, p2: 225, p3: 25}, payouts)
+}
+
+func TestPotManager_PaySplitWinners(t *testing.T) {
+	a := assert.New(t)
+
+	pm := setupPotManager(50, 25, 50, 50) // main pot of 75, side pot of 50
+	pm.EndGame()
+
+	_, err := pm.PaySplitWinners(nil)
+	a.EqualError(err, "there must be at least one set of winners")
+
+	payouts, err := pm.PaySplitWinners([][][]Participant{
+		{
+			{pm.tableOrder[0].Participant}, // only wins half of the main pot
+			{pm.tableOrder[1].Participant}, // wins half of the side pot
+		},
+		{
+			{pm.tableOrder[2].Participant},
+		},
+	})
+	a.NoError(err)
+
+	a.Equal(map[Participant]int{
+		pm.tableOrder[0].Participant: 50,
+		pm.tableOrder[1].Participant: 25,
+		pm.tableOrder[2].Participant: 50,
+	}, payouts)
+}
+
+func Test_splitPotAmount(t *testing.T) {
+	a := assert.New(t)
+	a.Equal(100, splitPotAmount(100, 1, 0))
+	a.Equal(50, splitPotAmount(75, 2, 0))
+	a.Equal(25, splitPotAmount(75, 2, 1))
+	a.Equal(60, splitPotAmount(110, 2, 0))
+	a.Equal(50, splitPotAmount(110, 2, 1))
+}
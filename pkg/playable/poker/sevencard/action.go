@@ -33,6 +33,9 @@ const (
 const (
 	ActionFlipMushroom Action = "flip-mushroom"
 	ActionPlayAntidote Action = "play-antidote"
+	ActionPassCards    Action = "pass-cards"
+	ActionRollCard     Action = "roll-card"
+	ActionFlipCard     Action = "flip-card"
 )
 
 var allowedActions = map[Action]bool{
@@ -43,6 +46,9 @@ var allowedActions = map[Action]bool{
 	ActionCall:         true,
 	ActionFlipMushroom: true,
 	ActionPlayAntidote: true,
+	ActionPassCards:    true,
+	ActionRollCard:     true,
+	ActionFlipCard:     true,
 }
 
 func (a Action) String() string {
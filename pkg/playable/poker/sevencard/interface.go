@@ -28,7 +28,8 @@ type InteractiveVariant interface {
 	// GetVariantActions returns additional actions available to the player based on variant rules
 	GetVariantActions(game *Game, p *participant) []Action
 	// HandleVariantAction handles a variant-specific action. Returns true if the action was handled.
-	HandleVariantAction(game *Game, p *participant, action Action) (handled bool, err error)
+	// cards contains any cards the player selected with the action
+	HandleVariantAction(game *Game, p *participant, action Action, cards []*deck.Card) (handled bool, err error)
 	// IsVariantPhasePending returns true if the variant is waiting for player actions before continuing
 	IsVariantPhasePending() bool
 	// GetVariantState returns variant-specific state to be included in the game state
@@ -40,3 +41,19 @@ type BetAwareVariant interface {
 	// OnBetPlaced is called when any player places a bet or raise
 	OnBetPlaced(game *Game)
 }
+
+// RoundVariant is a variant that replaces the standard street-by-street deal with its own sequence of rounds
+// The variant is responsible for calling determineFirstToAct once a round is ready for betting
+type RoundVariant interface {
+	// Deal is called in place of the standard opening deal
+	Deal(game *Game) error
+	// NextRound is called after each betting round is complete. Returns true once there are no rounds left
+	// and the winner should be revealed
+	NextRound(game *Game) (isOver bool, err error)
+}
+
+// BlindVariant is a variant where participants cannot look at their own face-down cards
+type BlindVariant interface {
+	// IsBlind returns true if participants must not see their own face-down cards
+	IsBlind() bool
+}
@@ -1,11 +1,17 @@
 package sevencard
 
-import "errors"
+import (
+	"errors"
+	"mondaynightpoker-server/pkg/deck"
+)
 
 // Options contains the various options for starting a new seven-card poker game
 type Options struct {
 	Ante    int
 	Variant Variant
+
+	// Joker adds a joker to the deck that plays as the bug
+	Joker bool
 }
 
 // DefaultOptions returns a default set of options for seven-card poker
@@ -32,3 +38,21 @@ func (o *Options) Validate() error {
 
 	return nil
 }
+
+// Name returns the name of the game
+func (o *Options) Name() string {
+	if o.Joker {
+		return o.Variant.Name() + " with the Bug"
+	}
+
+	return o.Variant.Name()
+}
+
+// deckSpec returns the composition of the deck
+func (o *Options) deckSpec() deck.Spec {
+	if o.Joker {
+		return deck.StandardSpec().WithJokers(1)
+	}
+
+	return deck.StandardSpec()
+}
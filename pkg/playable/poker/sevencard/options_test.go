@@ -2,6 +2,7 @@ package sevencard
 
 import (
 	"github.com/stretchr/testify/assert"
+	"mondaynightpoker-server/pkg/deck"
 	"testing"
 )
 
@@ -23,3 +24,15 @@ func TestOptions_Validate(t *testing.T) {
 	o.Variant = &Stud{}
 	a.NoError(o.Validate())
 }
+
+func TestOptions_joker(t *testing.T) {
+	a := assert.New(t)
+
+	o := DefaultOptions()
+	a.Equal("Seven-Card Stud", o.Name())
+	a.Equal(deck.StandardSpec(), o.deckSpec())
+
+	o.Joker = true
+	a.Equal("Seven-Card Stud with the Bug", o.Name())
+	a.Equal(53, o.deckSpec().Size())
+}
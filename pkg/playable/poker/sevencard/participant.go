@@ -56,3 +56,15 @@ func (p *participant) getHandAnalyzer() *handanalyzer.HandAnalyzer {
 
 	return p.handAnalyzer
 }
+
+// faceUpCards returns the cards every player can see
+func (p *participant) faceUpCards() deck.Hand {
+	hand := make(deck.Hand, 0, len(p.hand))
+	for _, card := range p.hand {
+		if card.IsBitSet(faceUp) && !card.IsBitSet(wasDiscarded) {
+			hand = append(hand, card)
+		}
+	}
+
+	return hand
+}
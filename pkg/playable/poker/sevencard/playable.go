@@ -7,7 +7,7 @@ import (
 
 // Name returns the name of the game
 func (g *Game) Name() string {
-	return g.options.Variant.Name()
+	return g.options.Name()
 }
 
 // Action performs a game action on behalf of the player
@@ -19,7 +19,7 @@ func (g *Game) Action(playerID int64, message *playable.PayloadIn) (playerRespon
 
 	// Check for variant-specific actions first
 	if iv, ok := g.options.Variant.(InteractiveVariant); ok {
-		handled, err := iv.HandleVariantAction(g, p, Action(message.Action))
+		handled, err := iv.HandleVariantAction(g, p, Action(message.Action), message.Cards)
 		if err != nil {
 			return nil, false, err
 		}
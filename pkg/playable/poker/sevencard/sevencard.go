@@ -86,7 +86,10 @@ func NewGameV2(logger logrus.FieldLogger, players []playable.Player, options Opt
 		return nil, fmt.Errorf("seven-card allows at most %d participants", maxParticipants)
 	}
 
-	d := deck.New()
+	d, err := deck.NewFromSpec(options.deckSpec())
+	if err != nil {
+		return nil, err
+	}
 	d.Shuffle()
 
 	options.Variant.Start()
@@ -123,16 +126,23 @@ func (g *Game) Start() error {
 		return errors.New("the game has already started")
 	}
 
-	// deal two face-down, one face-up
-	for _, faceDown := range []bool{true, true, false} {
-		if err := g.dealCards(faceDown); err != nil {
+	g.pendingLogs = append(g.pendingLogs, playable.SimpleLogMessage(0, "New game of %s started (ante: ${%d})", g.Name(), g.options.Ante))
+
+	if rv, ok := g.options.Variant.(RoundVariant); ok {
+		if err := rv.Deal(g); err != nil {
 			return err
 		}
-	}
+	} else {
+		// deal two face-down, one face-up
+		for _, faceDown := range []bool{true, true, false} {
+			if err := g.dealCards(faceDown); err != nil {
+				return err
+			}
+		}
 
-	g.pendingLogs = append(g.pendingLogs, playable.SimpleLogMessage(0, "New game of %s started (ante: ${%d})", g.Name(), g.options.Ante))
+		g.determineFirstToAct()
+	}
 
-	g.determineFirstToAct()
 	g.round++
 
 	g.logChan <- g.pendingLogs
@@ -142,13 +152,19 @@ func (g *Game) Start() error {
 }
 
 func (g *Game) nextRound() {
-	g.round++
 	g.currentBet = 0
 
 	for _, p := range g.idToParticipant {
 		p.resetForNewRound()
 	}
 
+	if rv, ok := g.options.Variant.(RoundVariant); ok {
+		g.nextVariantRound(rv)
+		return
+	}
+
+	g.round++
+
 	var cardName string
 	var err error
 	switch g.round {
@@ -182,6 +198,25 @@ func (g *Game) nextRound() {
 	g.determineFirstToAct()
 }
 
+// nextVariantRound lets a RoundVariant advance the game instead of dealing the next street
+func (g *Game) nextVariantRound(rv RoundVariant) {
+	isOver, err := rv.NextRound(g)
+	if err != nil {
+		panic(fmt.Sprintf("could not advance the round: %v", err))
+	}
+
+	if isOver {
+		g.endGame()
+	}
+}
+
+// suspendDecisions takes the decision away from every participant
+// This is used while a variant waits on all participants at once. Call determineFirstToAct to resume betting
+func (g *Game) suspendDecisions() {
+	g.decisionStartIndex = 0
+	g.decisionCount = len(g.playerIDs)
+}
+
 func (g *Game) isRoundOver() bool {
 	return g.getCurrentTurn() == nil
 }
@@ -260,12 +295,11 @@ func (g *Game) determineFirstToAct() {
 			}
 		}
 
-		ha := handanalyzer.New(5, hand)
-		strength := ha.GetStrength()
+		handRank, strength := handanalyzer.Evaluate(5, hand)
 		if strength > bestStrength {
 			bestStrength = strength
 			bestIndex = index
-			handName = ha.GetHand().String()
+			handName = handRank.String()
 		}
 	}
 
@@ -322,8 +356,7 @@ func (g *Game) endGame() {
 			continue
 		}
 
-		ha := handanalyzer.New(5, p.hand)
-		if s := ha.GetStrength(); s > bestStrength {
+		if _, s := handanalyzer.Evaluate(5, p.hand); s > bestStrength {
 			winnerList = []*participant{p}
 			bestStrength = s
 		} else if s == bestStrength {
@@ -398,9 +431,14 @@ func (g *Game) sendEndOfGameLogMessages(handWinnings, splitWinnings map[*partici
 	lms := make([]*playable.LogMessage, 0, len(g.idToParticipant))
 
 	// Log hand winners
+	var best *handanalyzer.HandAnalyzer
 	for winner, amount := range handWinnings {
-		hand := winner.getHandAnalyzer().GetHand().String()
-		lms = append(lms, playable.SimpleLogMessage(winner.PlayerID, "{} had a %s and won ${%d}", hand, amount))
+		ha := winner.getHandAnalyzer()
+		if best == nil || ha.GetStrength() > best.GetStrength() {
+			best = ha
+		}
+
+		lms = append(lms, playable.SimpleLogMessage(winner.PlayerID, "{} had a %s and won ${%d}", ha.Explain().String(), amount))
 	}
 
 	// Log split pot winners (with card in the log message)
@@ -424,8 +462,14 @@ func (g *Game) sendEndOfGameLogMessages(handWinnings, splitWinnings map[*partici
 		if p.didFold {
 			lms = append(lms, playable.SimpleLogMessage(p.PlayerID, "{} folded and lost ${%d}", -1*p.balance))
 		} else {
-			hand := p.getHandAnalyzer().GetHand().String()
-			lms = append(lms, playable.SimpleLogMessage(p.PlayerID, "{} had a %s and lost ${%d}", hand, -1*p.balance))
+			ha := p.getHandAnalyzer()
+			if best == nil {
+				lms = append(lms, playable.SimpleLogMessage(p.PlayerID, "{} had a %s and lost ${%d}", ha.Explain().String(), -1*p.balance))
+				continue
+			}
+
+			_, reason := handanalyzer.Compare(best, ha)
+			lms = append(lms, playable.SimpleLogMessage(p.PlayerID, "{} had a %s and lost ${%d}: %s", ha.Explain().String(), -1*p.balance, reason))
 		}
 	}
 
@@ -33,6 +33,26 @@ func TestNewGame(t *testing.T) {
 	a.Equal(-25, game.idToParticipant[1].balance)
 }
 
+func TestNewGame_joker(t *testing.T) {
+	a := assert.New(t)
+
+	opts := DefaultOptions()
+	opts.Joker = true
+	game, err := NewGame(logrus.StandardLogger(), []int64{1, 2}, opts)
+	a.NoError(err)
+	a.Equal("Seven-Card Stud with the Bug", game.Name())
+	a.Equal(53, len(game.deck.Cards))
+
+	jokers := 0
+	for _, card := range game.deck.Cards {
+		if card.IsBug() {
+			jokers++
+		}
+	}
+
+	a.Equal(1, jokers)
+}
+
 func TestGame_Start(t *testing.T) {
 	a := assert.New(t)
 
@@ -241,13 +261,13 @@ func TestGame_endGame(t *testing.T) {
 
 	m := game.pendingLogs
 	a.Equal(3, len(m))
-	a.Equal("{} had a Full house and won ${75}", m[0].Message)
+	a.Equal("{} had a Full house (tens full of nines) and won ${75}", m[0].Message)
 	a.Equal([]int64{2}, m[0].PlayerIDs)
 
 	a.Equal("{} folded and lost ${25}", m[1].Message)
 	a.Equal([]int64{1}, m[1].PlayerIDs)
 
-	a.Equal("{} had a Two pair and lost ${25}", m[2].Message)
+	a.Equal("{} had a Two pair (eights and sevens, K kicker) and lost ${25}: Full house beats Two pair", m[2].Message)
 	a.Equal([]int64{3}, m[2].PlayerIDs)
 }
 
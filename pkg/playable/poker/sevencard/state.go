@@ -3,6 +3,7 @@ package sevencard
 import (
 	"mondaynightpoker-server/pkg/deck"
 	"mondaynightpoker-server/pkg/playable/poker"
+	"mondaynightpoker-server/pkg/playable/poker/handanalyzer"
 )
 
 // GameState contains the state about the game
@@ -124,13 +125,26 @@ func (g *Game) getPlayerStateByPlayerID(playerID int64) PlayerState {
 			}
 		}
 
+		handRank := p.getHandAnalyzer().GetHand().String()
+
+		// in a blind variant, the participant only knows about the cards that are face-up
+		if bv, ok := g.options.Variant.(BlindVariant); ok && bv.IsBlind() && !g.isGameOver() {
+			for i, card := range filteredHand {
+				if !card.IsBitSet(faceUp) {
+					filteredHand[i] = nil
+				}
+			}
+
+			handRank = handanalyzer.New(5, p.faceUpCards()).GetHand().String()
+		}
+
 		pJSON = &participantJSON{
 			PlayerID:   p.PlayerID,
 			DidFold:    p.didFold,
 			Balance:    p.Balance(),
 			CurrentBet: p.currentBet,
 			Hand:       filteredHand,
-			HandRank:   p.getHandAnalyzer().GetHand().String(),
+			HandRank:   handRank,
 		}
 
 		actions = g.getActionsForParticipant(p)
@@ -0,0 +1,321 @@
+package sevencard
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+
+	"mondaynightpoker-server/pkg/deck"
+	"mondaynightpoker-server/pkg/playable"
+)
+
+// anacondaPasses is the number of cards passed to the left in each passing round
+var anacondaPasses = []int{3, 2, 1}
+
+// anacondaRolls is the number of cards each participant rolls before the showdown
+const anacondaRolls = 4
+
+type anacondaPhase int
+
+const (
+	anacondaPhasePassing anacondaPhase = iota
+	anacondaPhaseRolling
+	anacondaPhaseBetting
+)
+
+func (a anacondaPhase) String() string {
+	switch a {
+	case anacondaPhasePassing:
+		return "passing"
+	case anacondaPhaseRolling:
+		return "rolling"
+	case anacondaPhaseBetting:
+		return "betting"
+	default:
+		panic(fmt.Sprintf("unknown anaconda phase: %d", a))
+	}
+}
+
+// Anaconda is a seven-card variant where all seven cards are dealt face-down up front
+// Players pass three, then two, then one card to the player on their left, with a round of
+// betting after each pass. Then each player rolls (turns face-up) one card at a time with a
+// round of betting after each roll. Passes and rolls are chosen simultaneously and kept hidden
+// until every player has made a selection
+type Anaconda struct {
+	phase     anacondaPhase
+	passIndex int
+	rolls     int
+
+	// selections are the cards each participant chose to pass or roll
+	selections map[int64][]*deck.Card
+}
+
+// AnacondaState is the variant state sent to clients
+type AnacondaState struct {
+	Phase     string  `json:"phase"`
+	PassCount int     `json:"passCount,omitempty"`
+	Rolls     int     `json:"rolls"`
+	Ready     []int64 `json:"ready"`
+}
+
+// Name returns "Anaconda"
+func (a *Anaconda) Name() string {
+	return "Anaconda"
+}
+
+// Start resets all variant state
+func (a *Anaconda) Start() {
+	a.phase = anacondaPhasePassing
+	a.passIndex = 0
+	a.rolls = 0
+	a.selections = make(map[int64][]*deck.Card)
+}
+
+// ParticipantReceivedCard is a no-op for Anaconda
+func (a *Anaconda) ParticipantReceivedCard(_ *Game, _ *participant, _ *deck.Card) {
+}
+
+// Deal deals all seven cards face-down and starts the first passing round
+func (a *Anaconda) Deal(game *Game) error {
+	for i := 0; i < 7; i++ {
+		if err := game.dealCards(true); err != nil {
+			return err
+		}
+	}
+
+	a.startPhase(game, anacondaPhasePassing)
+	return nil
+}
+
+// NextRound starts the next passing or rolling round
+func (a *Anaconda) NextRound(game *Game) (bool, error) {
+	if a.phase != anacondaPhaseBetting {
+		return false, errors.New("the betting round is not active")
+	}
+
+	if a.passIndex < len(anacondaPasses)-1 {
+		a.passIndex++
+		a.startPhase(game, anacondaPhasePassing)
+		return false, nil
+	}
+
+	if a.rolls < anacondaRolls {
+		a.startPhase(game, anacondaPhaseRolling)
+		return false, nil
+	}
+
+	return true, nil
+}
+
+func (a *Anaconda) startPhase(game *Game, phase anacondaPhase) {
+	a.phase = phase
+	a.selections = make(map[int64][]*deck.Card)
+	game.suspendDecisions()
+
+	if phase == anacondaPhasePassing {
+		game.pendingLogs = append(game.pendingLogs, playable.SimpleLogMessage(0, "Pass %d %s to the left", a.passCount(), pluralizeCard(a.passCount())))
+	} else {
+		game.pendingLogs = append(game.pendingLogs, playable.SimpleLogMessage(0, "Roll a card"))
+	}
+}
+
+// passCount returns the number of cards to pass in the current passing round
+func (a *Anaconda) passCount() int {
+	return anacondaPasses[a.passIndex]
+}
+
+// GetVariantActions returns the pass or roll action if the participant still needs to make a selection
+//
+//nolint:revive // participant is intentionally unexported
+func (a *Anaconda) GetVariantActions(_ *Game, p *participant) []Action {
+	if p.didFold || a.selections[p.PlayerID] != nil {
+		return nil
+	}
+
+	switch a.phase {
+	case anacondaPhasePassing:
+		return []Action{ActionPassCards}
+	case anacondaPhaseRolling:
+		return []Action{ActionRollCard}
+	default:
+		return nil
+	}
+}
+
+// HandleVariantAction handles passing and rolling cards
+//
+//nolint:revive // participant is intentionally unexported
+func (a *Anaconda) HandleVariantAction(game *Game, p *participant, action Action, cards []*deck.Card) (bool, error) {
+	switch action {
+	case ActionPassCards:
+		if a.phase != anacondaPhasePassing {
+			return true, errors.New("you cannot pass cards right now")
+		}
+
+		if err := a.selectCards(p, cards, a.passCount()); err != nil {
+			return true, err
+		}
+
+		if a.isEveryoneReady(game) {
+			a.passCards(game)
+		}
+
+		return true, nil
+	case ActionRollCard:
+		if a.phase != anacondaPhaseRolling {
+			return true, errors.New("you cannot roll a card right now")
+		}
+
+		if err := a.selectCards(p, cards, 1); err != nil {
+			return true, err
+		}
+
+		if a.isEveryoneReady(game) {
+			a.rollCards(game)
+		}
+
+		return true, nil
+	default:
+		return false, nil
+	}
+}
+
+// selectCards validates and records the participant's selection
+func (a *Anaconda) selectCards(p *participant, cards []*deck.Card, count int) error {
+	if p.didFold {
+		return errors.New("you have already folded")
+	}
+
+	if a.selections[p.PlayerID] != nil {
+		return errors.New("you have already made your selection")
+	}
+
+	if len(cards) != count {
+		return fmt.Errorf("you must select %d %s", count, pluralizeCard(count))
+	}
+
+	selected := make([]*deck.Card, 0, count)
+	for _, card := range cards {
+		handCard := findFaceDownCard(p.hand, card)
+		if handCard == nil {
+			return fmt.Errorf("you do not have %s face-down in your hand", card.String())
+		}
+
+		for _, c := range selected {
+			if c == handCard {
+				return errors.New("you cannot select the same card twice")
+			}
+		}
+
+		selected = append(selected, handCard)
+	}
+
+	a.selections[p.PlayerID] = selected
+	return nil
+}
+
+// findFaceDownCard returns the card from the hand matching card, or nil if it isn't in the hand face-down
+func findFaceDownCard(hand deck.Hand, card *deck.Card) *deck.Card {
+	for _, c := range hand {
+		if c.Equal(card) && !c.IsBitSet(faceUp) {
+			return c
+		}
+	}
+
+	return nil
+}
+
+// isEveryoneReady returns true if every participant still in the game made a selection
+func (a *Anaconda) isEveryoneReady(game *Game) bool {
+	for _, p := range game.idToParticipant {
+		if !p.didFold && a.selections[p.PlayerID] == nil {
+			return false
+		}
+	}
+
+	return true
+}
+
+// passCards moves every selection to the next participant on the left who has not folded
+func (a *Anaconda) passCards(game *Game) {
+	active := make([]*participant, 0, len(game.playerIDs))
+	for _, id := range game.playerIDs {
+		if p := game.idToParticipant[id]; !p.didFold {
+			active = append(active, p)
+		}
+	}
+
+	for _, p := range active {
+		for _, card := range a.selections[p.PlayerID] {
+			p.hand.Discard(card, 1)
+		}
+	}
+
+	for i, p := range active {
+		left := active[(i+1)%len(active)]
+		for _, card := range a.selections[p.PlayerID] {
+			left.hand.AddCard(card)
+		}
+	}
+
+	game.pendingLogs = append(game.pendingLogs, playable.SimpleLogMessage(0, "Everybody passed %d %s to the left", a.passCount(), pluralizeCard(a.passCount())))
+	a.startBetting(game)
+}
+
+// rollCards turns every selection face-up
+func (a *Anaconda) rollCards(game *Game) {
+	for _, id := range game.playerIDs {
+		p := game.idToParticipant[id]
+		for _, card := range a.selections[p.PlayerID] {
+			card.SetBit(faceUp)
+			game.pendingLogs = append(game.pendingLogs, playable.SimpleLogMessageWithCard(p.PlayerID, card, "{} rolls %s", card.String()))
+		}
+	}
+
+	a.rolls++
+	a.startBetting(game)
+}
+
+func (a *Anaconda) startBetting(game *Game) {
+	a.phase = anacondaPhaseBetting
+	a.selections = make(map[int64][]*deck.Card)
+	game.determineFirstToAct()
+}
+
+// IsVariantPhasePending returns true if we are waiting on participants to pass or roll
+func (a *Anaconda) IsVariantPhasePending() bool {
+	return a.phase != anacondaPhaseBetting
+}
+
+// GetVariantState returns the variant state for clients
+// Only the participants who are ready are included, not the cards they selected
+func (a *Anaconda) GetVariantState() interface{} {
+	ready := make([]int64, 0, len(a.selections))
+	for id := range a.selections {
+		ready = append(ready, id)
+	}
+
+	sort.Slice(ready, func(i, j int) bool {
+		return ready[i] < ready[j]
+	})
+
+	state := &AnacondaState{
+		Phase: a.phase.String(),
+		Rolls: a.rolls,
+		Ready: ready,
+	}
+
+	if a.phase == anacondaPhasePassing {
+		state.PassCount = a.passCount()
+	}
+
+	return state
+}
+
+func pluralizeCard(n int) string {
+	if n == 1 {
+		return "card"
+	}
+
+	return "cards"
+}
@@ -0,0 +1,183 @@
+package sevencard
+
+import (
+	"testing"
+
+	"mondaynightpoker-server/pkg/deck"
+	"mondaynightpoker-server/pkg/playable"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+)
+
+func newAnacondaGame(t *testing.T) (*Game, *Anaconda) {
+	t.Helper()
+
+	opts := DefaultOptions()
+	anaconda := &Anaconda{}
+	opts.Variant = anaconda
+
+	game, err := NewGame(logrus.StandardLogger(), []int64{1, 2, 3}, opts)
+	assert.NoError(t, err)
+	drainLogChannel(game)
+
+	// player 1 gets clubs, player 2 gets diamonds, player 3 gets hearts
+	game.deck.Cards = deck.CardsFromString("2c,2d,2h,3c,3d,3h,4c,4d,4h,5c,5d,5h,6c,6d,6h,7c,7d,7h,8c,8d,8h")
+	assert.NoError(t, game.Start())
+
+	return game, anaconda
+}
+
+func anacondaAction(game *Game, playerID int64, action Action, cards string) error {
+	payload := &playable.PayloadIn{Action: string(action)}
+	if cards != "" {
+		payload.Cards = deck.CardsFromString(cards)
+	}
+
+	_, _, err := game.Action(playerID, payload)
+	return err
+}
+
+func anacondaEveryoneChecks(t *testing.T, game *Game) {
+	t.Helper()
+	for _, id := range []int64{1, 2, 3} {
+		assert.NoError(t, anacondaAction(game, id, ActionCheck, ""))
+	}
+}
+
+func TestAnaconda_Name(t *testing.T) {
+	assert.Equal(t, "Anaconda", (&Anaconda{}).Name())
+}
+
+func TestAnaconda_Deal(t *testing.T) {
+	a := assert.New(t)
+	game, anaconda := newAnacondaGame(t)
+	p := createParticipantGetter(game)
+
+	a.Equal("2c,3c,4c,5c,6c,7c,8c", p(1).hand.String())
+	a.Equal("2d,3d,4d,5d,6d,7d,8d", p(2).hand.String())
+	for _, card := range p(1).hand {
+		a.False(card.IsBitSet(faceUp))
+	}
+
+	a.True(anaconda.IsVariantPhasePending())
+	a.Nil(game.getCurrentTurn())
+	a.Equal(&AnacondaState{Phase: "passing", PassCount: 3, Ready: []int64{}}, anaconda.GetVariantState())
+
+	state := game.getPlayerStateByPlayerID(1)
+	a.Equal([]Action{ActionPassCards}, state.Actions)
+	a.Empty(state.FutureActions)
+
+	// betting is not allowed while we wait on the passes
+	a.Equal(errNotPlayersTurn, anacondaAction(game, 1, ActionCheck, ""))
+	a.EqualError(anacondaAction(game, 1, ActionRollCard, "2c"), "you cannot roll a card right now")
+}
+
+func TestAnaconda_passValidation(t *testing.T) {
+	a := assert.New(t)
+	game, anaconda := newAnacondaGame(t)
+
+	a.EqualError(anacondaAction(game, 1, ActionPassCards, "2c,3c"), "you must select 3 cards")
+	a.EqualError(anacondaAction(game, 1, ActionPassCards, "2c,3c,2d"), "you do not have 2♢ face-down in your hand")
+	a.EqualError(anacondaAction(game, 1, ActionPassCards, "2c,3c,3c"), "you cannot select the same card twice")
+
+	a.NoError(anacondaAction(game, 1, ActionPassCards, "2c,3c,4c"))
+	a.EqualError(anacondaAction(game, 1, ActionPassCards, "5c,6c,7c"), "you have already made your selection")
+	a.Empty(game.getPlayerStateByPlayerID(1).Actions)
+
+	// cards are not passed until everybody has made a selection
+	a.Equal("2c,3c,4c,5c,6c,7c,8c", game.idToParticipant[1].hand.String())
+	a.Equal(&AnacondaState{Phase: "passing", PassCount: 3, Ready: []int64{1}}, anaconda.GetVariantState())
+}
+
+func TestAnaconda_fullGame(t *testing.T) {
+	a := assert.New(t)
+	game, anaconda := newAnacondaGame(t)
+	p := createParticipantGetter(game)
+
+	// pass three
+	a.NoError(anacondaAction(game, 1, ActionPassCards, "2c,3c,4c"))
+	a.NoError(anacondaAction(game, 2, ActionPassCards, "2d,3d,4d"))
+	a.NoError(anacondaAction(game, 3, ActionPassCards, "2h,3h,4h"))
+
+	a.Equal("5c,6c,7c,8c,2h,3h,4h", p(1).hand.String())
+	a.Equal("5d,6d,7d,8d,2c,3c,4c", p(2).hand.String())
+	a.Equal("5h,6h,7h,8h,2d,3d,4d", p(3).hand.String())
+
+	a.False(anaconda.IsVariantPhasePending())
+	a.Equal(p(1), game.getCurrentTurn())
+	anacondaEveryoneChecks(t, game)
+
+	// pass two
+	a.Equal(&AnacondaState{Phase: "passing", PassCount: 2, Ready: []int64{}}, anaconda.GetVariantState())
+	a.Nil(game.getCurrentTurn())
+	a.NoError(anacondaAction(game, 1, ActionPassCards, "2h,3h"))
+	a.NoError(anacondaAction(game, 2, ActionPassCards, "2c,3c"))
+	a.NoError(anacondaAction(game, 3, ActionPassCards, "2d,3d"))
+	a.Equal("5c,6c,7c,8c,4h,2d,3d", p(1).hand.String())
+	anacondaEveryoneChecks(t, game)
+
+	// pass one, player 3 folds afterwards, so only two players remain
+	a.NoError(anacondaAction(game, 1, ActionPassCards, "4h"))
+	a.NoError(anacondaAction(game, 2, ActionPassCards, "8d"))
+	a.NoError(anacondaAction(game, 3, ActionPassCards, "4d"))
+	a.Equal("5c,6c,7c,8c,2d,3d,4d", p(1).hand.String())
+	a.Equal("5d,6d,7d,4c,2h,3h,4h", p(2).hand.String())
+	a.Equal("5h,6h,7h,8h,2c,3c,8d", p(3).hand.String())
+
+	a.NoError(anacondaAction(game, 1, ActionCheck, ""))
+	a.NoError(anacondaAction(game, 2, ActionCheck, ""))
+	a.NoError(anacondaAction(game, 3, ActionFold, ""))
+
+	// roll four cards
+	for i, rolls := range [][2]string{{"5c", "5d"}, {"6c", "6d"}, {"7c", "7d"}, {"8c", "4c"}} {
+		a.Equal(&AnacondaState{Phase: "rolling", Rolls: i, Ready: []int64{}}, anaconda.GetVariantState())
+		a.Equal([]Action{ActionRollCard}, game.getPlayerStateByPlayerID(1).Actions)
+		a.Empty(game.getPlayerStateByPlayerID(3).Actions)
+
+		a.EqualError(anacondaAction(game, 3, ActionRollCard, "5h"), "you have already folded")
+		a.NoError(anacondaAction(game, 1, ActionRollCard, rolls[0]))
+		a.NoError(anacondaAction(game, 2, ActionRollCard, rolls[1]))
+
+		a.False(anaconda.IsVariantPhasePending())
+		a.NoError(anacondaAction(game, 1, ActionCheck, ""))
+		a.NoError(anacondaAction(game, 2, ActionCheck, ""))
+	}
+
+	a.Equal(revealWinner, game.round)
+	a.True(game.isGameOver())
+	a.Equal(map[*participant]int{p(1): 75}, game.winners)
+	a.Equal(50, p(1).balance)
+	a.Equal(-25, p(2).balance)
+}
+
+func TestAnaconda_rolledCardsAreVisible(t *testing.T) {
+	a := assert.New(t)
+	game, _ := newAnacondaGame(t)
+
+	for _, passes := range [][3]string{{"2c,3c,4c", "2d,3d,4d", "2h,3h,4h"}, {"5c,6c", "5d,6d", "5h,6h"}, {"7c", "7d", "7h"}} {
+		for i, cards := range passes {
+			a.NoError(anacondaAction(game, int64(i+1), ActionPassCards, cards))
+		}
+
+		anacondaEveryoneChecks(t, game)
+	}
+
+	a.EqualError(anacondaAction(game, 1, ActionRollCard, "8c,2h"), "you must select 1 card")
+	a.NoError(anacondaAction(game, 1, ActionRollCard, "8c"))
+
+	// the selection stays hidden until everybody rolls
+	a.Equal(deck.Hand{nil, nil, nil, nil, nil, nil, nil}, game.getGameState().Participants[0].Hand)
+
+	a.NoError(anacondaAction(game, 2, ActionRollCard, "8d"))
+	a.NoError(anacondaAction(game, 3, ActionRollCard, "8h"))
+
+	hand := game.getGameState().Participants[0].Hand
+	a.Equal("8c", deck.CardToString(hand[0]))
+	for _, card := range hand[1:] {
+		a.Nil(card)
+	}
+
+	a.EqualError(anacondaAction(game, 1, ActionPassCards, "2h"), "you cannot pass cards right now")
+	a.Equal(game.idToParticipant[1], game.getCurrentTurn())
+}
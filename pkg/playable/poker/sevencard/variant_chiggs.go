@@ -208,7 +208,7 @@ func (c *Chiggs) GetVariantActions(game *Game, p *participant) []Action {
 // HandleVariantAction handles variant-specific actions
 //
 //nolint:revive // participant is intentionally unexported
-func (c *Chiggs) HandleVariantAction(game *Game, p *participant, action Action) (bool, error) {
+func (c *Chiggs) HandleVariantAction(game *Game, p *participant, action Action, _ []*deck.Card) (bool, error) {
 	switch action {
 	case ActionFlipMushroom:
 		return c.handleFlipMushroom(game, p)
@@ -272,7 +272,7 @@ func TestChiggs_AntidotePlayDiscardsCard(t *testing.T) {
 	chiggs.ParticipantReceivedCard(game, p(2), card4c)
 
 	// Player 1 plays antidote
-	handled, err := chiggs.HandleVariantAction(game, p(1), ActionPlayAntidote)
+	handled, err := chiggs.HandleVariantAction(game, p(1), ActionPlayAntidote, nil)
 	a.NoError(err)
 	a.True(handled)
 
@@ -305,7 +305,7 @@ func TestChiggs_FlippedMushroomIsDiscarded(t *testing.T) {
 	chiggs.ParticipantReceivedCard(game, p(2), card4c)
 
 	// Flip the mushroom
-	handled, err := chiggs.HandleVariantAction(game, p(2), ActionFlipMushroom)
+	handled, err := chiggs.HandleVariantAction(game, p(2), ActionFlipMushroom, nil)
 	a.NoError(err)
 	a.True(handled)
 
@@ -457,7 +457,7 @@ func TestChiggs_MultipleAntidotesAutoSelectsFirst(t *testing.T) {
 	chiggs.ParticipantReceivedCard(game, p(2), card4c)
 
 	// Player 1 plays antidote
-	handled, err := chiggs.HandleVariantAction(game, p(1), ActionPlayAntidote)
+	handled, err := chiggs.HandleVariantAction(game, p(1), ActionPlayAntidote, nil)
 	a.NoError(err)
 	a.True(handled)
 
@@ -531,7 +531,7 @@ func TestChiggs_HandleVariantAction_UnhandledAction(t *testing.T) {
 	p := createParticipantGetter(game)
 
 	// Try an unknown action
-	handled, err := chiggs.HandleVariantAction(game, p(1), ActionCheck)
+	handled, err := chiggs.HandleVariantAction(game, p(1), ActionCheck, nil)
 	a.NoError(err)
 	a.False(handled, "ActionCheck should not be handled by variant")
 }
@@ -547,7 +547,7 @@ func TestChiggs_FlipMushroom_NoMushroomToFlip(t *testing.T) {
 	p := createParticipantGetter(game)
 
 	// Player has no mushroom to flip
-	handled, err := chiggs.HandleVariantAction(game, p(1), ActionFlipMushroom)
+	handled, err := chiggs.HandleVariantAction(game, p(1), ActionFlipMushroom, nil)
 	a.NoError(err)
 	a.False(handled, "should not handle flip when no mushroom")
 }
@@ -563,7 +563,7 @@ func TestChiggs_PlayAntidote_NotPending(t *testing.T) {
 	p := createParticipantGetter(game)
 
 	// Player has no pending response
-	handled, err := chiggs.HandleVariantAction(game, p(1), ActionPlayAntidote)
+	handled, err := chiggs.HandleVariantAction(game, p(1), ActionPlayAntidote, nil)
 	a.NoError(err)
 	a.False(handled, "should not handle play-antidote when not pending")
 }
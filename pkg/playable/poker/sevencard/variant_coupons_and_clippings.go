@@ -134,7 +134,7 @@ func (c *CouponsAndClippings) GetVariantActions(_ *Game, _ *participant) []Actio
 // HandleVariantAction handles variant-specific actions (CouponsAndClippings has none)
 //
 //nolint:revive // participant is intentionally unexported
-func (c *CouponsAndClippings) HandleVariantAction(_ *Game, _ *participant, _ Action) (bool, error) {
+func (c *CouponsAndClippings) HandleVariantAction(_ *Game, _ *participant, _ Action, _ []*deck.Card) (bool, error) {
 	return false, nil
 }
 
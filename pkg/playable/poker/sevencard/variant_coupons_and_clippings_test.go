@@ -640,7 +640,7 @@ func TestCouponsAndClippings_HandleVariantAction(t *testing.T) {
 	p := createParticipantGetter(game)
 
 	// CouponsAndClippings doesn't handle any variant actions
-	handled, err := cc.HandleVariantAction(game, p(1), ActionCheck)
+	handled, err := cc.HandleVariantAction(game, p(1), ActionCheck, nil)
 	a.NoError(err)
 	a.False(handled, "CouponsAndClippings should not handle any variant actions")
 }
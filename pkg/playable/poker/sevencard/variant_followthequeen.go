@@ -39,10 +39,20 @@ func (f *FollowTheQueen) ParticipantReceivedCard(game *Game, _ *participant, c *
 	if wildDidChange {
 		for _, participant := range game.idToParticipant {
 			for _, card := range participant.hand {
-				card.IsWild = card.Rank == f.wildRank || card.Rank == deck.Queen
+				card.IsWild = f.isWild(card)
 			}
 		}
 	} else {
-		c.IsWild = c.Rank == f.wildRank || c.Rank == deck.Queen
+		c.IsWild = f.isWild(c)
 	}
 }
+
+// isWild returns true if the card is a queen or the rank that followed the last queen
+// The bug is never a regular wild
+func (f *FollowTheQueen) isWild(c *deck.Card) bool {
+	if c.IsBug() {
+		return false
+	}
+
+	return c.Rank == f.wildRank || c.Rank == deck.Queen
+}
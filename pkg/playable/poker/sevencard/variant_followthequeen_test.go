@@ -48,6 +48,19 @@ func TestFollowTheQueen_ParticipantReceivedCard(t *testing.T) {
 	a.Equal("5c,6c,7d,3c,14c", deck.CardsToString(p(3).hand))
 }
 
+func TestFollowTheQueen_isWild(t *testing.T) {
+	a := assert.New(t)
+
+	// the bug is not wild before a card has followed a queen
+	ftq := &FollowTheQueen{}
+	a.False(ftq.isWild(deck.NewJoker()))
+	a.True(ftq.isWild(deck.CardFromString("12c")))
+
+	ftq.wildRank = 3
+	a.True(ftq.isWild(deck.CardFromString("3d")))
+	a.False(ftq.isWild(deck.CardFromString("4d")))
+}
+
 func TestFollowTheQueen_Name(t *testing.T) {
 	ftq := FollowTheQueen{}
 	assert.Equal(t, "Follow the Queen", ftq.Name())
@@ -17,7 +17,8 @@ func (l *LowCardWild) Start() {
 func (l *LowCardWild) ParticipantReceivedCard(_ *Game, p *participant, _ *deck.Card) {
 	lowestRank := math.MaxInt32
 	for _, card := range p.hand {
-		if card.IsBitSet(faceUp) {
+		// the bug is not a low card
+		if card.IsBitSet(faceUp) || card.IsBug() {
 			continue
 		}
 
@@ -27,7 +28,7 @@ func (l *LowCardWild) ParticipantReceivedCard(_ *Game, p *participant, _ *deck.C
 	}
 
 	for _, card := range p.hand {
-		if card.Rank == lowestRank {
+		if card.Rank == lowestRank && !card.IsBug() {
 			card.IsWild = true
 		} else {
 			card.IsWild = false
@@ -65,3 +65,14 @@ func TestLowCardWild_ParticipantReceivedCard(t *testing.T) {
 	assertCard(3, "3d", false)
 	assertCard(4, "!2d", false)
 }
+
+func TestLowCardWild_ParticipantReceivedCard_bug(t *testing.T) {
+	a := assert.New(t)
+	lw := &LowCardWild{}
+
+	// the bug is never the low card
+	p := newParticipant(1, 0, 25)
+	p.hand = deck.CardsFromString("8c,0j,3c")
+	lw.ParticipantReceivedCard(nil, p, nil)
+	a.Equal("8c,0j,!3c", deck.CardsToString(p.hand))
+}
@@ -0,0 +1,222 @@
+package sevencard
+
+import (
+	"errors"
+	"math"
+
+	"mondaynightpoker-server/pkg/deck"
+	"mondaynightpoker-server/pkg/playable"
+	"mondaynightpoker-server/pkg/playable/poker/handanalyzer"
+)
+
+// NightBaseball is Baseball where all seven cards are dealt face-down, and nobody may look at their own cards
+// Starting left of the dealer, each player in turn flips their cards one at a time until their showing hand
+// beats the best showing hand at the table. A round of betting follows every time the lead changes. A player
+// who runs out of cards without taking the lead is done flipping. 3s and 9s are wild, and a flipped 4 earns
+// the player an extra face-down card
+type NightBaseball struct {
+	Baseball
+
+	isFlipping bool
+
+	// flipperIndex is the index of the player who is flipping, or who flipped last
+	flipperIndex int
+
+	// gameRef stores a reference to the game for the variant state
+	gameRef *Game
+}
+
+// NightBaseballState is the variant state sent to clients
+type NightBaseballState struct {
+	IsFlipping bool   `json:"isFlipping"`
+	FlipperID  int64  `json:"flipperId"`
+	LeaderID   int64  `json:"leaderId,omitempty"`
+	HandToBeat string `json:"handToBeat,omitempty"`
+}
+
+// Name returns "Night Baseball"
+func (n *NightBaseball) Name() string {
+	return "Night Baseball"
+}
+
+// Start resets all variant state
+func (n *NightBaseball) Start() {
+	n.Baseball.Start()
+	n.isFlipping = false
+	n.flipperIndex = 0
+	n.gameRef = nil
+}
+
+// IsBlind returns true because participants cannot look at their own cards
+func (n *NightBaseball) IsBlind() bool {
+	return true
+}
+
+// Deal deals all seven cards face-down and asks the first player to flip
+func (n *NightBaseball) Deal(game *Game) error {
+	n.gameRef = game
+
+	for i := 0; i < 7; i++ {
+		if err := game.dealCards(true); err != nil {
+			return err
+		}
+	}
+
+	if !n.startFlipping(game, game.dealerIndex) {
+		return errors.New("nobody is able to flip")
+	}
+
+	return nil
+}
+
+// NextRound asks the next player to flip
+func (n *NightBaseball) NextRound(game *Game) (bool, error) {
+	if n.isFlipping {
+		return false, errors.New("the betting round is not active")
+	}
+
+	return !n.startFlipping(game, n.flipperIndex), nil
+}
+
+// startFlipping finds the next player after index who needs to flip
+// Returns false if nobody is left to flip
+func (n *NightBaseball) startFlipping(game *Game, index int) bool {
+	nPlayers := len(game.playerIDs)
+	for i := 1; i <= nPlayers; i++ {
+		flipperIndex := (index + i) % nPlayers
+		p := game.idToParticipant[game.playerIDs[flipperIndex]]
+		if p.didFold || nextFaceDownCard(p) == nil || n.isLeading(game, p) {
+			continue
+		}
+
+		n.isFlipping = true
+		n.flipperIndex = flipperIndex
+		game.suspendDecisions()
+		return true
+	}
+
+	n.isFlipping = false
+	return false
+}
+
+// GetVariantActions returns the flip action to the player who is flipping
+//
+//nolint:revive // participant is intentionally unexported
+func (n *NightBaseball) GetVariantActions(game *Game, p *participant) []Action {
+	if n.getFlipper(game) != p {
+		return nil
+	}
+
+	return []Action{ActionFlipCard}
+}
+
+// HandleVariantAction flips the next card for the player who is flipping
+//
+//nolint:revive // participant is intentionally unexported
+func (n *NightBaseball) HandleVariantAction(game *Game, p *participant, action Action, _ []*deck.Card) (bool, error) {
+	if action != ActionFlipCard {
+		return false, nil
+	}
+
+	if n.getFlipper(game) != p {
+		return true, errors.New("it is not your turn to flip")
+	}
+
+	card := nextFaceDownCard(p)
+	card.SetBit(faceUp)
+	game.pendingLogs = append(game.pendingLogs, playable.SimpleLogMessageWithCard(p.PlayerID, card, "{} flips %s", card.String()))
+
+	// a face-up 4 earns an extra card
+	n.Baseball.ParticipantReceivedCard(game, p, card)
+
+	if n.isLeading(game, p) {
+		hand := handanalyzer.New(5, p.faceUpCards()).GetHand().String()
+		game.pendingLogs = append(game.pendingLogs, playable.SimpleLogMessage(p.PlayerID, "{} takes the lead with a %s", hand))
+
+		n.isFlipping = false
+		game.determineFirstToAct()
+		return true, nil
+	}
+
+	if nextFaceDownCard(p) != nil {
+		return true, nil
+	}
+
+	game.pendingLogs = append(game.pendingLogs, playable.SimpleLogMessage(p.PlayerID, "{} ran out of cards"))
+	if !n.startFlipping(game, n.flipperIndex) {
+		game.endGame()
+	}
+
+	return true, nil
+}
+
+// getFlipper returns the participant who needs to flip, or nil if no one is flipping
+func (n *NightBaseball) getFlipper(game *Game) *participant {
+	if !n.isFlipping || game.isGameOver() {
+		return nil
+	}
+
+	return game.idToParticipant[game.playerIDs[n.flipperIndex]]
+}
+
+// isLeading returns true if the participant's showing hand beats every other showing hand
+func (n *NightBaseball) isLeading(game *Game, p *participant) bool {
+	_, strength := handanalyzer.Evaluate(5, p.faceUpCards())
+	_, bestStrength := n.getBestShowingHand(game, p)
+	return strength > bestStrength
+}
+
+// getBestShowingHand returns the participant with the best showing hand and its strength
+// The participant passed in as exclude is not considered
+func (n *NightBaseball) getBestShowingHand(game *Game, exclude *participant) (*participant, int) {
+	var best *participant
+	bestStrength := math.MinInt64
+	for _, id := range game.playerIDs {
+		p := game.idToParticipant[id]
+		if p.didFold || p == exclude {
+			continue
+		}
+
+		if _, strength := handanalyzer.Evaluate(5, p.faceUpCards()); strength > bestStrength {
+			best = p
+			bestStrength = strength
+		}
+	}
+
+	return best, bestStrength
+}
+
+// nextFaceDownCard returns the next card the participant will flip, or nil if all cards are face-up
+func nextFaceDownCard(p *participant) *deck.Card {
+	for _, card := range p.hand {
+		if !card.IsBitSet(faceUp) {
+			return card
+		}
+	}
+
+	return nil
+}
+
+// IsVariantPhasePending returns true if we are waiting on a player to flip
+func (n *NightBaseball) IsVariantPhasePending() bool {
+	return n.isFlipping
+}
+
+// GetVariantState returns the variant state for clients
+func (n *NightBaseball) GetVariantState() interface{} {
+	state := &NightBaseballState{
+		IsFlipping: n.isFlipping,
+	}
+
+	if n.gameRef == nil {
+		return state
+	}
+
+	state.FlipperID = n.gameRef.playerIDs[n.flipperIndex]
+	if leader, _ := n.getBestShowingHand(n.gameRef, nil); leader != nil && len(leader.faceUpCards()) > 0 {
+		state.LeaderID = leader.PlayerID
+		state.HandToBeat = handanalyzer.New(5, leader.faceUpCards()).GetHand().String()
+	}
+
+	return state
+}
@@ -0,0 +1,105 @@
+package sevencard
+
+import (
+	"testing"
+
+	"mondaynightpoker-server/pkg/deck"
+	"mondaynightpoker-server/pkg/playable"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+)
+
+func nightBaseballFlip(game *Game, playerID int64) error {
+	_, _, err := game.Action(playerID, &playable.PayloadIn{Action: string(ActionFlipCard)})
+	return err
+}
+
+func TestNightBaseball_Name(t *testing.T) {
+	assert.Equal(t, "Night Baseball", (&NightBaseball{}).Name())
+}
+
+func TestNightBaseball(t *testing.T) {
+	a := assert.New(t)
+
+	opts := DefaultOptions()
+	nb := &NightBaseball{}
+	opts.Variant = nb
+
+	game, err := NewGame(logrus.StandardLogger(), []int64{1, 2, 3}, opts)
+	a.NoError(err)
+	drainLogChannel(game)
+	p := createParticipantGetter(game)
+
+	game.deck.Cards = deck.CardsFromString("10c,2d,4h,14c,6d,2h,2c,13d,5s,5c,9s,7h,7d,12s,8s,8h,13h,10d,11d,14d,12h,11s")
+	a.NoError(game.Start())
+
+	flip := func(playerID int64, times int) {
+		t.Helper()
+		for i := 0; i < times; i++ {
+			a.NoError(nightBaseballFlip(game, playerID))
+		}
+	}
+
+	checkAround := func(playerIDs ...int64) {
+		t.Helper()
+		for _, id := range playerIDs {
+			_, _, err := game.Action(id, &playable.PayloadIn{Action: string(ActionCheck)})
+			a.NoError(err)
+		}
+	}
+
+	// nobody can see their own cards
+	state := game.getPlayerStateByPlayerID(1)
+	a.Equal(deck.Hand{nil, nil, nil, nil, nil, nil, nil}, state.Participant.Hand)
+	a.Equal("High card", state.Participant.HandRank)
+	a.Equal([]Action{ActionFlipCard}, state.Actions)
+	a.Empty(game.getPlayerStateByPlayerID(2).Actions)
+	a.Equal(&NightBaseballState{IsFlipping: true, FlipperID: 1}, nb.GetVariantState())
+
+	a.Nil(game.getCurrentTurn())
+	_, _, err = game.Action(1, &playable.PayloadIn{Action: string(ActionCheck)})
+	a.Equal(errNotPlayersTurn, err)
+	a.EqualError(nightBaseballFlip(game, 2), "it is not your turn to flip")
+
+	// the first flip always takes the lead
+	flip(1, 1)
+	a.False(nb.IsVariantPhasePending())
+	a.Equal(p(1), game.getCurrentTurn())
+	a.Equal("10c", deck.CardToString(game.getPlayerStateByPlayerID(1).Participant.Hand[0]))
+	checkAround(1, 2, 3)
+
+	// player 2 needs to beat a ten
+	a.Equal(&NightBaseballState{IsFlipping: true, FlipperID: 2, LeaderID: 1, HandToBeat: "High card"}, nb.GetVariantState())
+	flip(2, 2)
+	a.True(nb.IsVariantPhasePending())
+	flip(2, 1)
+	a.False(nb.IsVariantPhasePending())
+	a.Equal(p(2), game.getCurrentTurn())
+	checkAround(2, 3, 1)
+
+	// player 3 flips a 4, gets an extra card, and can't beat the king
+	a.Equal(&NightBaseballState{IsFlipping: true, FlipperID: 3, LeaderID: 2, HandToBeat: "High card"}, nb.GetVariantState())
+	flip(3, 1)
+	a.Equal(8, len(p(3).hand))
+	flip(3, 7)
+
+	// player 1 is next and takes the lead with an ace
+	a.Equal(&NightBaseballState{IsFlipping: true, FlipperID: 1, LeaderID: 2, HandToBeat: "High card"}, nb.GetVariantState())
+	flip(1, 1)
+	a.False(nb.IsVariantPhasePending())
+	checkAround(1, 2, 3)
+
+	// player 2 makes a pair with a wild
+	flip(2, 1)
+	a.False(nb.IsVariantPhasePending())
+	checkAround(2, 3, 1)
+
+	// player 3 has no cards left, so player 1 flips and never catches up
+	a.Equal(&NightBaseballState{IsFlipping: true, FlipperID: 1, LeaderID: 2, HandToBeat: "Pair"}, nb.GetVariantState())
+	flip(1, 5)
+
+	a.True(game.isGameOver())
+	a.Equal(map[*participant]int{p(2): 75}, game.winners)
+	a.EqualError(nightBaseballFlip(game, 1), "it is not your turn to flip")
+}
@@ -0,0 +1,57 @@
+package texasholdem
+
+import (
+	"mondaynightpoker-server/pkg/deck"
+	"mondaynightpoker-server/pkg/playable/poker/equity"
+	"mondaynightpoker-server/pkg/playable/poker/handanalyzer"
+)
+
+// checkAllInShowdown reveals the hands of the remaining participants and calculates their equity
+// if no more betting can happen because everybody (or everybody but one) is all-in
+// This must be called after a betting round is over
+func (g *Game) checkAllInShowdown() {
+	if g.potManager.GetAliveParticipantCount() < 2 || g.potManager.GetCanActParticipantCount() > 1 {
+		return
+	}
+
+	remaining := make([]*Participant, 0, len(g.participantOrder))
+	for _, p := range g.participantOrder {
+		if !p.folded {
+			p.reveal = true
+			remaining = append(remaining, p)
+		}
+	}
+
+	// equity is not calculated across multiple boards
+	if g.isMultiBoard() {
+		return
+	}
+
+	players := make([]equity.Player, len(remaining))
+	for i, p := range remaining {
+		players[i] = equity.Player{Cards: p.cards}
+	}
+
+	community := g.community[0]
+	rules := g.options.Variant.Rules()
+	results, err := equity.Calculate(equity.Scenario{
+		Players:       players,
+		Community:     community,
+		CommunityDraw: 5 - len(community),
+		Deck:          g.options.Variant.DeckSpec().Cards(),
+		Strength: func(cards, community []*deck.Card) int {
+			_, strength := handanalyzer.EvaluateWithRules(5, append(bestHoleCards(cards, community, rules), community...), rules)
+			return strength
+		},
+	})
+
+	if err != nil {
+		// the equity is informational only, so it shouldn't stop the game
+		return
+	}
+
+	for i, p := range remaining {
+		result := results[i]
+		p.equity = &result
+	}
+}
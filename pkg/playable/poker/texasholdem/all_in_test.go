@@ -0,0 +1,71 @@
+package texasholdem
+
+import (
+	"mondaynightpoker-server/pkg/deck"
+	"mondaynightpoker-server/pkg/playable/poker/action"
+	"mondaynightpoker-server/pkg/playable/poker/handanalyzer"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGame__allInShowdown(t *testing.T) {
+	a := assert.New(t)
+
+	opts := DefaultOptions()
+	opts.Ante = 0
+	game := setupNewGame(opts, 100, 100)
+
+	assertTick(t, game)
+	assertTickFromWaiting(t, game, DealerStatePreFlopBettingRound)
+
+	game.participants[1].cards = deck.CardsFromString("14c,14d")
+	game.participants[2].cards = deck.CardsFromString("13c,13d")
+	game.deck.Cards = deck.CardsFromString("2c,7d,9h,12s,3h")
+
+	assertActionAndAmount(t, game, 2, action.Raise, 100)
+	a.Nil(game.getGameState().Participants[0].Equity, "player 1 can still act")
+	a.Equal(",", deck.CardsToString(game.getGameState().Participants[0].Cards))
+	assertAction(t, game, 1, action.Call)
+
+	// pre-flop, aces are about an 80% favorite
+	state := game.getGameState()
+	a.Equal("14c,14d", deck.CardsToString(state.Participants[0].Cards))
+	a.Equal("Pair", state.Participants[0].HandRank)
+	a.InDelta(0.82, state.Participants[0].Equity.Equity, 0.03)
+	a.InDelta(0.18, state.Participants[1].Equity.Equity, 0.03)
+
+	// kings need one of the two remaining kings without an ace, which is 83 of 990 run-outs
+	assertTickFromWaiting(t, game, DealerStateDealFlop)
+	assertTick(t, game)
+	a.Equal(DealerStateFlopBettingRound, game.dealerState)
+	assertTick(t, game)
+
+	state = game.getGameState()
+	a.InDelta(907.0/990, state.Participants[0].Equity.Win, 0.0001)
+	a.InDelta(0.0, state.Participants[0].Equity.Tie, 0.0001)
+	a.InDelta(83.0/990, state.Participants[1].Equity.Win, 0.0001)
+}
+
+func TestGame__noAllInShowdown(t *testing.T) {
+	a := assert.New(t)
+
+	game := setupNewGame(DefaultOptions(), 1000, 1000)
+	assertTick(t, game)
+	assertTickFromWaiting(t, game, DealerStatePreFlopBettingRound)
+
+	assertAction(t, game, 2, action.Call)
+	assertAction(t, game, 1, action.Check)
+
+	ps := game.getParticipantStateByPlayerID(1)
+	a.Nil(ps.Participant.Equity)
+	a.Equal(",", deck.CardsToString(ps.GameState.Participants[1].Cards))
+}
+
+func Test_bestHoleCards(t *testing.T) {
+	a := assert.New(t)
+
+	community := deck.CardsFromString("3c,5c,6c,13s,13d")
+	a.Equal("2c,4c", deck.CardsToString(bestHoleCards(deck.CardsFromString("2c,13h,4c"), community, handanalyzer.StandardRules)))
+	a.Equal("2c,13h", deck.CardsToString(bestHoleCards(deck.CardsFromString("2c,13h"), community, handanalyzer.StandardRules)))
+}
@@ -22,6 +22,7 @@ const (
 	DealerStateRevealWinner
 	DealerStateEnd
 	DealerStateWaiting
+	DealerStateStraddleRound
 )
 
 type pendingDealerState struct {
@@ -67,6 +68,8 @@ func (d DealerState) String() string {
 		return "end"
 	case DealerStateWaiting:
 		return "waiting"
+	case DealerStateStraddleRound:
+		return "straddle-round"
 	}
 
 	return ""
@@ -0,0 +1,115 @@
+package texasholdem
+
+import (
+	"github.com/stretchr/testify/assert"
+	"mondaynightpoker-server/pkg/deck"
+	"mondaynightpoker-server/pkg/playable/poker/action"
+	"testing"
+)
+
+func TestGame__doubleBoard_split(t *testing.T) {
+	// board 1: 2h,7s,9d,5c,3h
+	// board 2: 13h,8s,4d,6c,10s
+	game := assertDoubleBoard(t, "14c,14d", "13c,13d", "2h,7s,9d,13h,8s,4d,5c,6c,3h,10s", func(p1Adj, p2Adj int) {
+		assert.Equal(t, 0, p1Adj)
+		assert.Equal(t, 0, p2Adj)
+	})
+
+	a := assert.New(t)
+	a.Equal("2h,7s,9d,5c,3h", deck.CardsToString(game.community[0]))
+	a.Equal("13h,8s,4d,6c,10s", deck.CardsToString(game.community[1]))
+
+	ps := game.getParticipantStateByPlayerID(1)
+	a.Equal("Pair", ps.Participant.HandRank)
+	a.Equal([]string{"Pair", "Pair"}, ps.Participant.HandRanks)
+	a.Equal([]string{"Pair", "Three of a kind"}, ps.GameState.Participants[1].HandRanks)
+	a.Equal(game.community[0], ps.PokerState.Community)
+	a.Equal(game.community, ps.PokerState.Boards)
+	a.Equal(resultWon, game.participants[1].result)
+	a.Equal(resultWon, game.participants[2].result)
+}
+
+func TestGame__doubleBoard_scoop(t *testing.T) {
+	// board 1: 2h,7s,9d,5c,3h
+	// board 2: 12h,8s,4d,6c,10s
+	assertDoubleBoard(t, "14c,14d", "13c,13d", "2h,7s,9d,12h,8s,4d,5c,6c,3h,10s", func(p1Adj, p2Adj int) {
+		assert.Equal(t, 75, p1Adj)
+		assert.Equal(t, -75, p2Adj)
+	})
+}
+
+func TestGame__singleBoard_noBoards(t *testing.T) {
+	a := assert.New(t)
+	game := setupNewGame(DefaultOptions(), 1000, 1000)
+
+	ps := game.getParticipantStateByPlayerID(1)
+	a.Nil(ps.PokerState.Boards)
+	a.Nil(ps.Participant.HandRanks)
+}
+
+func assertDoubleBoard(t *testing.T, p1, p2, community string, callback func(p1Adj, p2Adj int)) *Game {
+	t.Helper()
+
+	a := assert.New(t)
+
+	opts := DefaultOptions()
+	opts.Variant = DoubleBoard
+
+	game := setupNewGame(opts, 1000, 1000)
+	a.Equal("Double-Board Texas Hold'em (${25}/${50})", game.Name())
+	a.Equal(2, len(game.community))
+
+	assertTick(t, game)
+	a.Equal(2, len(game.participants[1].cards))
+
+	game.participants[1].cards = deck.CardsFromString(p1)
+	game.participants[2].cards = deck.CardsFromString(p2)
+	game.deck.Cards = deck.CardsFromString(community)
+
+	assertTickFromWaiting(t, game, DealerStatePreFlopBettingRound)
+
+	{
+		assertAction(t, game, 2, action.Call)
+		assertAction(t, game, 1, action.Check)
+
+		assertTickFromWaiting(t, game, DealerStateDealFlop)
+	}
+
+	{
+		assertTick(t, game)
+		a.Equal(3, len(game.community[0]))
+		a.Equal(3, len(game.community[1]))
+		assertAction(t, game, 1, action.Check)
+		assertAction(t, game, 2, action.Check)
+
+		assertTickFromWaiting(t, game, DealerStateDealTurn)
+	}
+
+	{
+		assertTick(t, game)
+		assertAction(t, game, 1, action.Check)
+		assertAction(t, game, 2, action.Check)
+
+		assertTickFromWaiting(t, game, DealerStateDealRiver)
+	}
+
+	{
+		assertTick(t, game)
+		assertAction(t, game, 1, action.Check)
+		assertAction(t, game, 2, action.Check)
+
+		assertTickFromWaiting(t, game, DealerStateRevealWinner)
+	}
+
+	{
+		assertTick(t, game)
+		assertTickFromWaiting(t, game, DealerStateEnd)
+		assertTick(t, game)
+		details, ok := game.GetEndOfGameDetails()
+		a.True(ok)
+
+		callback(details.BalanceAdjustments[1], details.BalanceAdjustments[2])
+	}
+
+	return game
+}
@@ -1,22 +1,66 @@
 package texasholdem
 
-import "mondaynightpoker-server/pkg/deck"
+import (
+	"encoding/json"
+	"errors"
+	"mondaynightpoker-server/pkg/deck"
+)
 
 type gameLog struct {
 	Participants []*participantJSON `json:"participants"`
 	Community    deck.Hand          `json:"community"`
+	Boards       []deck.Hand        `json:"boards,omitempty"`
 	Pot          int                `json:"pot"`
+
+	// the details below are used to export the hand history
+	Variant    string `json:"variant"`
+	Ante       int    `json:"ante"`
+	SmallBlind int    `json:"smallBlind"`
+	BigBlind   int    `json:"bigBlind"`
+	// Stacks is the balance each participant started with, by player ID
+	Stacks  map[int64]int `json:"stacks"`
+	History []*event      `json:"history"`
 }
 
 func (g *Game) gameLog() *gameLog {
 	p := make([]*participantJSON, len(g.participantOrder))
+	stacks := make(map[int64]int, len(g.participantOrder))
 	for i, pt := range g.participantOrder {
 		p[i] = pt.participantJSON(g, true)
+		stacks[pt.PlayerID] = pt.tableStake
 	}
 
 	return &gameLog{
 		Participants: p,
-		Community:    g.community,
+		Community:    g.community[0],
+		Boards:       g.getBoards(),
 		Pot:          g.potManager.Pots().Total(),
+		Variant:      string(g.options.Variant),
+		Ante:         g.options.ante(),
+		SmallBlind:   g.options.SmallBlind,
+		BigBlind:     g.options.BigBlind,
+		Stacks:       stacks,
+		History:      g.history,
+	}
+}
+
+// parseGameLog parses the log that was stored when the game ended
+// ErrNoHandHistory is returned if the log is from another game or has no history
+func parseGameLog(data []byte) (*gameLog, error) {
+	var log gameLog
+	if err := json.Unmarshal(data, &log); err != nil {
+		var typeErr *json.UnmarshalTypeError
+		if errors.As(err, &typeErr) {
+			// the log of another game
+			return nil, ErrNoHandHistory
+		}
+
+		return nil, err
+	}
+
+	if len(log.History) == 0 {
+		return nil, ErrNoHandHistory
 	}
+
+	return &log, nil
 }
@@ -1,6 +1,7 @@
 package texasholdem
 
 import (
+	"mondaynightpoker-server/pkg/deck"
 	"mondaynightpoker-server/pkg/playable/poker"
 	"mondaynightpoker-server/pkg/playable/poker/action"
 )
@@ -77,6 +78,16 @@ func (g *Game) getPokerState() *poker.State {
 		MinBet:     minBet,
 		MaxBet:     g.potManager.GetPotLimitMaxBet(),
 		Pots:       g.potManager.Pots(),
-		Community:  g.community,
+		Community:  g.community[0],
+		Boards:     g.getBoards(),
 	}
 }
+
+// getBoards returns all community boards if there is more than one, otherwise nil
+func (g *Game) getBoards() []deck.Hand {
+	if !g.isMultiBoard() {
+		return nil
+	}
+
+	return g.community
+}
@@ -0,0 +1,72 @@
+package texasholdem
+
+import (
+	"mondaynightpoker-server/pkg/deck"
+	"mondaynightpoker-server/pkg/playable/poker/action"
+)
+
+// eventType is the type of event recorded in the history of the game
+type eventType string
+
+const (
+	eventAnte       eventType = "ante"
+	eventSmallBlind eventType = "small-blind"
+	eventBigBlind   eventType = "big-blind"
+	eventStraddle   eventType = "straddle"
+	eventAction     eventType = "action"
+	eventFlop       eventType = "flop"
+	eventTurn       eventType = "turn"
+	eventRiver      eventType = "river"
+)
+
+// event is a single step of the game, in the order it happened
+type event struct {
+	Type     eventType     `json:"type"`
+	PlayerID int64         `json:"playerId,omitempty"`
+	Action   action.Action `json:"action,omitempty"`
+
+	// Amount is the amount the participant added to the pot
+	Amount int `json:"amount,omitempty"`
+
+	// Total is the participant's total bet in the betting round after the event
+	Total int  `json:"total,omitempty"`
+	AllIn bool `json:"allIn,omitempty"`
+
+	// Board is the index of the board the cards were dealt to
+	Board int       `json:"board,omitempty"`
+	Cards deck.Hand `json:"cards,omitempty"`
+}
+
+// recordPayment records an ante, a blind or a straddle paid by the participant
+func (g *Game) recordPayment(t eventType, p *Participant, amount int) {
+	g.history = append(g.history, &event{
+		Type:     t,
+		PlayerID: p.PlayerID,
+		Amount:   amount,
+		Total:    amount,
+		AllIn:    p.Balance() == 0,
+	})
+}
+
+// recordAction records a betting action or a discard by the participant
+// previousBet is the participant's bet before the action
+func (g *Game) recordAction(p *Participant, a action.Action, previousBet int, cards deck.Hand) {
+	g.history = append(g.history, &event{
+		Type:     eventAction,
+		PlayerID: p.PlayerID,
+		Action:   a,
+		Amount:   p.bet - previousBet,
+		Total:    p.bet,
+		AllIn:    p.bet > previousBet && p.Balance() == 0,
+		Cards:    cards,
+	})
+}
+
+// recordDeal records the community cards dealt to a board
+func (g *Game) recordDeal(t eventType, board int, cards deck.Hand) {
+	g.history = append(g.history, &event{
+		Type:  t,
+		Board: board,
+		Cards: cards,
+	})
+}
@@ -3,7 +3,9 @@ package texasholdem
 import (
 	"mondaynightpoker-server/pkg/deck"
 	"mondaynightpoker-server/pkg/playable/poker/action"
+	"mondaynightpoker-server/pkg/playable/poker/equity"
 	"mondaynightpoker-server/pkg/playable/poker/handanalyzer"
+	"strings"
 )
 
 type result string
@@ -30,6 +32,12 @@ type Participant struct {
 	result   result
 	winnings int
 
+	// equity is the participant's chance of winning once everybody is all-in
+	equity *equity.Result
+
+	// rules determine how hands are ranked
+	rules handanalyzer.Rules
+
 	handAnalyzer         *handanalyzer.HandAnalyzer
 	handAnalyzerCacheKey string
 }
@@ -43,8 +51,12 @@ type participantJSON struct {
 	MinBet   int       `json:"minBet"`
 	MaxBet   int       `json:"maxBet"`
 	HandRank string    `json:"handRank"`
-	Result   result    `json:"result"`
-	Winnings int       `json:"winnings"`
+	// HandRanks is the hand rank on each board in a multi-board game
+	HandRanks []string `json:"handRanks,omitempty"`
+	Result    result   `json:"result"`
+	Winnings  int      `json:"winnings"`
+	// Equity is only available once everybody is all-in
+	Equity *equity.Result `json:"equity,omitempty"`
 }
 
 func newParticipant(id int64, tableStake int) *Participant {
@@ -100,6 +112,10 @@ func (g *Game) ActionsForParticipant(id int64) []action.Action {
 		return []action.Action{action.Discard}
 	}
 
+	if g.dealerState == DealerStateStraddleRound {
+		return []action.Action{action.Straddle, action.NoStraddle}
+	}
+
 	currentBet := g.potManager.GetBet()
 
 	actions := make([]action.Action, 0)
@@ -132,36 +148,80 @@ func (p *Participant) getHandAnalyzer(community []*deck.Card) *handanalyzer.Hand
 	if p.handAnalyzerCacheKey != key {
 		p.handAnalyzerCacheKey = key
 
-		// handle the case of lazy pineapple
-		if p.cards.Len() == 3 {
-			var bestHA *handanalyzer.HandAnalyzer
-			for _, index := range [][]int{{0, 1}, {0, 2}, {1, 2}} {
-				cards := deck.Hand{p.cards[index[0]], p.cards[index[1]]}
-				hand := append(cards, community...)
-				ha := handanalyzer.New(5, hand)
-				if bestHA == nil || ha.GetStrength() > bestHA.GetStrength() {
-					bestHA = ha
-				}
-			}
+		hand := append(bestHoleCards(p.cards, community, p.rules), community...)
+		p.handAnalyzer = handanalyzer.NewWithRules(5, hand, p.rules)
+	}
 
-			p.handAnalyzer = bestHA
-		} else {
-			hand := append(p.cards, community...)
-			p.handAnalyzer = handanalyzer.New(5, hand)
+	return p.handAnalyzer
+}
+
+// bestHoleCards returns the hole cards that make the best hand with the community cards
+// In lazy pineapple, this is the best two of the three hole cards
+func bestHoleCards(cards, community []*deck.Card, rules handanalyzer.Rules) deck.Hand {
+	if len(cards) != 3 {
+		return cards
+	}
+
+	var bestHand deck.Hand
+	bestStrength := 0
+	for _, index := range [][]int{{0, 1}, {0, 2}, {1, 2}} {
+		hole := deck.Hand{cards[index[0]], cards[index[1]]}
+		if _, strength := handanalyzer.EvaluateWithRules(5, append(hole, community...), rules); bestHand == nil || strength > bestStrength {
+			bestHand = hole
+			bestStrength = strength
 		}
 	}
 
-	return p.handAnalyzer
+	return bestHand
+}
+
+// handRanks returns the name of the participant's hand on each board
+func (p *Participant) handRanks(game *Game) []string {
+	if len(p.cards) == 0 {
+		return nil
+	}
+
+	ranks := make([]string, len(game.community))
+	for i, community := range game.community {
+		ranks[i] = p.getHandAnalyzer(community).GetHand().String()
+	}
+
+	return ranks
+}
+
+// handExplanations returns a description of the participant's hand on each board
+func (p *Participant) handExplanations(game *Game) []string {
+	explanations := make([]string, len(game.community))
+	for i, community := range game.community {
+		explanations[i] = p.getHandAnalyzer(community).Explain().String()
+	}
+
+	return explanations
+}
+
+// losingReason explains why the participant lost to the best hand on each board
+func (p *Participant) losingReason(game *Game, best []*handanalyzer.HandAnalyzer) string {
+	reasons := make([]string, len(game.community))
+	for i, community := range game.community {
+		_, reasons[i] = handanalyzer.Compare(best[i], p.getHandAnalyzer(community))
+	}
+
+	return strings.Join(reasons, " / ")
 }
 
 func (p *Participant) participantJSON(game *Game, forceReveal bool) *participantJSON {
 	var cards deck.Hand
 	var handRank string
+	var handRanks []string
 	if forceReveal || (p.reveal && !p.folded) {
 		cards = p.cards
 
-		if ha := p.getHandAnalyzer(game.community); ha != nil {
-			handRank = ha.GetHand().String()
+		if ranks := p.handRanks(game); len(ranks) > 0 {
+			handRank = ranks[0]
+
+			if game.isMultiBoard() {
+				handRanks = ranks
+			}
 		}
 	} else {
 		// make a null hand
@@ -169,14 +229,16 @@ func (p *Participant) participantJSON(game *Game, forceReveal bool) *participant
 	}
 
 	return &participantJSON{
-		PlayerID: p.PlayerID,
-		Balance:  p.Balance(),
-		Cards:    cards,
-		Folded:   p.folded,
-		Bet:      p.bet,
-		HandRank: handRank,
-		Result:   p.result,
-		Winnings: p.winnings,
+		PlayerID:  p.PlayerID,
+		Balance:   p.Balance(),
+		Cards:     cards,
+		Folded:    p.folded,
+		Bet:       p.bet,
+		HandRank:  handRank,
+		HandRanks: handRanks,
+		Result:    p.result,
+		Winnings:  p.winnings,
+		Equity:    p.equity,
 	}
 }
 
@@ -118,7 +118,7 @@ func TestParticipant_getHandAnalyzer_lazyPineapple(t *testing.T) {
 }
 
 func TestParticipant_participantJSON(t *testing.T) {
-	game := &Game{community: make(deck.Hand, 0)}
+	game := &Game{community: []deck.Hand{{}}}
 	p := &Participant{
 		cards:  deck.CardsFromString("2c,3c"),
 		reveal: false,
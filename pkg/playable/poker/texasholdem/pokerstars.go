@@ -0,0 +1,301 @@
+package texasholdem
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"mondaynightpoker-server/pkg/deck"
+	"mondaynightpoker-server/pkg/playable/poker/action"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrNoHandHistory is returned when the data is not from a game of Texas Hold'em, or when the game
+// was played before hand histories were recorded
+var ErrNoHandHistory = errors.New("the game does not have a hand history")
+
+// ErrHandHistoryNotSupported is returned when the variant cannot be written in the PokerStars format
+var ErrHandHistoryNotSupported = errors.New("the hand history format does not support this variant")
+
+// HandHistory describes a completed game to export in the PokerStars hand history format
+type HandHistory struct {
+	// GameID is used as the hand number
+	GameID    int64
+	TableName string
+	Time      time.Time
+
+	// Names are the display names of the players by player ID
+	Names map[int64]string
+
+	// Hero is the player exporting the hand
+	// Only the hero's hole cards are shown unless the other players reach the showdown
+	Hero int64
+}
+
+// WritePokerStarsHandHistory writes the log of a completed game in the PokerStars hand history format
+// data is the JSON of the log that is stored when the game ends
+func WritePokerStarsHandHistory(w io.Writer, data []byte, hh HandHistory) error {
+	log, err := parseGameLog(data)
+	if err != nil {
+		return err
+	}
+
+	variant := Variant(log.Variant)
+	if variant != Standard && variant != ShortDeck {
+		return ErrHandHistoryNotSupported
+	}
+
+	psw := &pokerStarsWriter{
+		log:     log,
+		hh:      hh,
+		street:  make(map[int64]string),
+		variant: variant,
+	}
+
+	_, err = io.WriteString(w, psw.write())
+	return err
+}
+
+type pokerStarsWriter struct {
+	log     *gameLog
+	hh      HandHistory
+	variant Variant
+	sb      strings.Builder
+
+	// street is the street each participant folded on by player ID
+	street map[int64]string
+}
+
+func (p *pokerStarsWriter) line(format string, a ...interface{}) {
+	p.sb.WriteString(fmt.Sprintf(format, a...))
+	p.sb.WriteString("\n")
+}
+
+func (p *pokerStarsWriter) name(playerID int64) string {
+	if name, ok := p.hh.Names[playerID]; ok && name != "" {
+		return name
+	}
+
+	return fmt.Sprintf("Player %d", playerID)
+}
+
+func (p *pokerStarsWriter) write() string {
+	game := "Hold'em"
+	if p.variant == ShortDeck {
+		game = "6+ Hold'em"
+	}
+
+	p.line("PokerStars Hand #%d:  %s Pot Limit (%s/%s USD) - %s UTC",
+		p.hh.GameID,
+		game,
+		formatDollars(p.log.SmallBlind),
+		formatDollars(p.log.BigBlind),
+		p.hh.Time.UTC().Format("2006/01/02 15:04:05"),
+	)
+
+	// the dealer is the last participant
+	p.line("Table '%s' %d-max Seat #%d is the button", p.hh.TableName, len(p.log.Participants), len(p.log.Participants))
+	for i, pt := range p.log.Participants {
+		p.line("Seat %d: %s (%s in chips)", i+1, p.name(pt.PlayerID), formatDollars(p.log.Stacks[pt.PlayerID]))
+	}
+
+	p.writeHistory()
+	p.writeShowdown()
+	p.writeSummary()
+	return p.sb.String()
+}
+
+var streetNames = map[eventType]string{
+	eventFlop:  "Flop",
+	eventTurn:  "Turn",
+	eventRiver: "River",
+}
+
+func (p *pokerStarsWriter) writeHistory() {
+	street := "before Flop"
+	currentBet := 0
+	dealtHoleCards := false
+	dealHoleCards := func() {
+		if dealtHoleCards {
+			return
+		}
+
+		dealtHoleCards = true
+		p.line("*** HOLE CARDS ***")
+		for _, pt := range p.log.Participants {
+			if pt.PlayerID == p.hh.Hero {
+				p.line("Dealt to %s %s", p.name(pt.PlayerID), formatPokerStarsCards(pt.Cards))
+			}
+		}
+	}
+
+	var board deck.Hand
+	for _, e := range p.log.History {
+		name := p.name(e.PlayerID)
+		allIn := ""
+		if e.AllIn {
+			allIn = " and is all-in"
+		}
+
+		switch e.Type {
+		case eventAnte:
+			p.line("%s: posts the ante %s%s", name, formatDollars(e.Amount), allIn)
+		case eventSmallBlind:
+			p.line("%s: posts small blind %s%s", name, formatDollars(e.Amount), allIn)
+			currentBet = maxInt(currentBet, e.Total)
+		case eventBigBlind:
+			p.line("%s: posts big blind %s%s", name, formatDollars(e.Amount), allIn)
+			currentBet = maxInt(currentBet, e.Total)
+		case eventStraddle:
+			p.line("%s: posts straddle %s%s", name, formatDollars(e.Amount), allIn)
+			currentBet = maxInt(currentBet, e.Total)
+		case eventFlop, eventTurn, eventRiver:
+			dealHoleCards()
+			heading := strings.ToUpper(streetNames[e.Type])
+			if len(board) == 0 {
+				p.line("*** %s *** %s", heading, formatPokerStarsCards(e.Cards))
+			} else {
+				p.line("*** %s *** %s %s", heading, formatPokerStarsCards(board), formatPokerStarsCards(e.Cards))
+			}
+
+			board = append(board, e.Cards...)
+			street = "on the " + streetNames[e.Type]
+			currentBet = 0
+		case eventAction:
+			dealHoleCards()
+			switch e.Action {
+			case action.Check:
+				p.line("%s: checks", name)
+			case action.Call:
+				p.line("%s: calls %s%s", name, formatDollars(e.Amount), allIn)
+			case action.Bet:
+				p.line("%s: bets %s%s", name, formatDollars(e.Amount), allIn)
+				currentBet = e.Total
+			case action.Raise:
+				p.line("%s: raises %s to %s%s", name, formatDollars(e.Total-currentBet), formatDollars(e.Total), allIn)
+				currentBet = e.Total
+			case action.Fold:
+				p.line("%s: folds", name)
+				p.street[e.PlayerID] = street
+			}
+		}
+	}
+
+	dealHoleCards()
+}
+
+// showdown returns true if more than one participant did not fold
+func (p *pokerStarsWriter) showdown() bool {
+	remaining := 0
+	for _, pt := range p.log.Participants {
+		if !pt.Folded {
+			remaining++
+		}
+	}
+
+	return remaining > 1
+}
+
+func (p *pokerStarsWriter) writeShowdown() {
+	showdown := p.showdown()
+	if showdown {
+		p.line("*** SHOW DOWN ***")
+		for _, pt := range p.log.Participants {
+			if !pt.Folded {
+				p.line("%s: shows %s (%s)", p.name(pt.PlayerID), formatPokerStarsCards(pt.Cards), pt.HandRank)
+			}
+		}
+	}
+
+	for _, pt := range p.log.Participants {
+		if pt.Result == resultWon {
+			p.line("%s collected %s from pot", p.name(pt.PlayerID), formatDollars(pt.Winnings))
+			if !showdown {
+				p.line("%s: doesn't show hand", p.name(pt.PlayerID))
+			}
+		}
+	}
+}
+
+func (p *pokerStarsWriter) writeSummary() {
+	p.line("*** SUMMARY ***")
+	p.line("Total pot %s | Rake $0", formatDollars(p.log.Pot))
+	if len(p.log.Community) > 0 {
+		p.line("Board %s", formatPokerStarsCards(p.log.Community))
+	}
+
+	showdown := p.showdown()
+	n := len(p.log.Participants)
+	for i, pt := range p.log.Participants {
+		seat := fmt.Sprintf("Seat %d: %s", i+1, p.name(pt.PlayerID))
+		switch {
+		case n == 2 && i == 1:
+			// heads up, the button pays the small blind
+			seat += " (button) (small blind)"
+		case i == n-1:
+			seat += " (button)"
+		case n == 2 && i == 0, n > 2 && i == 1:
+			seat += " (big blind)"
+		case n > 2 && i == 0:
+			seat += " (small blind)"
+		}
+
+		switch {
+		case pt.Folded:
+			street, ok := p.street[pt.PlayerID]
+			if !ok {
+				street = "before Flop"
+			}
+
+			p.line("%s folded %s", seat, street)
+		case !showdown:
+			p.line("%s collected (%s)", seat, formatDollars(pt.Winnings))
+		case pt.Result == resultWon:
+			p.line("%s showed %s and won (%s) with %s", seat, formatPokerStarsCards(pt.Cards), formatDollars(pt.Winnings), pt.HandRank)
+		default:
+			p.line("%s showed %s and lost with %s", seat, formatPokerStarsCards(pt.Cards), pt.HandRank)
+		}
+	}
+}
+
+// formatDollars formats an amount in cents, i.e., $1.25
+func formatDollars(cents int) string {
+	sign := ""
+	if cents < 0 {
+		sign = "-"
+		cents = -cents
+	}
+
+	return fmt.Sprintf("%s$%d.%02d", sign, cents/100, cents%100)
+}
+
+// formatPokerStarsCards formats the cards, i.e., [Ah Td]
+func formatPokerStarsCards(cards deck.Hand) string {
+	s := make([]string, len(cards))
+	for i, card := range cards {
+		s[i] = formatPokerStarsCard(card)
+	}
+
+	return "[" + strings.Join(s, " ") + "]"
+}
+
+func formatPokerStarsCard(card *deck.Card) string {
+	var rank string
+	switch card.Rank {
+	case deck.Ace, deck.LowAce:
+		rank = "A"
+	case deck.King:
+		rank = "K"
+	case deck.Queen:
+		rank = "Q"
+	case deck.Jack:
+		rank = "J"
+	case 10:
+		rank = "T"
+	default:
+		rank = strconv.Itoa(card.Rank)
+	}
+
+	return rank + string(card.Suit)[:1]
+}
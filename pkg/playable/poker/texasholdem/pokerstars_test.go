@@ -0,0 +1,160 @@
+package texasholdem
+
+import (
+	"encoding/json"
+	"mondaynightpoker-server/pkg/deck"
+	"mondaynightpoker-server/pkg/playable/poker/action"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWritePokerStarsHandHistory(t *testing.T) {
+	a := assert.New(t)
+
+	game := setupNewGame(DefaultOptions(), 1000, 1000, 1000)
+	assertTick(t, game)
+
+	game.participants[1].cards = deck.CardsFromString("2c,3d")
+	game.participants[2].cards = deck.CardsFromString("13h,12d")
+	game.participants[3].cards = deck.CardsFromString("14c,14d")
+	game.deck.Cards = deck.CardsFromString("13s,9c,5h,4d,2s")
+
+	assertTickFromWaiting(t, game, DealerStatePreFlopBettingRound)
+	assertActionAndAmount(t, game, 3, action.Raise, 100)
+	assertAction(t, game, 1, action.Fold)
+	assertAction(t, game, 2, action.Call)
+	assertTickFromWaiting(t, game, DealerStateDealFlop)
+
+	assertTick(t, game)
+	assertAction(t, game, 2, action.Check)
+	assertActionAndAmount(t, game, 3, action.Bet, 100)
+	assertAction(t, game, 2, action.Call)
+	assertTickFromWaiting(t, game, DealerStateDealTurn)
+
+	for _, next := range []DealerState{DealerStateDealRiver, DealerStateRevealWinner} {
+		assertTick(t, game)
+		assertAction(t, game, 2, action.Check)
+		assertAction(t, game, 3, action.Check)
+		assertTickFromWaiting(t, game, next)
+	}
+
+	assertTick(t, game)
+	assertTickFromWaiting(t, game, DealerStateEnd)
+	assertTick(t, game)
+
+	expected := `PokerStars Hand #42:  Hold'em Pot Limit ($0.25/$0.50 USD) - 2026/10/18 20:00:00 UTC
+Table 'Monday Night' 3-max Seat #3 is the button
+Seat 1: Alice ($10.00 in chips)
+Seat 2: Bob ($10.00 in chips)
+Seat 3: Carol ($10.00 in chips)
+Alice: posts the ante $0.25
+Bob: posts the ante $0.25
+Carol: posts the ante $0.25
+Alice: posts small blind $0.25
+Bob: posts big blind $0.50
+*** HOLE CARDS ***
+Dealt to Carol [Ac Ad]
+Carol: raises $0.50 to $1.00
+Alice: folds
+Bob: calls $0.50
+*** FLOP *** [Ks 9c 5h]
+Bob: checks
+Carol: bets $1.00
+Bob: calls $1.00
+*** TURN *** [Ks 9c 5h] [4d]
+Bob: checks
+Carol: checks
+*** RIVER *** [Ks 9c 5h 4d] [2s]
+Bob: checks
+Carol: checks
+*** SHOW DOWN ***
+Bob: shows [Kh Qd] (Pair)
+Carol: shows [Ac Ad] (Pair)
+Carol collected $5.00 from pot
+*** SUMMARY ***
+Total pot $5.00 | Rake $0
+Board [Ks 9c 5h 4d 2s]
+Seat 1: Alice (small blind) folded before Flop
+Seat 2: Bob (big blind) showed [Kh Qd] and lost with Pair
+Seat 3: Carol (button) showed [Ac Ad] and won ($5.00) with Pair
+`
+
+	a.Equal(expected, writeHandHistory(t, game, 3))
+}
+
+func TestWritePokerStarsHandHistory_noShowdown(t *testing.T) {
+	a := assert.New(t)
+
+	game := setupNewGame(DefaultOptions(), 1000, 1000)
+	assertTick(t, game)
+
+	game.participants[1].cards = deck.CardsFromString("2c,3d")
+	game.participants[2].cards = deck.CardsFromString("13h,12d")
+
+	assertTickFromWaiting(t, game, DealerStatePreFlopBettingRound)
+	assertActionAndAmount(t, game, 2, action.Raise, 100)
+	assertAction(t, game, 1, action.Fold)
+	assertTickFromWaiting(t, game, DealerStateRevealWinner)
+	assertTick(t, game)
+	assertTickFromWaiting(t, game, DealerStateEnd)
+	assertTick(t, game)
+
+	hh := writeHandHistory(t, game, 1)
+	a.Contains(hh, "Bob: posts small blind $0.25\nAlice: posts big blind $0.50\n")
+	a.Contains(hh, "Dealt to Alice [2c 3d]\n")
+	a.NotContains(hh, "Kh Qd")
+	a.NotContains(hh, "*** SHOW DOWN ***")
+	a.Contains(hh, "Bob: raises $0.50 to $1.00\nAlice: folds\n")
+	a.Contains(hh, "Bob collected $2.00 from pot\nBob: doesn't show hand\n")
+	a.Contains(hh, "Seat 1: Alice (big blind) folded before Flop\n")
+	a.Contains(hh, "Seat 2: Bob (button) (small blind) collected ($2.00)\n")
+	a.NotContains(hh, "Board")
+}
+
+func TestWritePokerStarsHandHistory_errors(t *testing.T) {
+	a := assert.New(t)
+
+	hh := HandHistory{GameID: 1}
+	a.Equal(ErrNoHandHistory, WritePokerStarsHandHistory(&strings.Builder{}, []byte(`{"participants":[]}`), hh))
+	a.Equal(ErrNoHandHistory, WritePokerStarsHandHistory(&strings.Builder{}, []byte(`{"participants":{"1":true}}`), hh))
+	a.Error(WritePokerStarsHandHistory(&strings.Builder{}, []byte(`{`), hh))
+
+	opts := DefaultOptions()
+	opts.Variant = Pineapple
+	game := setupNewGame(opts, 1000, 1000)
+	data, err := json.Marshal(game.gameLog())
+	a.NoError(err)
+	a.Equal(ErrHandHistoryNotSupported, WritePokerStarsHandHistory(&strings.Builder{}, data, hh))
+}
+
+func TestFormatDollars(t *testing.T) {
+	a := assert.New(t)
+	a.Equal("$0.05", formatDollars(5))
+	a.Equal("$1.25", formatDollars(125))
+	a.Equal("$10.00", formatDollars(1000))
+	a.Equal("-$0.50", formatDollars(-50))
+}
+
+func writeHandHistory(t *testing.T, game *Game, hero int64) string {
+	t.Helper()
+
+	details, ok := game.GetEndOfGameDetails()
+	assert.True(t, ok)
+
+	data, err := json.Marshal(details.Log)
+	assert.NoError(t, err)
+
+	var sb strings.Builder
+	assert.NoError(t, WritePokerStarsHandHistory(&sb, data, HandHistory{
+		GameID:    42,
+		TableName: "Monday Night",
+		Time:      time.Date(2026, 10, 18, 20, 0, 0, 0, time.UTC),
+		Names:     map[int64]string{1: "Alice", 2: "Bob", 3: "Carol"},
+		Hero:      hero,
+	}))
+
+	return sb.String()
+}
@@ -0,0 +1,88 @@
+package texasholdem
+
+import (
+	"github.com/stretchr/testify/assert"
+	"mondaynightpoker-server/pkg/deck"
+	"mondaynightpoker-server/pkg/playable/poker/action"
+	"testing"
+)
+
+func TestGame__shortDeck(t *testing.T) {
+	a := assert.New(t)
+
+	opts := DefaultOptions()
+	opts.Variant = ShortDeck
+
+	game := setupNewGame(opts, 1000, 1000)
+	a.Equal("Short-Deck Texas Hold'em (${25}/${50})", game.Name())
+	a.Equal(36, len(game.deck.Cards))
+	for _, card := range game.deck.Cards {
+		a.GreaterOrEqual(card.Rank, 6)
+	}
+}
+
+func TestGame__shortDeck_flushBeatsFullHouse(t *testing.T) {
+	assertShortDeck(t, "13c,6c", "14d,14h", "14c,10c,7c,10d,12s", func(p1Adj int, p1Hand string, p2Adj int, p2Hand string) {
+		assert.Equal(t, 75, p1Adj)
+		assert.Equal(t, -75, p2Adj)
+		assert.Equal(t, "Flush", p1Hand)
+		assert.Equal(t, "Full house", p2Hand)
+	})
+}
+
+func TestGame__shortDeck_lowStraight(t *testing.T) {
+	assertShortDeck(t, "14c,6d", "13d,13h", "7c,8h,9s,11d,12s", func(p1Adj int, p1Hand string, p2Adj int, p2Hand string) {
+		assert.Equal(t, 75, p1Adj)
+		assert.Equal(t, -75, p2Adj)
+		assert.Equal(t, "Straight", p1Hand)
+		assert.Equal(t, "Pair", p2Hand)
+	})
+}
+
+func assertShortDeck(t *testing.T, p1, p2, community string, callback func(p1Adj int, p1Hand string, p2Adj int, p2Hand string)) {
+	t.Helper()
+
+	a := assert.New(t)
+
+	opts := DefaultOptions()
+	opts.Variant = ShortDeck
+
+	game := setupNewGame(opts, 1000, 1000)
+	assertTick(t, game)
+
+	game.participants[1].cards = deck.CardsFromString(p1)
+	game.participants[2].cards = deck.CardsFromString(p2)
+	game.deck.Cards = deck.CardsFromString(community)
+
+	assertTickFromWaiting(t, game, DealerStatePreFlopBettingRound)
+
+	{
+		assertAction(t, game, 2, action.Call)
+		assertAction(t, game, 1, action.Check)
+
+		assertTickFromWaiting(t, game, DealerStateDealFlop)
+	}
+
+	for _, next := range []DealerState{DealerStateDealTurn, DealerStateDealRiver, DealerStateRevealWinner} {
+		assertTick(t, game)
+		assertAction(t, game, 1, action.Check)
+		assertAction(t, game, 2, action.Check)
+
+		assertTickFromWaiting(t, game, next)
+	}
+
+	{
+		assertTick(t, game)
+		assertTickFromWaiting(t, game, DealerStateEnd)
+		assertTick(t, game)
+		details, ok := game.GetEndOfGameDetails()
+		a.True(ok)
+
+		callback(
+			details.BalanceAdjustments[1],
+			game.participants[1].handAnalyzer.GetHand().String(),
+			details.BalanceAdjustments[2],
+			game.participants[2].handAnalyzer.GetHand().String(),
+		)
+	}
+}
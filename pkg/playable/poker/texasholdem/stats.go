@@ -0,0 +1,79 @@
+package texasholdem
+
+import "mondaynightpoker-server/pkg/playable/poker/action"
+
+// HandStats are the statistics of a participant in a single hand
+type HandStats struct {
+	// Showdown is true if the participant had not folded when the winner was revealed
+	// against at least one other participant
+	Showdown bool
+	// WonShowdown is true if the participant went to showdown and won at least part of the pot
+	WonShowdown bool
+	// Winnings is the amount the participant collected from the pot
+	Winnings int
+
+	// VPIP is true if the participant voluntarily put money into the pot before the flop
+	VPIP bool
+	// PFR is true if the participant bet or raised before the flop
+	PFR bool
+
+	BetsRaises int
+	Calls      int
+}
+
+// HandStatsFromLog returns the statistics of each participant in the hand by player ID
+// The data is the log that was stored when the game ended
+// ErrNoHandHistory is returned if the data is not from a game of Texas Hold'em
+func HandStatsFromLog(data []byte) (map[int64]*HandStats, error) {
+	log, err := parseGameLog(data)
+	if err != nil {
+		return nil, err
+	}
+
+	stats := make(map[int64]*HandStats, len(log.Participants))
+	inHand := 0
+	for _, p := range log.Participants {
+		stats[p.PlayerID] = &HandStats{Winnings: p.Winnings}
+		if !p.Folded {
+			inHand++
+		}
+	}
+
+	if inHand > 1 {
+		for _, p := range log.Participants {
+			if !p.Folded {
+				stats[p.PlayerID].Showdown = true
+				stats[p.PlayerID].WonShowdown = p.Result == resultWon
+			}
+		}
+	}
+
+	preFlop := true
+	for _, e := range log.History {
+		if e.Type == eventFlop {
+			preFlop = false
+			continue
+		}
+
+		if e.Type != eventAction {
+			continue
+		}
+
+		s, ok := stats[e.PlayerID]
+		if !ok {
+			continue
+		}
+
+		switch e.Action {
+		case action.Call:
+			s.Calls++
+			s.VPIP = s.VPIP || preFlop
+		case action.Bet, action.Raise:
+			s.BetsRaises++
+			s.VPIP = s.VPIP || preFlop
+			s.PFR = s.PFR || preFlop
+		}
+	}
+
+	return stats, nil
+}
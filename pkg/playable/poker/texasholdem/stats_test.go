@@ -0,0 +1,85 @@
+package texasholdem
+
+import (
+	"encoding/json"
+	"mondaynightpoker-server/pkg/deck"
+	"mondaynightpoker-server/pkg/playable/poker/action"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHandStatsFromLog(t *testing.T) {
+	a := assert.New(t)
+
+	game := setupNewGame(DefaultOptions(), 1000, 1000, 1000)
+	assertTick(t, game)
+
+	game.participants[1].cards = deck.CardsFromString("2c,3d")
+	game.participants[2].cards = deck.CardsFromString("13h,12d")
+	game.participants[3].cards = deck.CardsFromString("14c,14d")
+	game.deck.Cards = deck.CardsFromString("13s,9c,5h,4d,2s")
+
+	assertTickFromWaiting(t, game, DealerStatePreFlopBettingRound)
+	assertActionAndAmount(t, game, 3, action.Raise, 100)
+	assertAction(t, game, 1, action.Fold)
+	assertAction(t, game, 2, action.Call)
+	assertTickFromWaiting(t, game, DealerStateDealFlop)
+
+	assertTick(t, game)
+	assertAction(t, game, 2, action.Check)
+	assertActionAndAmount(t, game, 3, action.Bet, 100)
+	assertAction(t, game, 2, action.Call)
+	assertTickFromWaiting(t, game, DealerStateDealTurn)
+
+	for _, next := range []DealerState{DealerStateDealRiver, DealerStateRevealWinner} {
+		assertTick(t, game)
+		assertAction(t, game, 2, action.Check)
+		assertAction(t, game, 3, action.Check)
+		assertTickFromWaiting(t, game, next)
+	}
+
+	assertTick(t, game)
+	assertTickFromWaiting(t, game, DealerStateEnd)
+	assertTick(t, game)
+
+	details, ok := game.GetEndOfGameDetails()
+	a.True(ok)
+	data, err := json.Marshal(details.Log)
+	a.NoError(err)
+
+	stats, err := HandStatsFromLog(data)
+	a.NoError(err)
+	a.Equal(map[int64]*HandStats{
+		1: {},
+		2: {Showdown: true, VPIP: true, Calls: 2},
+		3: {Showdown: true, WonShowdown: true, Winnings: 500, VPIP: true, PFR: true, BetsRaises: 2},
+	}, stats)
+}
+
+func TestHandStatsFromLog_noShowdown(t *testing.T) {
+	a := assert.New(t)
+
+	game := setupNewGame(DefaultOptions(), 1000, 1000)
+	assertTick(t, game)
+	assertTickFromWaiting(t, game, DealerStatePreFlopBettingRound)
+	assertActionAndAmount(t, game, 2, action.Raise, 100)
+	assertAction(t, game, 1, action.Fold)
+	assertTickFromWaiting(t, game, DealerStateRevealWinner)
+	assertTick(t, game)
+	assertTickFromWaiting(t, game, DealerStateEnd)
+	assertTick(t, game)
+
+	details, _ := game.GetEndOfGameDetails()
+	data, _ := json.Marshal(details.Log)
+
+	stats, err := HandStatsFromLog(data)
+	a.NoError(err)
+	a.False(stats[2].Showdown)
+	a.False(stats[2].WonShowdown)
+	a.True(stats[2].PFR)
+	a.False(stats[1].VPIP)
+
+	_, err = HandStatsFromLog([]byte(`{"participants":{"1":true}}`))
+	a.Equal(ErrNoHandHistory, err)
+}
@@ -0,0 +1,169 @@
+package texasholdem
+
+import (
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"mondaynightpoker-server/pkg/playable/poker/action"
+	"testing"
+)
+
+func TestGame__straddle(t *testing.T) {
+	a := assert.New(t)
+
+	opts := DefaultOptions()
+	opts.Straddle = true
+
+	game := setupNewGame(opts, 1000, 1000, 1000, 1000)
+	a.Equal("Texas Hold'em (${25}/${50}) with straddle", game.Name())
+
+	assertTick(t, game)
+	a.Equal(DealerStateStraddleRound, game.dealerState)
+
+	turn, err := game.GetCurrentTurn()
+	a.NoError(err)
+	a.Equal(int64(3), turn.PlayerID)
+	a.Equal([]action.Action{action.Straddle, action.NoStraddle}, game.ActionsForParticipant(3))
+	a.Nil(game.ActionsForParticipant(1))
+	assertActionFailed(t, game, 1, action.Straddle, "you cannot perform straddle")
+
+	assertAction(t, game, 3, action.Straddle)
+	a.Equal(DealerStateStart, game.dealerState)
+
+	assertTick(t, game)
+	assertTickFromWaiting(t, game, DealerStatePreFlopBettingRound)
+	a.Equal(100, game.participants[3].bet)
+	a.Equal(100, game.potManager.GetBet())
+
+	// the action starts after the straddle, and the straddler acts last
+	assertAction(t, game, 4, action.Call)
+	assertAction(t, game, 1, action.Call)
+	assertAction(t, game, 2, action.Call)
+	a.Equal([]action.Action{action.Check, action.Raise, action.Fold}, game.ActionsForParticipant(3))
+	assertAction(t, game, 3, action.Check)
+	assertTickFromWaiting(t, game, DealerStateDealFlop)
+	a.Equal(500, game.potManager.Pots().Total())
+
+	found := false
+	for _, e := range game.history {
+		if e.Type == eventStraddle {
+			found = true
+			a.Equal(int64(3), e.PlayerID)
+			a.Equal(100, e.Amount)
+		}
+	}
+	a.True(found)
+}
+
+func TestGame__noStraddle(t *testing.T) {
+	a := assert.New(t)
+
+	opts := DefaultOptions()
+	opts.Straddle = true
+
+	game := setupNewGame(opts, 1000, 1000, 1000)
+	assertTick(t, game)
+	assertAction(t, game, 3, action.NoStraddle)
+
+	assertTick(t, game)
+	assertTickFromWaiting(t, game, DealerStatePreFlopBettingRound)
+	a.Equal(50, game.potManager.GetBet())
+
+	turn, err := game.GetCurrentTurn()
+	a.NoError(err)
+	a.Equal(int64(3), turn.PlayerID)
+
+	// heads up, there is no straddle
+	game = setupNewGame(opts, 1000, 1000)
+	assertTick(t, game)
+	assertTickFromWaiting(t, game, DealerStatePreFlopBettingRound)
+}
+
+func TestGame__shortStraddle(t *testing.T) {
+	a := assert.New(t)
+
+	opts := DefaultOptions()
+	opts.Straddle = true
+
+	// Carol has ${75} left after the ante and straddles all-in
+	game := setupNewGame(opts, 1000, 1000, 100, 1000)
+	assertTick(t, game)
+	assertAction(t, game, 3, action.Straddle)
+	a.Equal(75, game.lastAction.Amount)
+
+	assertTick(t, game)
+	assertTickFromWaiting(t, game, DealerStatePreFlopBettingRound)
+	a.Equal(75, game.participants[3].bet)
+	a.Equal(75, game.potManager.GetBet())
+	a.Equal(0, game.participants[3].Balance())
+
+	// Carol has nothing left after the ante, so there is no straddle round
+	game = setupNewGame(opts, 1000, 1000, 25, 1000)
+	assertTick(t, game)
+	assertTickFromWaiting(t, game, DealerStatePreFlopBettingRound)
+	a.Equal(50, game.potManager.GetBet())
+}
+
+func TestGame__bombPot(t *testing.T) {
+	a := assert.New(t)
+
+	opts := DefaultOptions()
+	opts.Straddle = true
+	opts.BombPot = 100
+
+	game := setupNewGame(opts, 1000, 1000, 1000)
+	a.Equal("Texas Hold'em (${25}/${50}), ${100} bomb pot", game.Name())
+	for _, p := range game.participantOrder {
+		a.Equal(-100, p.balance)
+	}
+
+	// there is no straddle or pre-flop betting in a bomb pot
+	assertTick(t, game)
+	assertTickFromWaiting(t, game, DealerStateDealFlop)
+	a.Equal(300, game.potManager.Pots().Total())
+
+	assertTick(t, game)
+	a.Equal(DealerStateFlopBettingRound, game.dealerState)
+	a.Len(game.community[0], 3)
+
+	turn, err := game.GetCurrentTurn()
+	a.NoError(err)
+	a.Equal(int64(1), turn.PlayerID)
+	a.Equal([]action.Action{action.Check, action.Bet, action.Fold}, game.ActionsForParticipant(1))
+}
+
+func TestGame__bombPotOptions(t *testing.T) {
+	a := assert.New(t)
+
+	opts := DefaultOptions()
+	opts.BombPot = 525
+	_, err := NewGame(logrus.StandardLogger(), setupParticipants(1000, 1000), opts)
+	a.EqualError(err, "bomb pot must be at most ${500}")
+
+	opts.BombPot = 110
+	_, err = NewGame(logrus.StandardLogger(), setupParticipants(1000, 1000), opts)
+	a.EqualError(err, "bomb pot must be in increments of ${25}")
+}
+
+func TestWritePokerStarsHandHistory_straddle(t *testing.T) {
+	a := assert.New(t)
+
+	opts := DefaultOptions()
+	opts.Straddle = true
+
+	game := setupNewGame(opts, 1000, 1000, 1000)
+	assertTick(t, game)
+	assertAction(t, game, 3, action.Straddle)
+	assertTick(t, game)
+	assertTickFromWaiting(t, game, DealerStatePreFlopBettingRound)
+	assertActionAndAmount(t, game, 1, action.Raise, 200)
+	assertAction(t, game, 2, action.Fold)
+	assertAction(t, game, 3, action.Fold)
+	assertTickFromWaiting(t, game, DealerStateRevealWinner)
+	assertTick(t, game)
+	assertTickFromWaiting(t, game, DealerStateEnd)
+	assertTick(t, game)
+
+	hh := writeHandHistory(t, game, 1)
+	a.Contains(hh, "Bob: posts big blind $0.50\nCarol: posts straddle $1.00\n*** HOLE CARDS ***\n")
+	a.Contains(hh, "Alice: raises $1.00 to $2.00\n")
+}
@@ -7,6 +7,7 @@ import (
 	"mondaynightpoker-server/pkg/deck"
 	"mondaynightpoker-server/pkg/playable"
 	"mondaynightpoker-server/pkg/playable/poker/action"
+	"mondaynightpoker-server/pkg/playable/poker/handanalyzer"
 	"mondaynightpoker-server/pkg/playable/poker/potmanager"
 	"time"
 )
@@ -16,6 +17,7 @@ const anteMax = 50
 const smallBlindMin = 0
 const smallBlindMax = 100
 const bigBlindMax = 200
+const bombPotMax = 500
 
 type lastAction struct {
 	Action   action.Action `json:"action"`
@@ -33,8 +35,16 @@ type Game struct {
 	pendingDealerState *pendingDealerState
 	potManager         *potmanager.PotManager
 	lastAction         *lastAction
-	community          deck.Hand
-	logChan            chan []*playable.LogMessage
+	// community contains the community cards for each board
+	community []deck.Hand
+	logChan   chan []*playable.LogMessage
+
+	// history contains every ante, blind, action and deal in order
+	history []*event
+
+	// straddleOffered is true once the participant after the big blind decided whether to straddle
+	straddleOffered bool
+	straddler       *Participant
 
 	// if true, GetEndOfGameDetails() returns
 	finished bool
@@ -46,6 +56,22 @@ type Options struct {
 	Ante       int
 	SmallBlind int
 	BigBlind   int
+
+	// Straddle lets the participant after the big blind post a straddle of twice the big blind
+	Straddle bool
+
+	// BombPot is the amount every participant antes in a bomb pot
+	// A bomb pot has no blinds, and the betting starts on the flop
+	BombPot int
+}
+
+// ante returns the amount every participant antes
+func (o Options) ante() int {
+	if o.BombPot > 0 {
+		return o.BombPot
+	}
+
+	return o.Ante
 }
 
 // DefaultOptions returns the default options for Texas Hold'em
@@ -60,7 +86,7 @@ func DefaultOptions() Options {
 
 // NewGame returns a new game of Texas Hold'em
 func NewGame(_ logrus.FieldLogger, players []playable.Player, opts Options) (*Game, error) {
-	if err := validateOptions(opts); err != nil {
+	if err := ValidateOptions(opts); err != nil {
 		return nil, err
 	}
 
@@ -68,7 +94,10 @@ func NewGame(_ logrus.FieldLogger, players []playable.Player, opts Options) (*Ga
 		return nil, errors.New("there must be at least two players")
 	}
 
-	d := deck.New()
+	d, err := deck.NewFromSpec(opts.Variant.DeckSpec())
+	if err != nil {
+		return nil, err
+	}
 	d.Shuffle()
 
 	participants := make(map[int64]*Participant)
@@ -77,38 +106,68 @@ func NewGame(_ logrus.FieldLogger, players []playable.Player, opts Options) (*Ga
 	logs := make([]*playable.LogMessage, 0)
 	logs = append(logs, playable.SimpleLogMessage(0, "started a new game of %s", NameFromOptions(opts)))
 
-	mgr := potmanager.New(opts.Ante)
+	ante := opts.ante()
+	mgr := potmanager.New(ante)
 	for i, player := range players {
 		id := player.GetPlayerID()
 		p := newParticipant(id, player.GetTableStake())
+		p.rules = opts.Variant.Rules()
 		if err := mgr.SeatParticipant(p); err != nil {
 			return nil, err
 		}
 
 		participants[id] = p
 		participantOrder[i] = p
-		logs = append(logs, playable.SimpleLogMessage(id, "{} paid the ante of ${%d}", opts.Ante))
+		if opts.BombPot > 0 {
+			logs = append(logs, playable.SimpleLogMessage(id, "{} paid ${%d} into the bomb pot", ante))
+		} else {
+			logs = append(logs, playable.SimpleLogMessage(id, "{} paid the ante of ${%d}", ante))
+		}
 	}
 	mgr.FinishSeatingParticipants()
 
+	community := make([]deck.Hand, opts.Variant.Boards())
+	for i := range community {
+		community[i] = make(deck.Hand, 0, 5)
+	}
+
 	lc := make(chan []*playable.LogMessage, 256)
 	lc <- logs
 
-	return &Game{
+	g := &Game{
 		options:            opts,
 		deck:               d,
 		participants:       participants,
 		participantOrder:   participantOrder,
 		dealerState:        DealerStateStart,
 		pendingDealerState: nil,
-		community:          make(deck.Hand, 0, 5),
+		community:          community,
 		logChan:            lc,
 		potManager:         mgr,
-	}, nil
+	}
+
+	for _, p := range participantOrder {
+		if p.balance < 0 {
+			g.recordPayment(eventAnte, p, -p.balance)
+		}
+	}
+
+	return g, nil
 }
 
 func (g *Game) payBlinds() {
-	sb, bb := g.potManager.PayBlinds(g.options.SmallBlind, g.options.BigBlind)
+	var sb, bb, straddle potmanager.Participant
+	if g.straddler != nil {
+		sb, bb, straddle = g.potManager.PayBlindsAndStraddle(g.options.SmallBlind, g.options.BigBlind, g.straddleAmount())
+	} else {
+		sb, bb = g.potManager.PayBlinds(g.options.SmallBlind, g.options.BigBlind)
+	}
+
+	g.recordPayment(eventSmallBlind, g.participants[sb.ID()], g.participants[sb.ID()].bet)
+	g.recordPayment(eventBigBlind, g.participants[bb.ID()], g.participants[bb.ID()].bet)
+	if straddle != nil {
+		g.recordPayment(eventStraddle, g.participants[straddle.ID()], g.participants[straddle.ID()].bet)
+	}
 
 	logs := make([]*playable.LogMessage, 2)
 	logs[0] = playable.SimpleLogMessage(sb.ID(), "{} paid the small blind of ${%d}", g.options.SmallBlind)
@@ -117,6 +176,44 @@ func (g *Game) payBlinds() {
 	g.logChan <- logs
 }
 
+// canStraddle returns true if the participant after the big blind can straddle
+// There is no straddle in a bomb pot, heads up, or when the participant has nothing left after the ante
+func (g *Game) canStraddle() bool {
+	return g.options.Straddle && g.options.BombPot == 0 && len(g.participantOrder) > 2 && g.straddleParticipant().Balance() > 0
+}
+
+// straddleParticipant returns the participant who decides whether to straddle
+func (g *Game) straddleParticipant() *Participant {
+	return g.participantOrder[2]
+}
+
+func (g *Game) straddleAmount() int {
+	return g.options.BigBlind * 2
+}
+
+// straddleDecision records whether the participant after the big blind straddles
+// The straddle is paid with the blinds
+func (g *Game) straddleDecision(p *Participant, a action.Action) {
+	if a == action.Straddle {
+		g.straddler = p
+	}
+
+	// a short-stacked straddler posts what they have left
+	amount := g.straddleAmount()
+	if balance := p.Balance(); balance < amount {
+		amount = balance
+	}
+
+	g.lastAction = &lastAction{
+		Action:   a,
+		PlayerID: p.PlayerID,
+		Amount:   amount,
+	}
+
+	g.dealerState = DealerStateStart
+	g.logChan <- playable.SimpleLogMessageSlice(p.PlayerID, "{} %s", a.LogMessage(amount))
+}
+
 func (g *Game) dealStartingCardsToEachParticipant() error {
 	if g.dealerState != DealerStateStart {
 		return fmt.Errorf("cannot deal cards from state %d", g.dealerState)
@@ -164,7 +261,8 @@ func validateAmount(desc string, number, minVal, maxVal int) error {
 	return nil
 }
 
-func validateOptions(opts Options) error {
+// ValidateOptions returns an error if the options are not valid
+func ValidateOptions(opts Options) error {
 	if _, ok := validVariants[opts.Variant]; !ok {
 		return fmt.Errorf("invalid variant %s", opts.Variant)
 	}
@@ -181,12 +279,20 @@ func validateOptions(opts Options) error {
 		return err
 	}
 
+	if err := validateAmount("bomb pot", opts.BombPot, 0, bombPotMax); err != nil {
+		return err
+	}
+
 	return nil
 }
 
 // GetCurrentTurn returns the participant who is currently making a decision
 // Returns an error unless the game is in a betting round
 func (g *Game) GetCurrentTurn() (*Participant, error) {
+	if g.dealerState == DealerStateStraddleRound {
+		return g.straddleParticipant(), nil
+	}
+
 	if !g.InDecisionRound() {
 		return nil, errors.New("not in a betting round")
 	}
@@ -223,3 +329,26 @@ func (g *Game) newRoundSetup() {
 		p.NewRound()
 	}
 }
+
+// isMultiBoard returns true if the game is played with more than one community board
+func (g *Game) isMultiBoard() bool {
+	return len(g.community) > 1
+}
+
+// bestHandAnalyzers returns the best hand on each board of the participants who did not fold
+func (g *Game) bestHandAnalyzers() []*handanalyzer.HandAnalyzer {
+	best := make([]*handanalyzer.HandAnalyzer, len(g.community))
+	for _, p := range g.participantOrder {
+		if p.folded {
+			continue
+		}
+
+		for i, community := range g.community {
+			if ha := p.getHandAnalyzer(community); best[i] == nil || ha.GetStrength() > best[i].GetStrength() {
+				best[i] = ha
+			}
+		}
+	}
+
+	return best
+}
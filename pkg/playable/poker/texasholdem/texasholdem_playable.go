@@ -10,6 +10,7 @@ import (
 	"mondaynightpoker-server/pkg/playable"
 	"mondaynightpoker-server/pkg/playable/poker/action"
 	"mondaynightpoker-server/pkg/playable/poker/potmanager"
+	"strings"
 	"time"
 )
 
@@ -36,7 +37,13 @@ func (g *Game) Action(playerID int64, message *playable.PayloadIn) (playerRespon
 		return nil, false, fmt.Errorf("you cannot perform %s", message.Action)
 	}
 
+	if g.dealerState == DealerStateStraddleRound {
+		g.straddleDecision(p, foundAction)
+		return playable.OK(), true, nil
+	}
+
 	amount, _ := message.AdditionalData.GetInt("amount")
+	previousBet := p.bet
 
 	switch foundAction {
 	case action.Discard:
@@ -74,6 +81,12 @@ func (g *Game) Action(playerID int64, message *playable.PayloadIn) (playerRespon
 		}
 	}
 
+	var discarded deck.Hand
+	if foundAction == action.Discard {
+		discarded = message.Cards
+	}
+	g.recordAction(p, foundAction, previousBet, discarded)
+
 	g.lastAction = &lastAction{
 		Action:   foundAction,
 		PlayerID: p.PlayerID,
@@ -81,6 +94,7 @@ func (g *Game) Action(playerID int64, message *playable.PayloadIn) (playerRespon
 	}
 
 	if g.potManager.IsRoundOver() && g.pendingDealerState == nil {
+		g.checkAllInShowdown()
 		g.setPendingDealerState(DealerState(int(g.dealerState)+1), time.Second*1)
 	}
 
@@ -122,7 +136,7 @@ func (g *Game) Name() string {
 
 // NameFromOptions returns the name from the provided options
 func NameFromOptions(opts Options) string {
-	if err := validateOptions(opts); err != nil {
+	if err := ValidateOptions(opts); err != nil {
 		return ""
 	}
 
@@ -134,9 +148,22 @@ func NameFromOptions(opts Options) string {
 		name = "Pineapple"
 	case LazyPineapple:
 		name = "Lazy Pineapple"
+	case DoubleBoard:
+		name = "Double-Board Texas Hold'em"
+	case ShortDeck:
+		name = "Short-Deck Texas Hold'em"
 	}
 
-	return fmt.Sprintf("%s (${%d}/${%d})", name, opts.SmallBlind, opts.BigBlind)
+	name = fmt.Sprintf("%s (${%d}/${%d})", name, opts.SmallBlind, opts.BigBlind)
+	if opts.BombPot > 0 {
+		return fmt.Sprintf("%s, ${%d} bomb pot", name, opts.BombPot)
+	}
+
+	if opts.Straddle {
+		return name + " with straddle"
+	}
+
+	return name
 }
 
 // LogChan returns a channel log messages must be sent on
@@ -156,7 +183,12 @@ func (g *Game) endGame() error {
 
 	g.potManager.EndGame()
 
-	wm := potmanager.NewWinManager()
+	// each board has its own winners, and the pot is split evenly between the boards
+	wms := make([]potmanager.WinManager, len(g.community))
+	for i := range wms {
+		wms[i] = potmanager.NewWinManager()
+	}
+
 	for _, p := range g.participantOrder {
 		if p.folded {
 			p.result = resultFolded
@@ -166,11 +198,18 @@ func (g *Game) endGame() error {
 		p.result = resultLost
 		p.reveal = true
 
-		strength := p.getHandAnalyzer(g.community).GetStrength()
-		wm.AddParticipant(p, strength)
+		for i, community := range g.community {
+			strength := p.getHandAnalyzer(community).GetStrength()
+			wms[i].AddParticipant(p, strength)
+		}
+	}
+
+	tiers := make([][][]potmanager.Participant, len(wms))
+	for i, wm := range wms {
+		tiers[i] = wm.GetSortedTiers()
 	}
 
-	winners, err := g.potManager.PayWinners(wm.GetSortedTiers())
+	winners, err := g.potManager.PaySplitWinners(tiers)
 	if err != nil {
 		return err
 	}
@@ -181,11 +220,12 @@ func (g *Game) endGame() error {
 		pt.winnings = amt
 	}
 
+	best := g.bestHandAnalyzers()
 	logs := make([]*playable.LogMessage, 0, len(g.participantOrder))
 	for _, p := range g.participantOrder {
 		pid := p.ID()
 
-		hand := p.getHandAnalyzer(g.community).GetHand().String()
+		hand := strings.Join(p.handExplanations(g), " / ")
 		msg := playable.LogMessage{
 			UUID:      uuid.New().String(),
 			PlayerIDs: []int64{pid},
@@ -199,7 +239,7 @@ func (g *Game) endGame() error {
 		} else if p.folded {
 			msg.Message = fmt.Sprintf("{} folded and lost ${%d}", -1*p.balance)
 		} else {
-			msg.Message = fmt.Sprintf("{} lost ${%d} with a %s", -1*p.balance, hand)
+			msg.Message = fmt.Sprintf("{} lost ${%d} with a %s: %s", -1*p.balance, hand, p.losingReason(g, best))
 			msg.Cards = p.cards
 		}
 
@@ -4,6 +4,7 @@ import (
 	"github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/assert"
 	"mondaynightpoker-server/pkg/deck"
+	"mondaynightpoker-server/pkg/playable"
 	"mondaynightpoker-server/pkg/playable/poker/action"
 	"mondaynightpoker-server/pkg/snapshot"
 	"testing"
@@ -71,7 +72,7 @@ func TestGame_validateBetOrRaise(t *testing.T) {
 	newGame := func(t *testing.T, ante, smallBlind, bigBlind, tableStake int) *Game {
 		t.Helper()
 
-		game := setupNewGame(Options{Standard, ante, smallBlind, bigBlind}, tableStake, tableStake)
+		game := setupNewGame(Options{Variant: Standard, Ante: ante, SmallBlind: smallBlind, BigBlind: bigBlind}, tableStake, tableStake)
 		assertTick(t, game)
 		assertTickFromWaiting(t, game, DealerStatePreFlopBettingRound)
 
@@ -152,3 +153,27 @@ func TestGame_discardCardForParticipant(t *testing.T) {
 
 	a.EqualError(game.discardCardForParticipant(game.participants[2], deck.CardsFromString("2d")), "round is over")
 }
+
+func TestGame_endGame_explanations(t *testing.T) {
+	a := assert.New(t)
+
+	game := setupNewGame(DefaultOptions(), 1000, 1000, 1000)
+	game.participants[1].cards = deck.CardsFromString("13c,14d")
+	game.participants[2].cards = deck.CardsFromString("13h,12d")
+	game.participants[3].cards = deck.CardsFromString("2c,3d")
+	game.participants[3].folded = true
+	game.community[0] = deck.CardsFromString("13s,9c,5h,4d,2s")
+	game.dealerState = DealerStateRevealWinner
+
+	a.NoError(game.endGame())
+
+	var logs []*playable.LogMessage
+	for len(game.logChan) > 0 {
+		logs = <-game.logChan
+	}
+
+	a.Equal(3, len(logs))
+	a.Equal("{} won ${75} (${50}) with a Pair (kings, A-9-5 kickers)", logs[0].Message)
+	a.Equal("{} lost ${25} with a Pair (kings, Q-9-5 kickers): kicker: A beats Q", logs[1].Message)
+	a.Equal("{} folded and lost ${25}", logs[2].Message)
+}
@@ -152,7 +152,7 @@ func TestGame_basicGameWithWinner(t *testing.T) {
 
 		assertTick(t, game, "advance state to flop betting round")
 		a.Equal(DealerStateFlopBettingRound, game.dealerState, "game is now in the post-flop betting round")
-		a.Equal(3, len(game.community), "three cards in the community")
+		a.Equal(3, len(game.community[0]), "three cards in the community")
 
 		a.Equal([]action.Action{action.Check, action.Bet, action.Fold}, game.ActionsForParticipant(1))
 		a.Nil(game.ActionsForParticipant(2), "only player 1 has actions")
@@ -200,7 +200,7 @@ func TestGame_basicGameWithWinner(t *testing.T) {
 	{
 		assertTick(t, game, "advance to post-turn betting round")
 		a.Equal(DealerStateTurnBettingRound, game.dealerState, "now in the turn betting round")
-		a.Equal(4, len(game.community), "community has the correct number of cards")
+		a.Equal(4, len(game.community[0]), "community has the correct number of cards")
 		a.Equal([]action.Action{action.Check, action.Bet, action.Fold}, game.ActionsForParticipant(1), "bet is now 200")
 		for i := 1; i <= 3; i++ {
 			assertAction(t, game, int64(i), action.Check, "checks all around")
@@ -213,7 +213,7 @@ func TestGame_basicGameWithWinner(t *testing.T) {
 	{
 		assertTick(t, game, "advance to final betting round")
 		a.Equal(DealerStateFinalBettingRound, game.dealerState, "now in the turn betting round")
-		a.Equal(5, len(game.community), "community has the correct number of cards")
+		a.Equal(5, len(game.community[0]), "community has the correct number of cards")
 		a.Equal([]action.Action{action.Check, action.Bet, action.Fold}, game.ActionsForParticipant(1), "bet is still 200")
 		for i := 1; i <= 3; i++ {
 			assertAction(t, game, int64(i), action.Check, "checks all around")
@@ -446,7 +446,7 @@ func TestGame_endsInTie(t *testing.T) {
 
 	// setup winners
 	{
-		game.community = deck.CardsFromString("2c,4d,6h,8s,10c")
+		game.community[0] = deck.CardsFromString("2c,4d,6h,8s,10c")
 		game.participants[1].cards = deck.CardsFromString("2d,4h")
 		game.participants[2].cards = deck.CardsFromString("8c,10c")
 		game.participants[3].cards = deck.CardsFromString("8d,10d")
@@ -604,14 +604,14 @@ func TestGame_GetCurrentTurn(t *testing.T) {
 	a.Nil(p)
 }
 
-func Test_validateOptions(t *testing.T) {
+func TestValidateOptions(t *testing.T) {
 	a := assert.New(t)
-	a.NoError(validateOptions(Options{Variant: Standard}))
-	a.EqualError(validateOptions(Options{Variant: Standard, Ante: -1}), "ante must be at least ${0}")
-	a.EqualError(validateOptions(Options{Variant: Standard, Ante: 26}), "ante must be in increments of ${25}")
-	a.EqualError(validateOptions(Options{Variant: Standard, Ante: 75}), "ante must be at most ${50}")
-	a.EqualError(validateOptions(Options{Variant: Standard, SmallBlind: -1}), "small blind must be at least ${0}")
-	a.EqualError(validateOptions(Options{Variant: Standard, SmallBlind: 25, BigBlind: 0}), "big blind must be at least ${25}")
+	a.NoError(ValidateOptions(Options{Variant: Standard}))
+	a.EqualError(ValidateOptions(Options{Variant: Standard, Ante: -1}), "ante must be at least ${0}")
+	a.EqualError(ValidateOptions(Options{Variant: Standard, Ante: 26}), "ante must be in increments of ${25}")
+	a.EqualError(ValidateOptions(Options{Variant: Standard, Ante: 75}), "ante must be at most ${50}")
+	a.EqualError(ValidateOptions(Options{Variant: Standard, SmallBlind: -1}), "small blind must be at least ${0}")
+	a.EqualError(ValidateOptions(Options{Variant: Standard, SmallBlind: 25, BigBlind: 0}), "big blind must be at least ${25}")
 }
 
 func TestGame__pineapple(t *testing.T) {
@@ -1,6 +1,7 @@
 package texasholdem
 
 import (
+	"fmt"
 	"github.com/google/uuid"
 	"mondaynightpoker-server/pkg/deck"
 	"mondaynightpoker-server/pkg/playable"
@@ -24,7 +25,12 @@ func (g *Game) Tick() (bool, error) {
 			// don't call new round setup if we are in the pre-flop betting round as are in a good state currently
 			// the initial setup was done in the constructor
 			if g.dealerState == DealerStatePreFlopBettingRound {
-				g.payBlinds()
+				if g.options.BombPot > 0 {
+					// everyone is in the bomb pot, the betting starts on the flop
+					g.dealerState = DealerStateDealFlop
+				} else {
+					g.payBlinds()
+				}
 			}
 
 			return true, nil
@@ -35,47 +41,56 @@ func (g *Game) Tick() (bool, error) {
 
 	switch g.dealerState {
 	case DealerStateStart:
+		if g.canStraddle() && !g.straddleOffered {
+			g.straddleOffered = true
+			g.dealerState = DealerStateStraddleRound
+			return true, nil
+		}
+
 		if err := g.dealStartingCardsToEachParticipant(); err != nil {
 			return false, err
 		}
 
 		return true, nil
 	case DealerStateDealFlop:
-		flop := make([]*deck.Card, 3)
-		for i := 0; i < 3; i++ {
-			card, err := g.drawCommunityCard()
-			if err != nil {
-				return false, err
+		logs := make([]*playable.LogMessage, len(g.community))
+		for board := range g.community {
+			flop := make([]*deck.Card, 3)
+			for i := 0; i < 3; i++ {
+				card, err := g.drawCommunityCard(board)
+				if err != nil {
+					return false, err
+				}
+
+				flop[i] = card
 			}
 
-			flop[i] = card
+			g.recordDeal(eventFlop, board, flop)
+
+			logs[board] = &playable.LogMessage{
+				UUID:      uuid.New().String(),
+				PlayerIDs: nil,
+				Cards:     flop,
+				Message:   g.boardMessage("dealer dealt the flop", board),
+				Time:      time.Now(),
+			}
 		}
 
-		g.logChan <- []*playable.LogMessage{{
-			UUID:      uuid.New().String(),
-			PlayerIDs: nil,
-			Cards:     flop,
-			Message:   "dealer dealt the flop",
-			Time:      time.Now(),
-		}}
+		g.logChan <- logs
 		g.dealerState = DealerStateFlopBettingRound
 		return true, nil
 	case DealerStateDealTurn:
-		card, err := g.drawCommunityCard()
-		if err != nil {
+		if err := g.dealCommunityCardToEachBoard(eventTurn, "dealer dealt the turn"); err != nil {
 			return false, err
 		}
 
-		g.logChan <- []*playable.LogMessage{playable.SimpleLogMessageWithCard(0, card, "dealer dealt the turn")}
 		g.dealerState = DealerStateTurnBettingRound
 		return true, nil
 	case DealerStateDealRiver:
-		card, err := g.drawCommunityCard()
-		if err != nil {
+		if err := g.dealCommunityCardToEachBoard(eventRiver, "dealer dealt the river"); err != nil {
 			return false, err
 		}
 
-		g.logChan <- []*playable.LogMessage{playable.SimpleLogMessageWithCard(0, card, "dealer dealt the river")}
 		g.dealerState = DealerStateFinalBettingRound
 		return true, nil
 	case DealerStateRevealWinner:
@@ -91,6 +106,7 @@ func (g *Game) Tick() (bool, error) {
 		}
 	default:
 		if g.InBettingRound() && g.potManager.IsRoundOver() {
+			g.checkAllInShowdown()
 			g.setPendingDealerState(DealerState(int(g.dealerState)+1), time.Second)
 			return true, nil
 		}
@@ -99,12 +115,37 @@ func (g *Game) Tick() (bool, error) {
 	return false, nil
 }
 
-func (g *Game) drawCommunityCard() (*deck.Card, error) {
+func (g *Game) dealCommunityCardToEachBoard(t eventType, message string) error {
+	logs := make([]*playable.LogMessage, len(g.community))
+	for board := range g.community {
+		card, err := g.drawCommunityCard(board)
+		if err != nil {
+			return err
+		}
+
+		g.recordDeal(t, board, deck.Hand{card})
+		logs[board] = playable.SimpleLogMessageWithCard(0, card, "%s", g.boardMessage(message, board))
+	}
+
+	g.logChan <- logs
+	return nil
+}
+
+func (g *Game) drawCommunityCard(board int) (*deck.Card, error) {
 	card, err := g.deck.Draw()
 	if err != nil {
 		return nil, err
 	}
 
-	g.community.AddCard(card)
+	g.community[board].AddCard(card)
 	return card, nil
 }
+
+// boardMessage adds the board number to the message in a multi-board game
+func (g *Game) boardMessage(message string, board int) string {
+	if !g.isMultiBoard() {
+		return message
+	}
+
+	return fmt.Sprintf("%s on board %d", message, board+1)
+}
@@ -3,6 +3,8 @@ package texasholdem
 import (
 	"encoding/json"
 	"fmt"
+	"mondaynightpoker-server/pkg/deck"
+	"mondaynightpoker-server/pkg/playable/poker/handanalyzer"
 	"strings"
 )
 
@@ -14,21 +16,52 @@ const (
 	Standard      Variant = "standard"
 	Pineapple     Variant = "pineapple"
 	LazyPineapple Variant = "lazy-pineapple"
+	DoubleBoard   Variant = "double-board"
+	ShortDeck     Variant = "short-deck"
 )
 
 var validVariants = map[Variant]bool{
 	Standard:      true,
 	Pineapple:     true,
 	LazyPineapple: true,
+	DoubleBoard:   true,
+	ShortDeck:     true,
 }
 
 // HoleCards returns the number of hole cards for the game
 func (v Variant) HoleCards() int {
-	if v == Standard {
+	if v == Pineapple || v == LazyPineapple {
+		return 3
+	}
+
+	return 2
+}
+
+// Boards returns the number of community boards for the game
+func (v Variant) Boards() int {
+	if v == DoubleBoard {
 		return 2
 	}
 
-	return 3
+	return 1
+}
+
+// DeckSpec returns the composition of the deck for the game
+func (v Variant) DeckSpec() deck.Spec {
+	if v == ShortDeck {
+		return deck.ShortDeckSpec()
+	}
+
+	return deck.StandardSpec()
+}
+
+// Rules returns the hand rankings for the game
+func (v Variant) Rules() handanalyzer.Rules {
+	if v == ShortDeck {
+		return handanalyzer.ShortDeckRules
+	}
+
+	return handanalyzer.StandardRules
 }
 
 func (v Variant) String() string {
@@ -39,6 +72,10 @@ func (v Variant) String() string {
 		return "Pineapple"
 	case LazyPineapple:
 		return "Lazy Pineapple"
+	case DoubleBoard:
+		return "Double Board"
+	case ShortDeck:
+		return "Short Deck"
 	}
 
 	panic(fmt.Sprintf("unknown variant: %s", string(v)))
@@ -0,0 +1,142 @@
+package room
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"mondaynightpoker-server/pkg/model"
+	"mondaynightpoker-server/pkg/playable"
+
+	"github.com/sirupsen/logrus"
+)
+
+// BalanceAdjustment is a buy-in, cash-out or correction that a table admin makes to a player's balance
+type BalanceAdjustment struct {
+	PlayerID int64  `json:"playerId"`
+	Kind     string `json:"kind"`
+	Amount   int    `json:"amount"`
+	Reason   string `json:"reason"`
+}
+
+// balanceRequest is a balance adjustment that was requested outside of the websocket
+type balanceRequest struct {
+	table      *model.Table
+	admin      *model.Player
+	adjustment *BalanceAdjustment
+	result     chan balanceResult
+}
+
+type balanceResult struct {
+	playerTable *model.PlayerTable
+	err         error
+}
+
+// AdjustBalance records a buy-in, cash-out or correction to the balance of a player at the table
+// If players are connected to the table, the adjustment is refused while the player is in a game and the
+// players are sent the new balance
+func (p *PitBoss) AdjustBalance(ctx context.Context, table *model.Table, admin *model.Player, adjustment *BalanceAdjustment) (*model.PlayerTable, error) {
+	req := &balanceRequest{
+		table:      table,
+		admin:      admin,
+		adjustment: adjustment,
+		result:     make(chan balanceResult, 1),
+	}
+
+	p.adjustBalance <- req
+
+	select {
+	case result := <-req.result:
+		return result.playerTable, result.err
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// NOTE: must only be called from the PitBoss run loop
+func (p *PitBoss) handleBalanceRequest(req *balanceRequest) {
+	dealer, found := p.dealers[req.table.UUID]
+	if !found {
+		// nobody is connected to the table, so there is no game in progress
+		go func() {
+			pt, err := adjustBalance(req.table, req.admin, req.adjustment, false)
+			req.result <- balanceResult{playerTable: pt, err: err}
+		}()
+
+		return
+	}
+
+	dealer.execInRunLoop <- func() {
+		pt, err := dealer.adjustBalance(req.admin, req.adjustment)
+		req.result <- balanceResult{playerTable: pt, err: err}
+	}
+}
+
+// isInGame returns true if the player was dealt into the game in progress, or has chips in the cash game
+// or tournament
+// NOTE: must only be called from the run loop
+func (d *Dealer) isInGame(playerID int64) bool {
+	if d.gamePlayerIDs[playerID] {
+		return true
+	}
+
+	return d.session != nil && d.session.Stacks[playerID] > 0
+}
+
+// adjustBalance records a balance adjustment and sends the new balance to the players
+// NOTE: must only be called from the run loop
+func (d *Dealer) adjustBalance(admin *model.Player, adjustment *BalanceAdjustment) (*model.PlayerTable, error) {
+	pt, err := adjustBalance(d.table, admin, adjustment, d.isInGame(adjustment.PlayerID))
+	if err != nil {
+		return nil, err
+	}
+
+	var msg string
+	switch adjustment.Kind {
+	case model.AdjustmentBuyIn:
+		msg = fmt.Sprintf("{} recorded a ${%d} buy-in for %s", adjustment.Amount, pt.Player.DisplayName)
+	case model.AdjustmentCashOut:
+		msg = fmt.Sprintf("{} recorded a ${%d} cash-out for %s", adjustment.Amount, pt.Player.DisplayName)
+	default:
+		msg = fmt.Sprintf("{} corrected the balance of %s by ${%d}", pt.Player.DisplayName, adjustment.Amount)
+	}
+
+	d.sendLogMessages(playable.SimpleLogMessageSlice(admin.ID, "%s", msg))
+	d.stateChanged <- stateClientEvent
+	return pt, nil
+}
+
+func adjustBalance(table *model.Table, admin *model.Player, adjustment *BalanceAdjustment, isInGame bool) (*model.PlayerTable, error) {
+	ctx := context.Background()
+	player, err := model.GetPlayerByID(ctx, adjustment.PlayerID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, model.ErrPlayerNotAtTable
+		}
+
+		return nil, err
+	}
+
+	pt, err := player.GetPlayerTable(ctx, table)
+	if err != nil {
+		return nil, err
+	}
+
+	if isInGame {
+		return nil, model.UserError(fmt.Sprintf("%s is in a game, the balance can be adjusted after the game", player.DisplayName))
+	}
+
+	if err := pt.RecordAdjustment(ctx, admin, adjustment.Kind, adjustment.Amount, adjustment.Reason); err != nil {
+		return nil, err
+	}
+
+	logrus.WithFields(logrus.Fields{
+		"table":    table.UUID,
+		"admin":    admin.ID,
+		"playerId": adjustment.PlayerID,
+		"kind":     adjustment.Kind,
+		"amount":   adjustment.Amount,
+		"reason":   adjustment.Reason,
+	}).Info("balance adjusted")
+
+	return pt, nil
+}
@@ -0,0 +1,28 @@
+package room
+
+import (
+	"mondaynightpoker-server/pkg/model"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDealer_isInGame(t *testing.T) {
+	a := assert.New(t)
+
+	d := NewDealer(&PitBoss{}, &model.Table{})
+	a.False(d.isInGame(1))
+
+	d.startGame(nil, nil, []int64{1, 2})
+	a.True(d.isInGame(1))
+	a.True(d.isInGame(2))
+	a.False(d.isInGame(3))
+
+	d.unsetGame()
+	a.False(d.isInGame(1))
+
+	// between the hands of a cash game, the players with chips are still in the game
+	d.session = &session{Stacks: map[int64]int{1: 500, 2: 0}}
+	a.True(d.isInGame(1))
+	a.False(d.isInGame(2))
+}
@@ -8,12 +8,17 @@ import (
 	"mondaynightpoker-server/pkg/model"
 	"mondaynightpoker-server/pkg/playable"
 	"mondaynightpoker-server/pkg/room/gamefactory"
+	"mondaynightpoker-server/pkg/stats"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/sirupsen/logrus"
 )
 
+// settlementRetryInterval is how long the dealer waits before retrying the settlement of
+// games whose balance adjustments could not be saved
+const settlementRetryInterval = time.Minute
+
 type state int
 
 const (
@@ -21,6 +26,7 @@ const (
 	stateGameEvent
 	stateGameEnded
 	stateGameScheduled
+	stateSessionChanged
 )
 
 type action string
@@ -40,6 +46,15 @@ type Dealer struct {
 	game    playable.Playable
 	ticker  *time.Ticker
 
+	// gameRecord is the record of the game in the database
+	gameRecord *model.Game
+
+	// gamePlayerIDs are the players who were dealt into the game
+	gamePlayerIDs map[int64]bool
+
+	// buttonSeat is the seat with the button in the game, it is saved when the game ends
+	buttonSeat int
+
 	execInRunLoop chan func()
 	stateChanged  chan state
 	close         chan bool
@@ -48,6 +63,15 @@ type Dealer struct {
 	logMessages []*playable.LogMessage
 
 	pendingGame *pendingGame
+
+	// session is the cash game that is being dealt, if any
+	session *session
+
+	// creditLimited are the players who were not dealt in because they have no credit left
+	creditLimited map[int64]bool
+
+	// settlementRetry fires when the games that could not be settled should be retried
+	settlementRetry *time.Timer
 }
 
 // NewDealer creates a new dealer object
@@ -68,6 +92,8 @@ func NewDealer(pitBoss *PitBoss, table *model.Table) *Dealer {
 
 // StartShift starts the run loop
 func (d *Dealer) StartShift() {
+	// settle the games that could not be settled before the table was closed
+	d.execInRunLoop <- d.retrySettlements
 	go d.runLoop()
 }
 
@@ -94,6 +120,16 @@ func (d *Dealer) runLoop() {
 			ticker = d.ticker.C
 		}
 
+		var nextHandTimer <-chan time.Time
+		if d.session != nil && d.session.timer != nil {
+			nextHandTimer = d.session.timer.C
+		}
+
+		var settlementRetryTimer <-chan time.Time
+		if d.settlementRetry != nil {
+			settlementRetryTimer = d.settlementRetry.C
+		}
+
 		select {
 		case <-ticker:
 			if d.game != nil {
@@ -120,6 +156,13 @@ func (d *Dealer) runLoop() {
 			}
 
 			d.pendingGame = nil
+		case <-nextHandTimer:
+			if err := d.dealHand(); err != nil {
+				logrus.WithError(err).Error("could not deal the next hand")
+			}
+		case <-settlementRetryTimer:
+			d.settlementRetry = nil
+			d.retrySettlements()
 		case messages := <-logChan:
 			d.sendLogMessages(messages)
 		case s := <-d.stateChanged:
@@ -133,6 +176,8 @@ func (d *Dealer) runLoop() {
 				d.sendPlayerData()
 			case stateGameScheduled:
 				d.sendGameScheduled()
+			case stateSessionChanged:
+				d.sendSession()
 			}
 		case fn := <-d.execInRunLoop:
 			fn()
@@ -164,6 +209,13 @@ func (d *Dealer) AddClient(client *Client) {
 			})
 		}
 
+		if d.session != nil {
+			client.Send(playable.Response{
+				Key:  "session",
+				Data: d.session,
+			})
+		}
+
 		if d.game == nil {
 			return
 		}
@@ -234,6 +286,16 @@ func (d *Dealer) sendGameScheduled() {
 	}
 }
 
+func (d *Dealer) sendSession() {
+	s := d.session
+	for client := range d.clients {
+		client.Send(playable.Response{
+			Key:  "session",
+			Data: s,
+		})
+	}
+}
+
 func (d *Dealer) sendLogMessages(messages []*playable.LogMessage) {
 	var gameName string
 	if d.game != nil {
@@ -277,9 +339,10 @@ func (d *Dealer) sendPlayerData() {
 		_, isConnected := connectedClients[player.PlayerID]
 		delete(connectedClients, player.PlayerID)
 		csPlayers[player.PlayerID] = &clientStatePlayers{
-			PlayerTable: player,
-			IsConnected: isConnected,
-			IsSeated:    true,
+			PlayerTable:   player,
+			IsConnected:   isConnected,
+			IsSeated:      true,
+			AtCreditLimit: player.AtCreditLimit(d.table),
 		}
 	}
 
@@ -387,6 +450,74 @@ func (d *Dealer) ReceivedMessage(c *Client, msg *playable.PayloadIn) {
 				return
 			}
 
+			c.Send(playable.OK(msg.Context))
+		}
+	case "bombPot":
+		if !canPerformActionOnTable(msg.Context, c, actionStart) {
+			return
+		}
+
+		d.execInRunLoop <- func() {
+			if d.session == nil {
+				c.Send(newErrorResponse(msg.Context, errors.New("there is no cash game in progress")))
+				return
+			}
+
+			amount, _ := msg.AdditionalData.GetInt("amount")
+			if err := d.session.callBombPot(amount); err != nil {
+				c.Send(newErrorResponse(msg.Context, err))
+				return
+			}
+
+			d.stateChanged <- stateSessionChanged
+			d.sendLogMessages(playable.SimpleLogMessageSlice(c.player.ID, "{} called a ${%d} bomb pot for the next hand", amount))
+			c.Send(playable.OK(msg.Context))
+		}
+	case "adjustBalance":
+		if !canPerformActionOnTable(msg.Context, c, actionAdmin) {
+			return
+		}
+
+		playerID, _ := msg.AdditionalData.GetInt("playerId")
+		kind, _ := msg.AdditionalData.GetString("kind")
+		amount, _ := msg.AdditionalData.GetInt("amount")
+		reason, _ := msg.AdditionalData.GetString("reason")
+
+		d.execInRunLoop <- func() {
+			_, err := d.adjustBalance(c.player, &BalanceAdjustment{
+				PlayerID: int64(playerID),
+				Kind:     kind,
+				Amount:   amount,
+				Reason:   reason,
+			})
+
+			if err != nil {
+				c.Send(newErrorResponse(msg.Context, err))
+				return
+			}
+
+			c.Send(playable.OK(msg.Context))
+		}
+	case "stopSession":
+		if !canPerformActionOnTable(msg.Context, c, actionTerminate) {
+			return
+		}
+
+		d.execInRunLoop <- func() {
+			if d.session == nil {
+				c.Send(newErrorResponse(msg.Context, errors.New("there is no cash game in progress")))
+				return
+			}
+
+			if d.game == nil {
+				// between hands
+				d.endSession()
+			} else {
+				d.session.Stopping = true
+				d.stateChanged <- stateSessionChanged
+				d.sendLogMessages(playable.SimpleLogMessageSlice(c.player.ID, "{} will end the cash game after this hand"))
+			}
+
 			c.Send(playable.OK(msg.Context))
 		}
 	case "terminateGame":
@@ -395,7 +526,14 @@ func (d *Dealer) ReceivedMessage(c *Client, msg *playable.PayloadIn) {
 		}
 
 		d.execInRunLoop <- func() {
+			if record := d.gameRecord; record != nil {
+				if err := record.Abandon(context.Background()); err != nil {
+					logrus.WithError(err).WithField("game", record.ID).Error("could not abandon game")
+				}
+			}
+
 			d.unsetGame()
+			d.endSession()
 			d.stateChanged <- stateGameEnded
 			d.sendLogMessages([]*playable.LogMessage{
 				{
@@ -457,11 +595,50 @@ func (d *Dealer) ReceivedMessage(c *Client, msg *playable.PayloadIn) {
 				playerTable.IsBlocked = isBlocked
 			}
 
+			if value, ok := msg.AdditionalData["creditLimit"]; ok {
+				creditLimit, err := parseCreditLimit(value)
+				if err != nil {
+					c.Send(newErrorResponse(msg.Context, err))
+					return
+				}
+
+				playerTable.CreditLimit = creditLimit
+			}
+
 			if err := playerTable.Save(context.Background()); err != nil {
 				c.Send(newErrorResponse(msg.Context, err))
 				return
 			}
 
+			c.Send(playable.OK(msg.Context))
+			d.stateChanged <- stateClientEvent
+		}
+	case "creditLimit":
+		d.execInRunLoop <- func() {
+			if !canPerformActionOnTable(msg.Context, c, actionAdmin) {
+				return
+			}
+
+			creditLimit, err := parseCreditLimit(msg.AdditionalData["creditLimit"])
+			if err != nil {
+				c.Send(newErrorResponse(msg.Context, err))
+				return
+			}
+
+			previous := d.table.CreditLimit
+			d.table.CreditLimit = creditLimit
+			if err := d.table.Save(context.Background()); err != nil {
+				d.table.CreditLimit = previous
+				c.Send(newErrorResponse(msg.Context, err))
+				return
+			}
+
+			if creditLimit == nil {
+				d.sendLogMessages(playable.SimpleLogMessageSlice(c.player.ID, "{} removed the credit limit"))
+			} else {
+				d.sendLogMessages(playable.SimpleLogMessageSlice(c.player.ID, "{} set the credit limit to ${%d}", *creditLimit))
+			}
+
 			c.Send(playable.OK(msg.Context))
 			d.stateChanged <- stateClientEvent
 		}
@@ -566,9 +743,15 @@ func (d *Dealer) ReceivedMessage(c *Client, msg *playable.PayloadIn) {
 			}
 
 			if details, isOver := game.GetEndOfGameDetails(); isOver {
-				if err := d.endGame(game, details); err != nil {
-					c.Send(newErrorResponse(msg.Context, err))
-					return
+				d.execInRunLoop <- func() {
+					// the ticker may have ended the game first
+					if d.game != game {
+						return
+					}
+
+					if err := d.endGame(game, details); err != nil {
+						c.Send(newErrorResponse(msg.Context, err))
+					}
 				}
 			}
 
@@ -579,27 +762,162 @@ func (d *Dealer) ReceivedMessage(c *Client, msg *playable.PayloadIn) {
 	}
 }
 
+// endGame saves the game, settles the players' balances and moves on to the next hand of the session
+// Note: this must only be called within the run loop
 func (d *Dealer) endGame(game playable.Playable, details *playable.GameOverDetails) error {
-	record, err := d.table.CreateGame(context.Background(), game.Name())
-	if err != nil {
-		return fmt.Errorf("could not create game: %w", err)
+	record := d.gameRecord
+	if record == nil {
+		// every game is recorded when it starts, so the game cannot be saved
+		d.unsetGame()
+		d.stateChanged <- stateGameEnded
+		return fmt.Errorf("%s was not recorded when it started", game.Name())
+	}
+
+	balanceAdjustments := details.BalanceAdjustments
+	if d.session != nil && d.session.Tournament != nil {
+		// the hands of a tournament are played for chips, the results are posted when the tournament ends
+		balanceAdjustments = nil
+	}
+
+	if err := record.EndGame(context.Background(), details.Log, balanceAdjustments); err != nil {
+		if !errors.Is(err, model.ErrSettlementPending) {
+			return fmt.Errorf("could not save game: %w", err)
+		}
+
+		// the game was saved, so it can end while the settlement is retried
+		d.settlementFailed(record, err)
 	}
 
-	if err := record.EndGame(context.Background(), details.Log, details.BalanceAdjustments); err != nil {
-		return fmt.Errorf("could not save game: %w", err)
+	if balanceAdjustments != nil {
+		d.recordStats(record.GameType, details.Log, balanceAdjustments)
+	}
+
+	if d.buttonSeat > 0 {
+		if err := d.table.SetButtonSeat(context.Background(), d.buttonSeat); err != nil {
+			logrus.WithError(err).WithField("uuid", d.table.UUID).Error("could not move the button")
+		}
 	}
 
 	d.unsetGame()
 	d.stateChanged <- stateGameEnded
+
+	if d.session != nil {
+		d.sessionHandEnded(details.BalanceAdjustments)
+	}
+
 	return nil
 }
 
+// settlementFailed schedules another attempt to adjust the balances of the players after the game
+// The admins at the table are alerted when the first attempt fails, and when the game has failed too many times
+// to be retried
+func (d *Dealer) settlementFailed(game *model.Game, err error) {
+	log := logrus.WithError(err).WithField("game", game.ID).WithField("attempts", game.SettlementAttempts)
+	if !game.CanRetrySettlement() {
+		log.Error("could not settle game, it will not be retried")
+		d.alertAdmins(playable.Response{
+			Key:   "settlementFailed",
+			Value: fmt.Sprintf("the balances could not be updated after game #%d and the update will not be retried", game.ID),
+			Data:  map[string]int64{"gameId": game.ID},
+		})
+
+		return
+	}
+
+	log.Error("could not settle game")
+	if game.SettlementAttempts <= 1 {
+		d.alertAdmins(playable.Response{
+			Key:   "settlementFailed",
+			Value: fmt.Sprintf("the balances could not be updated after game #%d, the update will be retried", game.ID),
+			Data:  map[string]int64{"gameId": game.ID},
+		})
+	}
+
+	d.scheduleSettlementRetry()
+}
+
+// scheduleSettlementRetry schedules another attempt to settle the games that could not be settled
+func (d *Dealer) scheduleSettlementRetry() {
+	if d.settlementRetry == nil {
+		d.settlementRetry = time.NewTimer(settlementRetryInterval)
+	}
+}
+
+// retrySettlements adjusts the balances of the players for the games that could not be settled when they ended
+func (d *Dealer) retrySettlements() {
+	games, err := d.table.GetUnsettledGames(context.Background())
+	if err != nil {
+		logrus.WithError(err).WithField("uuid", d.table.UUID).Error("could not get unsettled games")
+		d.scheduleSettlementRetry()
+		return
+	}
+
+	settled := false
+	for _, game := range games {
+		if err := game.Settle(context.Background()); err != nil {
+			d.settlementFailed(game, err)
+			continue
+		}
+
+		logrus.WithField("game", game.ID).Info("settled game")
+		settled = true
+	}
+
+	if settled {
+		d.stateChanged <- stateClientEvent
+	}
+}
+
+// alertAdmins sends the response to the connected clients who are table admins or site admins
+func (d *Dealer) alertAdmins(response playable.Response) {
+	if len(d.clients) == 0 {
+		return
+	}
+
+	players, err := d.table.GetPlayers(context.Background())
+	if err != nil {
+		logrus.WithField("uuid", d.table.UUID).WithError(err).Error("could not get players")
+		return
+	}
+
+	admins := make(map[int64]bool)
+	for _, player := range players {
+		if player.IsTableAdmin {
+			admins[player.PlayerID] = true
+		}
+	}
+
+	for client := range d.clients {
+		if client.player.IsSiteAdmin || admins[client.player.ID] {
+			client.Send(response)
+		}
+	}
+}
+
+// recordStats adds the results of the game to the statistics of the players
+// The game has already been saved, so an error is logged instead of returned
+func (d *Dealer) recordStats(gameType string, log interface{}, balanceAdjustments map[int64]int) {
+	playerStats, err := stats.FromGame(log, balanceAdjustments)
+	if err != nil {
+		logrus.WithError(err).Error("could not derive player stats")
+		return
+	}
+
+	if err := d.table.AddPlayerStats(context.Background(), gameType, playerStats); err != nil {
+		logrus.WithError(err).Error("could not record player stats")
+	}
+}
+
+// getNextPlayersForGame returns the players who are dealt into the next game in seat order
+// The button moves to the first player to the left of the previous button, who is dealt in last
 func (d *Dealer) getNextPlayersForGame() ([]*model.PlayerTable, error) {
-	players, err := d.table.GetActivePlayersShifted(context.Background())
+	players, err := d.table.GetSeatedPlayers(context.Background())
 	if err != nil {
 		return nil, err
 	}
 
+	d.skipPlayersAtCreditLimit(players)
+
 	filteredPlayers := make([]*model.PlayerTable, 0, len(players))
 	for _, player := range players {
 		if player.IsPlaying() {
@@ -607,14 +925,85 @@ func (d *Dealer) getNextPlayersForGame() ([]*model.PlayerTable, error) {
 		}
 	}
 
+	if len(filteredPlayers) > 0 {
+		filteredPlayers = append(filteredPlayers[1:], filteredPlayers[0])
+	}
+
 	return filteredPlayers, nil
 }
 
+// parseCreditLimit parses a credit limit from a client message
+// A null credit limit removes the limit
+func parseCreditLimit(value interface{}) (*int, error) {
+	if value == nil {
+		return nil, nil
+	}
+
+	f, ok := value.(float64)
+	if !ok {
+		return nil, errors.New("creditLimit must be a number or null")
+	}
+
+	creditLimit := int(f)
+	if err := model.ValidateCreditLimit(&creditLimit); err != nil {
+		return nil, err
+	}
+
+	return &creditLimit, nil
+}
+
+// skipPlayersAtCreditLimit marks the players who have no credit left as not playing, so they are not dealt in
+// Everyone else is dealt in with at most their available credit as their stake
+// The players are not saved, so they are dealt back in once they are under their limit. The table is told
+// when a player is first skipped
+func (d *Dealer) skipPlayersAtCreditLimit(players []*model.PlayerTable) {
+	limited := make(map[int64]bool)
+	logs := make([]*playable.LogMessage, 0)
+	for _, pt := range players {
+		if !pt.IsPlaying() {
+			continue
+		}
+
+		if !pt.AtCreditLimit(d.table) {
+			pt.LimitTableStake(d.table)
+			continue
+		}
+
+		pt.Active = false
+		limited[pt.PlayerID] = true
+		if !d.creditLimited[pt.PlayerID] {
+			logs = append(logs, playable.SimpleLogMessage(pt.PlayerID, "{} has reached their credit limit of ${%d} and will not be dealt in", *pt.EffectiveCreditLimit(d.table)))
+		}
+	}
+
+	d.creditLimited = limited
+	if len(logs) > 0 {
+		d.sendLogMessages(logs)
+	}
+}
+
 func (d *Dealer) scheduleGame(c *Client, msg *playable.PayloadIn) error {
 	if d.pendingGame != nil {
 		return errors.New("a game is already scheduled to start")
 	}
 
+	if d.session != nil {
+		return errors.New("a cash game is in progress")
+	}
+
+	if isSessionRequest(msg) {
+		sf, err := getSessionFactory(msg)
+		if err != nil {
+			return err
+		}
+
+		if isTournamentRequest(msg) {
+			if _, err := newTournament(sf, msg.AdditionalData); err != nil {
+				return err
+			}
+		}
+	}
+
 	pendingGame, err := newPendingGame(c, msg)
 	if err != nil {
 		return err
@@ -636,6 +1025,10 @@ func (d *Dealer) createGame(client *Client, msg *playable.PayloadIn) error {
 		return err
 	}
 
+	if isSessionRequest(msg) {
+		return d.startSession(client, msg)
+	}
+
 	players, err := d.getNextPlayersForGame()
 	if err != nil {
 		return err
@@ -653,26 +1046,77 @@ func (d *Dealer) createGame(client *Client, msg *playable.PayloadIn) error {
 		"playerIDs": playerIDs,
 	})
 
-	var game playable.Playable
-	if v2, ok := factory.(gamefactory.V2); ok {
-		game, err = v2.CreateGameV2(logger, players, msg.AdditionalData)
-	} else {
-		game, err = factory.CreateGame(logger, playerIDs, msg.AdditionalData)
+	game, err := factory.CreateGameV2(logger, players, msg.AdditionalData)
+	if err != nil {
+		return err
+	}
+
+	seated := make([]playable.Player, len(players))
+	for i, player := range players {
+		seated[i] = player
 	}
 
+	record, err := d.recordGameStart(game, seated, players)
 	if err != nil {
 		return err
 	}
-	logger.Info("game started")
+	logger.WithField("gameID", record.ID).Info("game started")
 
+	d.startGame(game, record, playerIDs)
+	if len(players) > 0 {
+		d.buttonSeat = *players[len(players)-1].Seat
+	}
+
+	return nil
+}
+
+// recordGameStart creates the record of the game with the players who were dealt in
+// The seat of each player is looked up in tablePlayers
+func (d *Dealer) recordGameStart(game playable.Playable, players []playable.Player, tablePlayers []*model.PlayerTable) (*model.Game, error) {
+	seats := seatsByPlayerID(tablePlayers)
+	participants := make([]*model.GameParticipant, len(players))
+	for i, p := range players {
+		participants[i] = &model.GameParticipant{
+			PlayerID:      p.GetPlayerID(),
+			Seat:          seats[p.GetPlayerID()],
+			StartingStake: p.GetTableStake(),
+		}
+	}
+
+	record, err := d.table.StartGame(context.Background(), game.Name(), participants)
+	if err != nil {
+		return nil, fmt.Errorf("could not create game: %w", err)
+	}
+
+	return record, nil
+}
+
+// seatsByPlayerID returns the seat of each player by player ID
+// Players without a seat are left out
+func seatsByPlayerID(players []*model.PlayerTable) map[int64]int {
+	seats := make(map[int64]int, len(players))
+	for _, pt := range players {
+		if pt.Seat != nil {
+			seats[pt.PlayerID] = *pt.Seat
+		}
+	}
+
+	return seats
+}
+
+func (d *Dealer) startGame(game playable.Playable, record *model.Game, playerIDs []int64) {
 	d.game = game
+	d.gameRecord = record
+	d.gamePlayerIDs = make(map[int64]bool, len(playerIDs))
+	for _, id := range playerIDs {
+		d.gamePlayerIDs[id] = true
+	}
 
 	if t, ok := game.(playable.Tickable); ok {
 		d.ticker = time.NewTicker(t.Interval())
 	}
 
 	d.stateChanged <- stateGameEvent
-	return nil
 }
 
 func (d *Dealer) unsetGame() {
@@ -689,6 +1133,9 @@ func (d *Dealer) unsetGame() {
 	}
 
 	d.game = nil
+	d.gameRecord = nil
+	d.gamePlayerIDs = nil
+	d.buttonSeat = 0
 
 	if d.ticker != nil {
 		d.ticker.Stop()
@@ -1,6 +1,7 @@
 package room
 
 import (
+	"errors"
 	"github.com/stretchr/testify/assert"
 	"mondaynightpoker-server/pkg/model"
 	"testing"
@@ -17,3 +18,106 @@ func TestDealer_AddClient(t *testing.T) {
 	assert.False(t, d.RemoveClient(c))
 	assert.True(t, d.RemoveClient(c2))
 }
+
+func TestParseCreditLimit(t *testing.T) {
+	a := assert.New(t)
+
+	creditLimit, err := parseCreditLimit(nil)
+	a.NoError(err)
+	a.Nil(creditLimit)
+
+	creditLimit, err = parseCreditLimit(float64(5000))
+	a.NoError(err)
+	a.Equal(5000, *creditLimit)
+
+	_, err = parseCreditLimit("5000")
+	a.EqualError(err, "creditLimit must be a number or null")
+
+	_, err = parseCreditLimit(float64(-25))
+	a.EqualError(err, "the credit limit must be in increments of ${25}")
+}
+
+func TestDealer_skipPlayersAtCreditLimit(t *testing.T) {
+	a := assert.New(t)
+
+	tableLimit := 1000
+	playerLimit := 3000
+	noCredit := 0
+	d := NewDealer(&PitBoss{}, &model.Table{CreditLimit: &tableLimit})
+	players := []*model.PlayerTable{
+		{PlayerID: 1, Active: true, Balance: -1025, TableStake: 1000},
+		{PlayerID: 2, Active: true, Balance: -2000, TableStake: 2000, CreditLimit: &playerLimit},
+		{PlayerID: 3, Active: true, Balance: 500, TableStake: 1000},
+		{PlayerID: 4, Active: false, Balance: -5000, TableStake: 1000},
+		{PlayerID: 5, Active: true, Balance: -1000, TableStake: 1000},
+		{PlayerID: 6, Active: true, Balance: 0, TableStake: 1000, CreditLimit: &noCredit},
+		{PlayerID: 7, Active: true, Balance: 300, TableStake: 1000, CreditLimit: &noCredit},
+	}
+
+	d.skipPlayersAtCreditLimit(players)
+	a.False(players[0].Active)
+	a.True(players[1].Active)
+	a.True(players[2].Active)
+	a.False(players[4].Active, "a player who owes exactly their limit has no credit left")
+	a.False(players[5].Active, "a player with no credit cannot play without winnings")
+	a.True(players[6].Active)
+	a.Equal(map[int64]bool{1: true, 5: true, 6: true}, d.creditLimited)
+	if a.Equal(3, len(d.logMessages)) {
+		a.Equal("{} has reached their credit limit of ${1000} and will not be dealt in", d.logMessages[0].Message)
+		a.Equal("{} has reached their credit limit of ${0} and will not be dealt in", d.logMessages[2].Message)
+	}
+
+	// the stakes are capped at what the players can lose before they reach their limit
+	a.Equal(1000, players[1].GetTableStake())
+	a.Equal(1000, players[2].GetTableStake())
+	a.Equal(300, players[6].GetTableStake())
+
+	// the table is only told once
+	players[0].Active = true
+	d.skipPlayersAtCreditLimit(players)
+	a.False(players[0].Active)
+	a.Equal(3, len(d.logMessages))
+}
+
+func TestDealer_scheduleSettlementRetry(t *testing.T) {
+	a := assert.New(t)
+
+	d := NewDealer(&PitBoss{}, &model.Table{})
+	a.Nil(d.settlementRetry)
+
+	d.scheduleSettlementRetry()
+	timer := d.settlementRetry
+	a.NotNil(timer)
+
+	// a retry that is already scheduled is not rescheduled
+	d.scheduleSettlementRetry()
+	a.Same(timer, d.settlementRetry)
+	timer.Stop()
+}
+
+func TestDealer_settlementFailed(t *testing.T) {
+	a := assert.New(t)
+
+	d := NewDealer(&PitBoss{}, &model.Table{})
+	d.settlementFailed(&model.Game{ID: 1, SettlementAttempts: 2}, errors.New("bad adjustments"))
+	if a.NotNil(d.settlementRetry) {
+		d.settlementRetry.Stop()
+	}
+
+	// a game that failed too many times is not retried
+	d = NewDealer(&PitBoss{}, &model.Table{})
+	d.settlementFailed(&model.Game{ID: 1, SettlementAttempts: model.MaxSettlementAttempts}, errors.New("bad adjustments"))
+	a.Nil(d.settlementRetry)
+}
+
+func TestSeatsByPlayerID(t *testing.T) {
+	seat3 := 3
+	seat7 := 7
+	seats := seatsByPlayerID([]*model.PlayerTable{
+		{PlayerID: 1, Seat: &seat7},
+		{PlayerID: 2, Seat: &seat3},
+		{PlayerID: 3},
+	})
+
+	assert.Equal(t, map[int64]int{1: 7, 2: 3}, seats)
+}
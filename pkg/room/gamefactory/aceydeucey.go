@@ -2,14 +2,16 @@ package gamefactory
 
 import (
 	"github.com/sirupsen/logrus"
+	"mondaynightpoker-server/pkg/model"
 	"mondaynightpoker-server/pkg/playable"
 	"mondaynightpoker-server/pkg/playable/aceydeucey"
 )
 
 type aceyDeuceyFactory struct{}
 
-func (a aceyDeuceyFactory) CreateGame(logger logrus.FieldLogger, playerIDs []int64, additionalData playable.AdditionalData) (playable.Playable, error) {
-	return aceydeucey.NewGame(logger, playerIDs, getAceyDeuceyOptions(additionalData))
+func (a aceyDeuceyFactory) CreateGameV2(logger logrus.FieldLogger, players []*model.PlayerTable, additionalData playable.AdditionalData) (playable.Playable, error) {
+	p := getPlayersFromPlayerTableList(players)
+	return aceydeucey.NewGame(logger, p, getAceyDeuceyOptions(additionalData))
 }
 
 func (a aceyDeuceyFactory) Details(additionalData playable.AdditionalData) (name string, ante int, err error) {
@@ -3,14 +3,18 @@ package gamefactory
 import (
 	"github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/assert"
+	"mondaynightpoker-server/pkg/model"
 	"mondaynightpoker-server/pkg/playable"
 	"mondaynightpoker-server/pkg/playable/aceydeucey"
 	"testing"
 )
 
-func Test_aceyDeuceyFactory_CreateGame(t *testing.T) {
+func Test_aceyDeuceyFactory_CreateGameV2(t *testing.T) {
 	a := assert.New(t)
-	game, err := aceyDeuceyFactory{}.CreateGame(logrus.StandardLogger(), []int64{1, 2}, playable.AdditionalData{})
+	game, err := aceyDeuceyFactory{}.CreateGameV2(logrus.StandardLogger(), []*model.PlayerTable{
+		{PlayerID: 1, TableStake: 100},
+		{PlayerID: 2, TableStake: 100},
+	}, playable.AdditionalData{})
 	a.IsType(&aceydeucey.Game{}, game)
 	a.NoError(err)
 }
@@ -2,6 +2,7 @@ package gamefactory
 
 import (
 	"github.com/sirupsen/logrus"
+	"mondaynightpoker-server/pkg/model"
 	"mondaynightpoker-server/pkg/playable"
 	"mondaynightpoker-server/pkg/playable/bourre"
 )
@@ -13,9 +14,10 @@ func (b bourreFactory) Details(additionalData playable.AdditionalData) (string,
 	return bourre.NameFromOptions(opts), opts.Ante, nil
 }
 
-func (b bourreFactory) CreateGame(logger logrus.FieldLogger, playerIDs []int64, additionalData playable.AdditionalData) (playable.Playable, error) {
+func (b bourreFactory) CreateGameV2(logger logrus.FieldLogger, players []*model.PlayerTable, additionalData playable.AdditionalData) (playable.Playable, error) {
+	p := getPlayersFromPlayerTableList(players)
 	opts := getBourreOptions(additionalData)
-	game, err := bourre.NewGame(logger, playerIDs, opts)
+	game, err := bourre.NewGame(logger, p, opts)
 	if err != nil {
 		return nil, err
 	}
@@ -1,8 +1,11 @@
 package gamefactory
 
 import (
+	"github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/assert"
+	"mondaynightpoker-server/pkg/model"
 	"mondaynightpoker-server/pkg/playable"
+	"mondaynightpoker-server/pkg/playable/bourre"
 	"testing"
 )
 
@@ -21,3 +24,13 @@ func Test_bourreFactory_Details(t *testing.T) {
 	assert.Equal(t, "Bourré (Five Suit)", name)
 	assert.Equal(t, 50, ante)
 }
+
+func Test_bourreFactory_CreateGameV2(t *testing.T) {
+	a := assert.New(t)
+	game, err := factories["bourre"].CreateGameV2(logrus.StandardLogger(), []*model.PlayerTable{
+		{PlayerID: 1, TableStake: 100},
+		{PlayerID: 2, TableStake: 100},
+	}, playable.AdditionalData{})
+	a.NoError(err)
+	a.IsType(&bourre.Game{}, game)
+}
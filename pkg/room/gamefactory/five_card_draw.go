@@ -0,0 +1,46 @@
+package gamefactory
+
+import (
+	"github.com/sirupsen/logrus"
+	"mondaynightpoker-server/pkg/model"
+	"mondaynightpoker-server/pkg/playable"
+	"mondaynightpoker-server/pkg/playable/poker/fivecarddraw"
+)
+
+type fiveCardDrawFactory struct{}
+
+func (f fiveCardDrawFactory) Details(additionalData playable.AdditionalData) (string, int, error) {
+	opts := getFiveCardDrawOptions(additionalData)
+	return fivecarddraw.NameFromOptions(opts), opts.Ante, nil
+}
+
+func (f fiveCardDrawFactory) CreateGameV2(logger logrus.FieldLogger, players []*model.PlayerTable, additionalData playable.AdditionalData) (playable.Playable, error) {
+	p := getPlayersFromPlayerTableList(players)
+	game, err := fivecarddraw.NewGame(logger, p, getFiveCardDrawOptions(additionalData))
+	if err != nil {
+		return nil, err
+	}
+
+	if err := game.DealCards(); err != nil {
+		return nil, err
+	}
+
+	return game, nil
+}
+
+func getFiveCardDrawOptions(additionalData playable.AdditionalData) fivecarddraw.Options {
+	opts := fivecarddraw.DefaultOptions()
+	if ante, _ := additionalData.GetInt("ante"); ante > 0 {
+		opts.Ante = ante
+	}
+
+	if jacksOrBetter, ok := additionalData.GetBool("jacksOrBetter"); ok {
+		opts.JacksOrBetter = jacksOrBetter
+	}
+
+	if tripsToWin, ok := additionalData.GetBool("tripsToWin"); ok {
+		opts.TripsToWin = tripsToWin
+	}
+
+	return opts
+}
@@ -0,0 +1,37 @@
+package gamefactory
+
+import (
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"mondaynightpoker-server/pkg/model"
+	"mondaynightpoker-server/pkg/playable"
+	"mondaynightpoker-server/pkg/playable/poker/fivecarddraw"
+	"testing"
+)
+
+func Test_fiveCardDrawFactory_CreateGameV2(t *testing.T) {
+	a := assert.New(t)
+	game, err := factories["five-card-draw"].CreateGameV2(logrus.StandardLogger(), []*model.PlayerTable{
+		{PlayerID: 1, TableStake: 100},
+		{PlayerID: 2, TableStake: 100},
+	}, playable.AdditionalData{})
+	a.NoError(err)
+	a.IsType(&fivecarddraw.Game{}, game)
+}
+
+func Test_fiveCardDrawFactory_Details(t *testing.T) {
+	a := assert.New(t)
+	name, ante, err := factories["five-card-draw"].Details(playable.AdditionalData{})
+	a.NoError(err)
+	a.Equal("Five-Card Draw", name)
+	a.Equal(25, ante)
+
+	name, ante, err = factories["five-card-draw"].Details(playable.AdditionalData{
+		"ante":          float64(50),
+		"jacksOrBetter": true,
+		"tripsToWin":    true,
+	})
+	a.NoError(err)
+	a.Equal("Five-Card Draw (Jacks or Better, Trips to Win)", name)
+	a.Equal(50, ante)
+}
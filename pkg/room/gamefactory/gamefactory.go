@@ -8,25 +8,29 @@ import (
 )
 
 var factories = map[string]GameFactory{
-	"bourre":        bourreFactory{},
-	"seven-card":    sevenCardFactory{},
-	"pass-the-poop": passThePoopFactory{},
-	"little-l":      littleLFactory{},
-	"acey-deucey":   aceyDeuceyFactory{},
-	"texas-hold-em": texasHoldEmFactory{},
-	"guts":          gutsFactory{},
+	"bourre":         bourreFactory{},
+	"seven-card":     sevenCardFactory{},
+	"pass-the-poop":  passThePoopFactory{},
+	"little-l":       littleLFactory{},
+	"acey-deucey":    aceyDeuceyFactory{},
+	"texas-hold-em":  texasHoldEmFactory{},
+	"guts":           gutsFactory{},
+	"five-card-draw": fiveCardDrawFactory{},
+	"indian-poker":   indianPokerFactory{},
 }
 
 // GameFactory is a factory for creating games that implement the Playable interface
+// Every game is dealt to the players with their table stakes, and no player can lose more than their stake
 type GameFactory interface {
-	CreateGame(logger logrus.FieldLogger, playerIDs []int64, additionalData playable.AdditionalData) (playable.Playable, error)
+	CreateGameV2(logger logrus.FieldLogger, players []*model.PlayerTable, additionalData playable.AdditionalData) (playable.Playable, error)
 	Details(additionalData playable.AdditionalData) (name string, ante int, err error)
 }
 
-// V2 is a factory for creating games that implement the Playable interface
-type V2 interface {
-	CreateGameV2(logger logrus.FieldLogger, players []*model.PlayerTable, additionalData playable.AdditionalData) (playable.Playable, error)
-	Details(additionalData playable.AdditionalData) (name string, ante int, err error)
+// Session is a factory for games that can be dealt hand after hand in a cash-game session
+// Each hand is dealt to the provided players with their stacks from the previous hand
+type Session interface {
+	GameFactory
+	CreateHand(logger logrus.FieldLogger, players []playable.Player, additionalData playable.AdditionalData) (playable.Playable, error)
 }
 
 // Get returns a factory by the given name
@@ -2,6 +2,7 @@ package gamefactory
 
 import (
 	"github.com/sirupsen/logrus"
+	"mondaynightpoker-server/pkg/model"
 	"mondaynightpoker-server/pkg/playable"
 	"mondaynightpoker-server/pkg/playable/guts"
 )
@@ -13,9 +14,10 @@ func (g gutsFactory) Details(additionalData playable.AdditionalData) (string, in
 	return guts.NameFromOptions(opts), opts.Ante, nil
 }
 
-func (g gutsFactory) CreateGame(logger logrus.FieldLogger, playerIDs []int64, additionalData playable.AdditionalData) (playable.Playable, error) {
+func (g gutsFactory) CreateGameV2(logger logrus.FieldLogger, players []*model.PlayerTable, additionalData playable.AdditionalData) (playable.Playable, error) {
+	p := getPlayersFromPlayerTableList(players)
 	opts := getGutsOptions(additionalData)
-	game, err := guts.NewGame(logger, playerIDs, opts)
+	game, err := guts.NewGame(logger, p, opts)
 	if err != nil {
 		return nil, err
 	}
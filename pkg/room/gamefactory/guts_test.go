@@ -5,6 +5,7 @@ import (
 
 	"github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/assert"
+	"mondaynightpoker-server/pkg/model"
 	"mondaynightpoker-server/pkg/playable"
 )
 
@@ -33,10 +34,13 @@ func Test_gutsFactory_Details(t *testing.T) {
 	assert.Equal(t, 25, ante)
 }
 
-func Test_gutsFactory_CreateGame(t *testing.T) {
+func Test_gutsFactory_CreateGameV2(t *testing.T) {
 	factory := factories["guts"]
 
-	game, err := factory.CreateGame(logrus.StandardLogger(), []int64{1, 2}, playable.AdditionalData{
+	game, err := factory.CreateGameV2(logrus.StandardLogger(), []*model.PlayerTable{
+		{PlayerID: 1, TableStake: 100},
+		{PlayerID: 2, TableStake: 100},
+	}, playable.AdditionalData{
 		"ante": float64(25),
 	})
 	assert.NoError(t, err)
@@ -44,11 +48,13 @@ func Test_gutsFactory_CreateGame(t *testing.T) {
 	assert.Equal(t, "guts", game.Name())
 }
 
-func Test_gutsFactory_CreateGame_InvalidPlayerCount(t *testing.T) {
+func Test_gutsFactory_CreateGameV2_InvalidPlayerCount(t *testing.T) {
 	factory := factories["guts"]
 
 	// Too few players
-	game, err := factory.CreateGame(logrus.StandardLogger(), []int64{1}, playable.AdditionalData{})
+	game, err := factory.CreateGameV2(logrus.StandardLogger(), []*model.PlayerTable{
+		{PlayerID: 1, TableStake: 100},
+	}, playable.AdditionalData{})
 	assert.Error(t, err)
 	assert.Nil(t, game)
 }
@@ -0,0 +1,42 @@
+package gamefactory
+
+import (
+	"github.com/sirupsen/logrus"
+	"mondaynightpoker-server/pkg/model"
+	"mondaynightpoker-server/pkg/playable"
+	"mondaynightpoker-server/pkg/playable/poker/indianpoker"
+)
+
+type indianPokerFactory struct{}
+
+func (i indianPokerFactory) Details(additionalData playable.AdditionalData) (string, int, error) {
+	opts := getIndianPokerOptions(additionalData)
+	return indianpoker.NameFromOptions(opts), opts.Ante, nil
+}
+
+func (i indianPokerFactory) CreateGameV2(logger logrus.FieldLogger, players []*model.PlayerTable, additionalData playable.AdditionalData) (playable.Playable, error) {
+	p := getPlayersFromPlayerTableList(players)
+	game, err := indianpoker.NewGame(logger, p, getIndianPokerOptions(additionalData))
+	if err != nil {
+		return nil, err
+	}
+
+	if err := game.DealCards(); err != nil {
+		return nil, err
+	}
+
+	return game, nil
+}
+
+func getIndianPokerOptions(additionalData playable.AdditionalData) indianpoker.Options {
+	opts := indianpoker.DefaultOptions()
+	if ante, _ := additionalData.GetInt("ante"); ante > 0 {
+		opts.Ante = ante
+	}
+
+	if cards, _ := additionalData.GetInt("cards"); cards > 0 {
+		opts.Cards = cards
+	}
+
+	return opts
+}
@@ -0,0 +1,43 @@
+package gamefactory
+
+import (
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"mondaynightpoker-server/pkg/model"
+	"mondaynightpoker-server/pkg/playable"
+	"mondaynightpoker-server/pkg/playable/poker/indianpoker"
+	"testing"
+)
+
+func Test_indianPokerFactory_CreateGameV2(t *testing.T) {
+	a := assert.New(t)
+	game, err := factories["indian-poker"].CreateGameV2(logrus.StandardLogger(), []*model.PlayerTable{
+		{PlayerID: 1, TableStake: 100},
+		{PlayerID: 2, TableStake: 100},
+	}, playable.AdditionalData{})
+	a.NoError(err)
+	a.IsType(&indianpoker.Game{}, game)
+
+	game, err = factories["indian-poker"].CreateGameV2(logrus.StandardLogger(), []*model.PlayerTable{
+		{PlayerID: 1, TableStake: 100},
+		{PlayerID: 2, TableStake: 100},
+	}, playable.AdditionalData{"cards": float64(4)})
+	a.EqualError(err, "the number of cards must be between 1 and 3")
+	a.Nil(game)
+}
+
+func Test_indianPokerFactory_Details(t *testing.T) {
+	a := assert.New(t)
+	name, ante, err := factories["indian-poker"].Details(playable.AdditionalData{})
+	a.NoError(err)
+	a.Equal("Indian Poker", name)
+	a.Equal(25, ante)
+
+	name, ante, err = factories["indian-poker"].Details(playable.AdditionalData{
+		"ante":  float64(50),
+		"cards": float64(2),
+	})
+	a.NoError(err)
+	a.Equal("2-Card Indian Poker", name)
+	a.Equal(50, ante)
+}
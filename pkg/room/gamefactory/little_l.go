@@ -19,10 +19,6 @@ func (l littleLFactory) Details(additionalData playable.AdditionalData) (string,
 	return name, opts.Ante, nil
 }
 
-func (l littleLFactory) CreateGame(_ logrus.FieldLogger, _ []int64, _ playable.AdditionalData) (playable.Playable, error) {
-	panic("use CreateGameV2")
-}
-
 func (l littleLFactory) CreateGameV2(logger logrus.FieldLogger, players []*model.PlayerTable, additionalData playable.AdditionalData) (playable.Playable, error) {
 	p := getPlayersFromPlayerTableList(players)
 
@@ -52,5 +48,9 @@ func getOptions(additionalData playable.AdditionalData) littlel.Options {
 		opts.TradeIns = tradeIns
 	}
 
+	if joker, ok := additionalData.GetBool("joker"); ok {
+		opts.Joker = joker
+	}
+
 	return opts
 }
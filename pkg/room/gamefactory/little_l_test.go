@@ -34,4 +34,11 @@ func Test_littleLFactory_Name(t *testing.T) {
 	assert.EqualError(t, err, "invalid trade-in option: 4")
 	assert.Empty(t, name)
 	assert.Empty(t, ante)
+
+	name, _, err = factories["little-l"].Details(playable.AdditionalData{
+		"joker": true,
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "4-Card Little L (trade: 0, 2) with the Bug", name)
 }
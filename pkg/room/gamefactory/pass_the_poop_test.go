@@ -1,8 +1,11 @@
 package gamefactory
 
 import (
+	"github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/assert"
+	"mondaynightpoker-server/pkg/model"
 	"mondaynightpoker-server/pkg/playable"
+	"mondaynightpoker-server/pkg/playable/passthepoop"
 	"testing"
 )
 
@@ -42,3 +45,16 @@ func Test_passThePoopFactory_Name(t *testing.T) {
 	a.Equal(75, ante)
 	a.Equal("Pass the Poop, Pairs Edition (with Blocks)", name)
 }
+
+func Test_passThePoopFactory_CreateGameV2(t *testing.T) {
+	a := assert.New(t)
+	game, err := factories["pass-the-poop"].CreateGameV2(logrus.StandardLogger(), []*model.PlayerTable{
+		{PlayerID: 1, TableStake: 100},
+		{PlayerID: 2, TableStake: 100},
+	}, playable.AdditionalData{
+		"edition": "standard",
+		"ante":    float64(25),
+	})
+	a.NoError(err)
+	a.IsType(&passthepoop.Game{}, game)
+}
@@ -16,26 +16,7 @@ func (s sevenCardFactory) Details(additionalData playable.AdditionalData) (name
 		return "", 0, err
 	}
 
-	return opts.Variant.Name(), opts.Ante, nil
-}
-
-// CreateGame is deprecated, use CreateGameV2 instead
-func (s sevenCardFactory) CreateGame(logger logrus.FieldLogger, playerIDs []int64, additionalData playable.AdditionalData) (playable.Playable, error) {
-	opts, err := s.getOptions(additionalData)
-	if err != nil {
-		return nil, err
-	}
-
-	game, err := sevencard.NewGame(logger, playerIDs, opts)
-	if err != nil {
-		return nil, err
-	}
-
-	if err := game.Start(); err != nil {
-		return nil, err
-	}
-
-	return game, nil
+	return opts.Name(), opts.Ante, nil
 }
 
 // CreateGameV2 creates a new seven-card game with table stake support
@@ -64,6 +45,10 @@ func (s sevenCardFactory) getOptions(additionalData playable.AdditionalData) (se
 		opts.Ante = ante
 	}
 
+	if joker, ok := additionalData.GetBool("joker"); ok {
+		opts.Joker = joker
+	}
+
 	if variant, _ := additionalData.GetString("variant"); variant != "" {
 		switch variant {
 		case "stud":
@@ -80,6 +65,10 @@ func (s sevenCardFactory) getOptions(additionalData playable.AdditionalData) (se
 			opts.Variant = &sevencard.Chiggs{}
 		case "coupons-and-clippings":
 			opts.Variant = &sevencard.CouponsAndClippings{}
+		case "anaconda":
+			opts.Variant = &sevencard.Anaconda{}
+		case "night-baseball":
+			opts.Variant = &sevencard.NightBaseball{}
 		default:
 			return sevencard.Options{}, fmt.Errorf("unknown seven-card variant: %s", variant)
 		}
@@ -32,4 +32,27 @@ func Test_sevenCardFactory_Name(t *testing.T) {
 	a.NoError(err)
 	a.Equal(75, ante)
 	a.Equal("Low Card Wild", name)
+
+	name, ante, err = factories["seven-card"].Details(playable.AdditionalData{
+		"variant": "anaconda",
+		"ante":    float64(25),
+	})
+	a.NoError(err)
+	a.Equal(25, ante)
+	a.Equal("Anaconda", name)
+
+	name, ante, err = factories["seven-card"].Details(playable.AdditionalData{
+		"variant": "night-baseball",
+		"ante":    float64(25),
+	})
+	a.NoError(err)
+	a.Equal(25, ante)
+	a.Equal("Night Baseball", name)
+
+	name, _, err = factories["seven-card"].Details(playable.AdditionalData{
+		"variant": "follow-the-queen",
+		"joker":   true,
+	})
+	a.NoError(err)
+	a.Equal("Follow the Queen with the Bug", name)
 }
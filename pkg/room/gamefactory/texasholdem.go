@@ -14,14 +14,22 @@ func (t texasHoldEmFactory) CreateGameV2(logger logrus.FieldLogger, players []*m
 	return texasholdem.NewGame(logger, p, texasHoldEmOptions(additionalData))
 }
 
-func (t texasHoldEmFactory) CreateGame(_ logrus.FieldLogger, _ []int64, _ playable.AdditionalData) (playable.Playable, error) {
-	panic("use CreateGameV2")
+func (t texasHoldEmFactory) CreateHand(logger logrus.FieldLogger, players []playable.Player, additionalData playable.AdditionalData) (playable.Playable, error) {
+	return texasholdem.NewGame(logger, players, texasHoldEmOptions(additionalData))
 }
 
 func (t texasHoldEmFactory) Details(additionalData playable.AdditionalData) (name string, ante int, err error) {
 	opts := texasHoldEmOptions(additionalData)
+	if err := texasholdem.ValidateOptions(opts); err != nil {
+		return "", 0, err
+	}
+
 	name = texasholdem.NameFromOptions(opts)
 
+	if opts.BombPot > 0 {
+		return name, opts.BombPot, nil
+	}
+
 	return name, opts.Ante, nil
 }
 
@@ -48,5 +56,13 @@ func texasHoldEmOptions(additionData playable.AdditionalData) texasholdem.Option
 		opts.BigBlind = bigBlind
 	}
 
+	if straddle, ok := additionData.GetBool("straddle"); ok {
+		opts.Straddle = straddle
+	}
+
+	if bombPot, ok := additionData.GetInt("bombPot"); ok && bombPot >= 0 {
+		opts.BombPot = bombPot
+	}
+
 	return opts
 }
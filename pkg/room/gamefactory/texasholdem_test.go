@@ -9,16 +9,10 @@ import (
 	"testing"
 )
 
-func Test_texasHoldEmFactory_CreateGame(t *testing.T) {
-	assert.PanicsWithValue(t, "use CreateGameV2", func() {
-		_, _ = factories["texas-hold-em"].CreateGame(logrus.StandardLogger(), []int64{1, 2, 3}, playable.AdditionalData{})
-	})
-}
-
 func Test_texasHoldEmFactory_CreateGameV2(t *testing.T) {
 	a := assert.New(t)
 
-	game, err := factories["texas-hold-em"].(V2).CreateGameV2(logrus.StandardLogger(), []*model.PlayerTable{
+	game, err := factories["texas-hold-em"].CreateGameV2(logrus.StandardLogger(), []*model.PlayerTable{
 		{PlayerID: 1, TableStake: 100},
 		{PlayerID: 2, TableStake: 100},
 	}, playable.AdditionalData{})
@@ -26,6 +20,22 @@ func Test_texasHoldEmFactory_CreateGameV2(t *testing.T) {
 	a.IsType(&texasholdem.Game{}, game)
 }
 
+func Test_texasHoldEmFactory_CreateHand(t *testing.T) {
+	a := assert.New(t)
+
+	players := []playable.Player{
+		&model.PlayerTable{PlayerID: 1, TableStake: 1000},
+		&model.PlayerTable{PlayerID: 2, TableStake: 1000},
+	}
+
+	game, err := factories["texas-hold-em"].(Session).CreateHand(logrus.StandardLogger(), players, playable.AdditionalData{"bigBlind": float64(100)})
+	a.NoError(err)
+	a.Equal("Texas Hold'em (${25}/${100})", game.Name())
+
+	_, isSession := factories["bourre"].(Session)
+	a.False(isSession)
+}
+
 func Test_texasHoldEmFactory_Details(t *testing.T) {
 	a := assert.New(t)
 	name, ante, err := factories["texas-hold-em"].Details(playable.AdditionalData{})
@@ -41,4 +51,35 @@ func Test_texasHoldEmFactory_Details(t *testing.T) {
 	a.NoError(err)
 	a.Equal("Texas Hold'em (${75}/${100})", name)
 	a.Equal(0, ante)
+
+	name, _, err = factories["texas-hold-em"].Details(playable.AdditionalData{
+		"variant": "double-board",
+	})
+	a.NoError(err)
+	a.Equal("Double-Board Texas Hold'em (${25}/${50})", name)
+
+	name, _, err = factories["texas-hold-em"].Details(playable.AdditionalData{
+		"variant": "short-deck",
+	})
+	a.NoError(err)
+	a.Equal("Short-Deck Texas Hold'em (${25}/${50})", name)
+
+	name, ante, err = factories["texas-hold-em"].Details(playable.AdditionalData{
+		"straddle": true,
+	})
+	a.NoError(err)
+	a.Equal("Texas Hold'em (${25}/${50}) with straddle", name)
+	a.Equal(25, ante)
+
+	name, ante, err = factories["texas-hold-em"].Details(playable.AdditionalData{
+		"bombPot": float64(100),
+	})
+	a.NoError(err)
+	a.Equal("Texas Hold'em (${25}/${50}), ${100} bomb pot", name)
+	a.Equal(100, ante)
+
+	_, _, err = factories["texas-hold-em"].Details(playable.AdditionalData{
+		"bombPot": float64(1000),
+	})
+	a.EqualError(err, "bomb pot must be at most ${500}")
 }
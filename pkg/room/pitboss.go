@@ -6,17 +6,19 @@ import (
 
 // PitBoss is responsible for dispatching players to games
 type PitBoss struct {
-	dealers    map[string]*Dealer
-	connect    chan *Client
-	disconnect chan *Client
+	dealers       map[string]*Dealer
+	connect       chan *Client
+	disconnect    chan *Client
+	adjustBalance chan *balanceRequest
 }
 
 // NewPitBoss returns a new dispatch object
 func NewPitBoss() *PitBoss {
 	return &PitBoss{
-		dealers:    make(map[string]*Dealer),
-		connect:    make(chan *Client, 256),
-		disconnect: make(chan *Client, 256),
+		dealers:       make(map[string]*Dealer),
+		connect:       make(chan *Client, 256),
+		disconnect:    make(chan *Client, 256),
+		adjustBalance: make(chan *balanceRequest, 256),
 	}
 }
 
@@ -50,6 +52,8 @@ func (p *PitBoss) runLoop() {
 				dealer.EndShift()
 				delete(p.dealers, client.table.UUID)
 			}
+		case req := <-p.adjustBalance:
+			p.handleBalanceRequest(req)
 		}
 	}
 }
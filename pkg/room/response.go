@@ -9,6 +9,9 @@ type clientStatePlayers struct {
 	*model.PlayerTable
 	IsConnected bool `json:"isConnected"`
 	IsSeated    bool `json:"isSeated"`
+
+	// AtCreditLimit is true if the player has no credit left and is not dealt in
+	AtCreditLimit bool `json:"atCreditLimit"`
 }
 
 func newErrorResponse(ctx string, err error) *playable.Response {
@@ -0,0 +1,417 @@
+package room
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"mondaynightpoker-server/pkg/model"
+	"mondaynightpoker-server/pkg/playable"
+	"mondaynightpoker-server/pkg/room/gamefactory"
+	"sort"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// handDelay is how long the dealer waits between the hands of a session
+var handDelay = time.Second * 5
+
+// session is a cash game where the dealer deals hand after hand of the same game until it is stopped
+// The players' stacks are carried from one hand to the next and the button moves one seat each hand
+// Note: the session must only be manipulated within the run loop
+type session struct {
+	Name     string        `json:"name"`
+	Hands    int           `json:"hands"`
+	Button   int64         `json:"button"`
+	Stacks   map[int64]int `json:"stacks"`
+	Stopping bool          `json:"stopping"`
+	NextHand *time.Time    `json:"nextHand"`
+	PlayerID int64         `json:"playerId"`
+
+	// BombPot is the bomb pot the table called for the next hand
+	BombPot int `json:"bombPot"`
+
+	// Tournament is set if the session is a tournament instead of a cash game
+	Tournament *tournament `json:"tournament,omitempty"`
+
+	factory gamefactory.Session
+	message *playable.PayloadIn
+	timer   *time.Timer
+}
+
+func newSession(c *Client, factory gamefactory.Session, msg *playable.PayloadIn) (*session, error) {
+	s := &session{
+		Stacks:   make(map[int64]int),
+		PlayerID: c.player.ID,
+		factory:  factory,
+		message:  msg,
+	}
+
+	if isTournamentRequest(msg) {
+		t, err := newTournament(factory, msg.AdditionalData)
+		if err != nil {
+			return nil, err
+		}
+
+		s.Tournament = t
+	}
+
+	name, _, err := factory.Details(s.handOptions())
+	if err != nil {
+		return nil, err
+	}
+
+	s.Name = name
+	return s, nil
+}
+
+// sessionPlayer is a player in a hand of a session
+type sessionPlayer struct {
+	id    int64
+	stack int
+}
+
+// GetPlayerID returns the player ID
+func (s *sessionPlayer) GetPlayerID() int64 {
+	return s.id
+}
+
+// GetTableStake returns the player's stack in the session
+func (s *sessionPlayer) GetTableStake() int {
+	return s.stack
+}
+
+// seat returns the players of the next hand with the button last
+// players must be in seat order, starting to the left of the table's button. Only the players who are playing are dealt in, and a player without
+// a stack buys in with their table stake
+func (s *session) seat(players []*model.PlayerTable) []playable.Player {
+	button := s.nextButton(players)
+	if button < 0 {
+		return nil
+	}
+
+	seated := make([]playable.Player, 0, len(players))
+	for i := 1; i <= len(players); i++ {
+		pt := players[(button+i)%len(players)]
+		if !s.isDealtIn(pt) {
+			continue
+		}
+
+		stack, ok := s.Stacks[pt.PlayerID]
+		if !ok || stack <= 0 {
+			stack = pt.GetTableStake()
+			s.Stacks[pt.PlayerID] = stack
+		}
+
+		seated = append(seated, &sessionPlayer{
+			id:    pt.PlayerID,
+			stack: stack,
+		})
+	}
+
+	s.Button = players[button].PlayerID
+	return seated
+}
+
+// nextButton returns the index of the player who has the button in the next hand
+// The button moves to the next playing seat after the previous button
+func (s *session) nextButton(players []*model.PlayerTable) int {
+	if s.Hands == 0 {
+		// the button moves from the table's button to the first player who is dealt in
+		for i, pt := range players {
+			if s.isDealtIn(pt) {
+				return i
+			}
+		}
+
+		return -1
+	}
+
+	// if the previous button left the table, the button starts over at the first seat
+	previous := -1
+	for i, pt := range players {
+		if pt.PlayerID == s.Button {
+			previous = i
+			break
+		}
+	}
+
+	for i := 1; i <= len(players); i++ {
+		index := (previous + i) % len(players)
+		if s.isDealtIn(players[index]) {
+			return index
+		}
+	}
+
+	return -1
+}
+
+// isDealtIn returns true if the player is dealt in the next hand
+// In a tournament, the entrants are dealt in until they run out of chips
+func (s *session) isDealtIn(pt *model.PlayerTable) bool {
+	if s.Tournament != nil {
+		return s.Stacks[pt.PlayerID] > 0
+	}
+
+	return pt.IsPlaying()
+}
+
+// settle carries the results of a hand to the players' stacks
+// It returns the IDs of the players who ran out of chips
+func (s *session) settle(balanceAdjustments map[int64]int) []int64 {
+	busted := make([]int64, 0)
+	for id, adjustment := range balanceAdjustments {
+		s.Stacks[id] += adjustment
+		if s.Stacks[id] <= 0 {
+			busted = append(busted, id)
+		}
+	}
+
+	sort.Slice(busted, func(i, j int) bool {
+		return busted[i] < busted[j]
+	})
+
+	return busted
+}
+
+// handOptions returns the options of the next hand
+func (s *session) handOptions() playable.AdditionalData {
+	additionalData := s.message.AdditionalData
+	if s.Tournament != nil {
+		additionalData = s.Tournament.handOptions(additionalData, s.Tournament.Level)
+	}
+
+	options := make(playable.AdditionalData, len(additionalData)+1)
+	for key, value := range additionalData {
+		options[key] = value
+	}
+
+	if s.BombPot > 0 {
+		options["bombPot"] = float64(s.BombPot)
+	}
+
+	return options
+}
+
+// callBombPot makes the next hand a bomb pot where every player antes the amount
+func (s *session) callBombPot(amount int) error {
+	if amount <= 0 {
+		return errors.New("the bomb pot must be more than ${0}")
+	}
+
+	previous := s.BombPot
+	s.BombPot = amount
+	if _, _, err := s.factory.Details(s.handOptions()); err != nil {
+		s.BombPot = previous
+		return err
+	}
+
+	return nil
+}
+
+func (s *session) stopTimer() {
+	if s.timer != nil && !s.timer.Stop() {
+		select {
+		case <-s.timer.C:
+		default:
+		}
+	}
+
+	s.timer = nil
+	s.NextHand = nil
+}
+
+// isSessionRequest returns true if the message asks for a cash game or a tournament
+func isSessionRequest(msg *playable.PayloadIn) bool {
+	isSession, _ := msg.AdditionalData.GetBool("session")
+	return isSession || isTournamentRequest(msg)
+}
+
+// getSessionFactory returns the factory of a game that can be played as a cash game
+func getSessionFactory(msg *playable.PayloadIn) (gamefactory.Session, error) {
+	factory, err := gamefactory.Get(msg.Subject)
+	if err != nil {
+		return nil, err
+	}
+
+	sf, ok := factory.(gamefactory.Session)
+	if !ok {
+		return nil, fmt.Errorf("%s cannot be played as a cash game", msg.Subject)
+	}
+
+	return sf, nil
+}
+
+// startSession starts a cash game and deals the first hand
+func (d *Dealer) startSession(client *Client, msg *playable.PayloadIn) error {
+	sf, err := getSessionFactory(msg)
+	if err != nil {
+		return err
+	}
+
+	s, err := newSession(client, sf, msg)
+	if err != nil {
+		return err
+	}
+
+	d.session = s
+	if s.Tournament != nil {
+		d.sendLogMessages(playable.SimpleLogMessageSlice(client.player.ID, "{} started a tournament of %s with a buy-in of ${%d}", s.Name, s.Tournament.BuyIn))
+	} else {
+		d.sendLogMessages(playable.SimpleLogMessageSlice(client.player.ID, "{} started a cash game of %s", s.Name))
+	}
+
+	return d.dealHand()
+}
+
+// dealHand deals the next hand of the session
+// The session ends if there are not enough players
+func (d *Dealer) dealHand() error {
+	s := d.session
+	s.stopTimer()
+
+	players, err := d.table.GetSeatedPlayers(context.Background())
+	if err != nil {
+		d.endSession()
+		return err
+	}
+
+	if s.Tournament == nil || s.Hands == 0 {
+		// the credit limit of a tournament is checked when the players enter
+		d.skipPlayersAtCreditLimit(players)
+	}
+
+	if t := s.Tournament; t != nil {
+		if s.Hands == 0 {
+			if err := t.register(players, s.Stacks); err != nil {
+				d.endSession()
+				return err
+			}
+		} else if t.updateLevel(s.Hands, time.Now()) {
+			level := t.Levels[t.Level]
+			d.sendLogMessages(playable.SimpleLogMessageSlice(0, "the blinds are now ${%d}/${%d} with an ante of ${%d}", level.SmallBlind, level.BigBlind, level.Ante))
+		}
+	}
+
+	seated := s.seat(players)
+	if len(seated) < 2 {
+		d.endSession()
+		return errors.New("the cash game needs at least two players")
+	}
+
+	logger := logrus.WithFields(logrus.Fields{
+		"startedBy": s.PlayerID,
+		"game":      s.Name,
+		"table":     d.table.UUID,
+		"hand":      s.Hands + 1,
+	})
+
+	game, err := s.factory.CreateHand(logger, seated, s.handOptions())
+	if err != nil {
+		d.endSession()
+		return err
+	}
+
+	record, err := d.recordGameStart(game, seated, players)
+	if err != nil {
+		d.endSession()
+		return err
+	}
+
+	s.BombPot = 0
+	s.Hands++
+	logger.WithField("gameID", record.ID).Info("hand started")
+
+	playerIDs := make([]int64, len(seated))
+	for i, p := range seated {
+		playerIDs[i] = p.GetPlayerID()
+	}
+
+	d.startGame(game, record, playerIDs)
+	for _, pt := range players {
+		if pt.PlayerID == s.Button && pt.Seat != nil {
+			d.buttonSeat = *pt.Seat
+		}
+	}
+
+	d.stateChanged <- stateSessionChanged
+	return nil
+}
+
+// sessionHandEnded carries the results of the hand to the session and schedules the next hand
+// Players who run out of chips sit out until they sit back in and buy in again
+// Note: this must only be called within the run loop, which waits on the timer of the next hand
+func (d *Dealer) sessionHandEnded(balanceAdjustments map[int64]int) {
+	s := d.session
+	busted := s.settle(balanceAdjustments)
+	if s.Tournament != nil {
+		if d.tournamentHandEnded(busted, balanceAdjustments) {
+			d.endSession()
+			return
+		}
+	} else if len(busted) > 0 {
+		d.sitOut(busted)
+	}
+
+	if s.Stopping {
+		d.endSession()
+		return
+	}
+
+	next := time.Now().Add(handDelay)
+	s.NextHand = &next
+	s.timer = time.NewTimer(handDelay)
+	d.stateChanged <- stateSessionChanged
+}
+
+func (d *Dealer) sitOut(playerIDs []int64) {
+	players, err := d.table.GetPlayers(context.Background())
+	if err != nil {
+		logrus.WithError(err).Error("could not get players")
+		return
+	}
+
+	for _, id := range playerIDs {
+		for _, pt := range players {
+			if pt.PlayerID != id || !pt.Active {
+				continue
+			}
+
+			pt.Active = false
+			if err := pt.Save(context.Background()); err != nil {
+				logrus.WithError(err).WithField("playerId", id).Error("could not sit out player")
+				continue
+			}
+
+			d.sendLogMessages(playable.SimpleLogMessageSlice(id, "{} is out of chips and is sitting out"))
+		}
+	}
+
+	d.stateChanged <- stateClientEvent
+}
+
+// endSession ends the cash game, if there is one
+func (d *Dealer) endSession() {
+	s := d.session
+	if s == nil {
+		return
+	}
+
+	s.stopTimer()
+	d.session = nil
+	hands := "hands"
+	if s.Hands == 1 {
+		hands = "hand"
+	}
+
+	switch {
+	case s.Tournament == nil:
+		d.sendLogMessages(playable.SimpleLogMessageSlice(0, "the cash game ended after %d %s", s.Hands, hands))
+	case s.Tournament.Finished:
+		d.sendLogMessages(playable.SimpleLogMessageSlice(0, "the tournament ended after %d %s", s.Hands, hands))
+	default:
+		d.sendLogMessages(playable.SimpleLogMessageSlice(0, "the tournament was stopped after %d %s and the buy-ins were not charged", s.Hands, hands))
+	}
+
+	d.stateChanged <- stateSessionChanged
+}
@@ -0,0 +1,146 @@
+package room
+
+import (
+	"mondaynightpoker-server/pkg/model"
+	"mondaynightpoker-server/pkg/playable"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSession_seat(t *testing.T) {
+	a := assert.New(t)
+
+	players := []*model.PlayerTable{
+		{PlayerID: 1, Active: true, TableStake: 1000},
+		{PlayerID: 2, Active: true, TableStake: 1000, Balance: 2500},
+		{PlayerID: 3, Active: true, TableStake: 1000},
+		{PlayerID: 4, Active: false, TableStake: 1000},
+	}
+
+	s := &session{Stacks: make(map[int64]int)}
+
+	// the first player to the left of the table's button gets the button
+	seated := s.seat(players)
+	a.Equal([]int64{2, 3, 1}, sessionPlayerIDs(seated))
+	a.Equal(int64(1), s.Button)
+	a.Equal(map[int64]int{1: 1000, 2: 2500, 3: 1000}, s.Stacks)
+
+	// the button moves one seat
+	s.Hands++
+	s.settle(map[int64]int{1: 150, 2: -100, 3: -50})
+	seated = s.seat(players)
+	a.Equal([]int64{3, 1, 2}, sessionPlayerIDs(seated))
+	a.Equal(int64(2), s.Button)
+	a.Equal(1150, seated[1].GetTableStake())
+	a.Equal(2400, seated[2].GetTableStake())
+
+	// a player sits in, and the button skips a player who sits out
+	s.Hands++
+	players[1].Active = false
+	players[3].Active = true
+	seated = s.seat(players)
+	a.Equal([]int64{4, 1, 3}, sessionPlayerIDs(seated))
+	a.Equal(int64(3), s.Button)
+	a.Equal(1000, seated[0].GetTableStake())
+
+	// a player who ran out of chips buys in again
+	s.Hands++
+	a.Equal([]int64{1}, s.settle(map[int64]int{1: -1150, 3: 1150}))
+	seated = s.seat(players)
+	a.Equal([]int64{1, 3, 4}, sessionPlayerIDs(seated))
+	a.Equal(int64(4), s.Button)
+	a.Equal(1000, seated[0].GetTableStake())
+	a.Equal(2100, seated[1].GetTableStake())
+}
+
+func TestDealer_sessionHandEnded(t *testing.T) {
+	a := assert.New(t)
+
+	d := NewDealer(&PitBoss{}, &model.Table{})
+	d.session = &session{Stacks: map[int64]int{1: 1000, 2: 1000}}
+	go d.runLoop()
+	defer func() {
+		d.close <- true
+	}()
+
+	done := make(chan bool)
+	d.execInRunLoop <- func() {
+		d.sessionHandEnded(map[int64]int{1: 250, 2: -250})
+		done <- true
+	}
+	<-done
+
+	d.execInRunLoop <- func() {
+		a.Equal(map[int64]int{1: 1250, 2: 750}, d.session.Stacks)
+		a.NotNil(d.session.NextHand)
+		if a.NotNil(d.session.timer) {
+			d.session.timer.Stop()
+		}
+
+		done <- true
+	}
+	<-done
+}
+
+func TestSession_seat_notEnoughPlayers(t *testing.T) {
+	a := assert.New(t)
+
+	s := &session{Stacks: make(map[int64]int)}
+	a.Empty(s.seat([]*model.PlayerTable{
+		{PlayerID: 1, Active: false},
+		{PlayerID: 2, Active: true, IsBlocked: true},
+	}))
+
+	seated := s.seat([]*model.PlayerTable{
+		{PlayerID: 1, Active: false},
+		{PlayerID: 2, Active: true, TableStake: 500},
+	})
+	a.Equal([]int64{2}, sessionPlayerIDs(seated))
+}
+
+func sessionPlayerIDs(players []playable.Player) []int64 {
+	ids := make([]int64, len(players))
+	for i, p := range players {
+		ids[i] = p.GetPlayerID()
+	}
+
+	return ids
+}
+
+func TestSession_callBombPot(t *testing.T) {
+	a := assert.New(t)
+
+	msg := &playable.PayloadIn{Subject: "texas-hold-em", AdditionalData: playable.AdditionalData{"session": true, "straddle": true}}
+	factory, err := getSessionFactory(msg)
+	a.NoError(err)
+
+	s := &session{Stacks: make(map[int64]int), factory: factory, message: msg}
+	a.Equal(playable.AdditionalData{"session": true, "straddle": true}, s.handOptions())
+
+	a.EqualError(s.callBombPot(0), "the bomb pot must be more than ${0}")
+	a.EqualError(s.callBombPot(1000), "bomb pot must be at most ${500}")
+	a.Equal(0, s.BombPot)
+
+	a.NoError(s.callBombPot(100))
+	a.Equal(100, s.BombPot)
+	a.Equal(playable.AdditionalData{"session": true, "straddle": true, "bombPot": float64(100)}, s.handOptions())
+
+	// the bomb pot is only for the next hand
+	_, hasBombPot := msg.AdditionalData["bombPot"]
+	a.False(hasBombPot)
+}
+
+func TestGetSessionFactory(t *testing.T) {
+	a := assert.New(t)
+
+	msg := &playable.PayloadIn{Subject: "texas-hold-em", AdditionalData: playable.AdditionalData{"session": true}}
+	a.True(isSessionRequest(msg))
+	_, err := getSessionFactory(msg)
+	a.NoError(err)
+
+	msg = &playable.PayloadIn{Subject: "bourre", AdditionalData: playable.AdditionalData{}}
+	a.False(isSessionRequest(msg))
+	_, err = getSessionFactory(msg)
+	a.EqualError(err, "bourre cannot be played as a cash game")
+}
@@ -0,0 +1,431 @@
+package room
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"mondaynightpoker-server/pkg/model"
+	"mondaynightpoker-server/pkg/playable"
+	"mondaynightpoker-server/pkg/room/gamefactory"
+	"sort"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+const defaultBuyIn = 2000
+const defaultStartingStack = 2000
+const defaultLevelMinutes = 15
+
+// blindLevel is a level of the blind schedule of a tournament
+type blindLevel struct {
+	SmallBlind int `json:"smallBlind"`
+	BigBlind   int `json:"bigBlind"`
+	Ante       int `json:"ante"`
+}
+
+func defaultBlindLevels() []*blindLevel {
+	return []*blindLevel{
+		{SmallBlind: 25, BigBlind: 50},
+		{SmallBlind: 50, BigBlind: 100},
+		{SmallBlind: 75, BigBlind: 150},
+		{SmallBlind: 75, BigBlind: 150, Ante: 25},
+		{SmallBlind: 100, BigBlind: 200, Ante: 25},
+		{SmallBlind: 100, BigBlind: 200, Ante: 50},
+	}
+}
+
+// tournament is a session that is played until one player has all of the chips
+// Every entrant pays the same buy-in and starts with the same stack. The stacks are tournament chips,
+// so the hands do not change the players' balances. When the tournament ends, the prize pool is paid out
+// by the final placement
+type tournament struct {
+	BuyIn         int           `json:"buyIn"`
+	StartingStack int           `json:"startingStack"`
+	Levels        []*blindLevel `json:"levels"`
+
+	// the blinds go up every LevelHands hands, or every LevelMinutes minutes
+	LevelHands   int `json:"levelHands"`
+	LevelMinutes int `json:"levelMinutes"`
+
+	// Payouts is the percentage of the prize pool paid to each place, starting with first place
+	Payouts []int `json:"payouts"`
+
+	Level    int       `json:"level"`
+	Started  time.Time `json:"started"`
+	Entrants []int64   `json:"entrants"`
+
+	// Eliminated contains the player IDs in the order they were eliminated
+	Eliminated []int64 `json:"eliminated"`
+	Finished   bool    `json:"finished"`
+}
+
+// tournamentResult is the final placement of an entrant
+type tournamentResult struct {
+	PlayerID int64 `json:"playerId"`
+	Place    int   `json:"place"`
+	Payout   int   `json:"payout"`
+
+	// Adjustment is the change to the player's balance, i.e., the payout less the buy-in
+	Adjustment int `json:"adjustment"`
+}
+
+// isTournamentRequest returns true if the message asks for a tournament
+func isTournamentRequest(msg *playable.PayloadIn) bool {
+	isTournament, _ := msg.AdditionalData.GetBool("tournament")
+	return isTournament
+}
+
+// newTournament returns a tournament configured by the additional data
+func newTournament(factory gamefactory.Session, additionalData playable.AdditionalData) (*tournament, error) {
+	t := &tournament{
+		BuyIn:         defaultBuyIn,
+		StartingStack: defaultStartingStack,
+		Levels:        defaultBlindLevels(),
+	}
+
+	if buyIn, ok := additionalData.GetInt("buyIn"); ok {
+		t.BuyIn = buyIn
+	}
+
+	if startingStack, ok := additionalData.GetInt("startingStack"); ok {
+		t.StartingStack = startingStack
+	}
+
+	if levels, ok := additionalData["levels"]; ok {
+		parsed, err := parseBlindLevels(levels)
+		if err != nil {
+			return nil, err
+		}
+
+		t.Levels = parsed
+	}
+
+	t.LevelHands, _ = additionalData.GetInt("levelHands")
+	t.LevelMinutes, _ = additionalData.GetInt("levelMinutes")
+	if t.LevelHands == 0 && t.LevelMinutes == 0 {
+		t.LevelMinutes = defaultLevelMinutes
+	}
+
+	if payouts, ok := additionalData.GetIntSlice("payouts"); ok {
+		t.Payouts = payouts
+	}
+
+	if err := t.validate(factory, additionalData); err != nil {
+		return nil, err
+	}
+
+	return t, nil
+}
+
+func parseBlindLevels(value interface{}) ([]*blindLevel, error) {
+	errInvalid := errors.New("levels must be a list of blinds and antes")
+
+	list, ok := value.([]interface{})
+	if !ok {
+		return nil, errInvalid
+	}
+
+	levels := make([]*blindLevel, len(list))
+	for i, item := range list {
+		m, ok := item.(map[string]interface{})
+		if !ok {
+			return nil, errInvalid
+		}
+
+		data := playable.AdditionalData(m)
+		smallBlind, _ := data.GetInt("smallBlind")
+		bigBlind, _ := data.GetInt("bigBlind")
+		ante, _ := data.GetInt("ante")
+		levels[i] = &blindLevel{
+			SmallBlind: smallBlind,
+			BigBlind:   bigBlind,
+			Ante:       ante,
+		}
+	}
+
+	return levels, nil
+}
+
+func (t *tournament) validate(factory gamefactory.Session, additionalData playable.AdditionalData) error {
+	if t.BuyIn < 0 || t.BuyIn%25 > 0 {
+		return errors.New("the buy-in must be in increments of ${25}")
+	}
+
+	if t.StartingStack <= 0 || t.StartingStack%25 > 0 {
+		return errors.New("the starting stack must be in increments of ${25}")
+	}
+
+	if len(t.Levels) == 0 {
+		return errors.New("the tournament must have at least one level")
+	}
+
+	if t.LevelHands < 0 || t.LevelMinutes < 0 || (t.LevelHands > 0 && t.LevelMinutes > 0) {
+		return errors.New("the levels must go up by hands or by minutes")
+	}
+
+	for i := range t.Levels {
+		if _, _, err := factory.Details(t.handOptions(additionalData, i)); err != nil {
+			return fmt.Errorf("level %d: %w", i+1, err)
+		}
+	}
+
+	if t.Payouts != nil {
+		total := 0
+		for _, payout := range t.Payouts {
+			if payout <= 0 {
+				return errors.New("each payout must be more than 0%")
+			}
+
+			total += payout
+		}
+
+		if total != 100 {
+			return errors.New("the payouts must add up to 100%")
+		}
+	}
+
+	return nil
+}
+
+// handOptions returns the options of a hand at the level
+func (t *tournament) handOptions(additionalData playable.AdditionalData, level int) playable.AdditionalData {
+	options := make(playable.AdditionalData, len(additionalData)+3)
+	for key, value := range additionalData {
+		options[key] = value
+	}
+
+	options["smallBlind"] = float64(t.Levels[level].SmallBlind)
+	options["bigBlind"] = float64(t.Levels[level].BigBlind)
+	options["ante"] = float64(t.Levels[level].Ante)
+	return options
+}
+
+// register enters the players who are playing and gives each of them the starting stack
+func (t *tournament) register(players []*model.PlayerTable, stacks map[int64]int) error {
+	entrants := make([]int64, 0, len(players))
+	for _, pt := range players {
+		if pt.IsPlaying() {
+			entrants = append(entrants, pt.PlayerID)
+		}
+	}
+
+	if len(entrants) < 2 {
+		return errors.New("the tournament needs at least two players")
+	}
+
+	if t.Payouts == nil {
+		t.Payouts = defaultPayouts(len(entrants))
+	} else if len(t.Payouts) > len(entrants) {
+		return fmt.Errorf("the tournament pays %d places, but only %d players entered", len(t.Payouts), len(entrants))
+	}
+
+	for _, id := range entrants {
+		stacks[id] = t.StartingStack
+	}
+
+	t.Entrants = entrants
+	t.Started = time.Now()
+	return nil
+}
+
+// defaultPayouts returns the payouts based on the number of entrants
+func defaultPayouts(entrants int) []int {
+	switch {
+	case entrants >= 8:
+		return []int{50, 30, 20}
+	case entrants >= 5:
+		return []int{70, 30}
+	}
+
+	return []int{100}
+}
+
+// updateLevel moves to the level of the blind schedule for the next hand
+// Returns true if the level changed
+func (t *tournament) updateLevel(hands int, now time.Time) bool {
+	var level int
+	if t.LevelHands > 0 {
+		level = hands / t.LevelHands
+	} else {
+		level = int(now.Sub(t.Started) / (time.Duration(t.LevelMinutes) * time.Minute))
+	}
+
+	if level >= len(t.Levels) {
+		level = len(t.Levels) - 1
+	}
+
+	if level == t.Level {
+		return false
+	}
+
+	t.Level = level
+	return true
+}
+
+// eliminate records the players who ran out of chips in a hand
+// balanceAdjustments are the results of the hand. If more than one player is eliminated in the same hand,
+// the player who started the hand with more chips finishes higher
+// Returns the eliminated players in the order they were eliminated
+func (t *tournament) eliminate(busted []int64, balanceAdjustments map[int64]int) []int64 {
+	eliminated := make([]int64, len(busted))
+	copy(eliminated, busted)
+	sort.SliceStable(eliminated, func(i, j int) bool {
+		// the adjustment of an eliminated player is their stack at the start of the hand
+		return balanceAdjustments[eliminated[i]] > balanceAdjustments[eliminated[j]]
+	})
+
+	t.Eliminated = append(t.Eliminated, eliminated...)
+	return eliminated
+}
+
+// remaining returns the number of entrants who are not eliminated
+func (t *tournament) remaining() int {
+	return len(t.Entrants) - len(t.Eliminated)
+}
+
+// place returns the place of a player who was eliminated
+func (t *tournament) place(playerID int64) int {
+	for i, id := range t.Eliminated {
+		if id == playerID {
+			return len(t.Entrants) - i
+		}
+	}
+
+	return 0
+}
+
+// results returns the final placement of every entrant, starting with first place
+// The winner must be the only entrant who is not eliminated
+func (t *tournament) results() []*tournamentResult {
+	eliminated := make(map[int64]bool, len(t.Eliminated))
+	for _, id := range t.Eliminated {
+		eliminated[id] = true
+	}
+
+	placed := make([]int64, 0, len(t.Entrants))
+	for _, id := range t.Entrants {
+		if !eliminated[id] {
+			placed = append(placed, id)
+		}
+	}
+
+	for i := len(t.Eliminated) - 1; i >= 0; i-- {
+		placed = append(placed, t.Eliminated[i])
+	}
+
+	prizePool := t.BuyIn * len(t.Entrants)
+	paid := 0
+	results := make([]*tournamentResult, len(placed))
+	for i, id := range placed {
+		payout := 0
+		if i < len(t.Payouts) {
+			payout = prizePool * t.Payouts[i] / 100
+			paid += payout
+		}
+
+		results[i] = &tournamentResult{
+			PlayerID: id,
+			Place:    i + 1,
+			Payout:   payout,
+		}
+	}
+
+	// any rounding goes to the winner
+	results[0].Payout += prizePool - paid
+
+	for _, result := range results {
+		result.Adjustment = result.Payout - t.BuyIn
+	}
+
+	return results
+}
+
+// ordinal returns the number with its suffix, i.e., 1st or 22nd
+func ordinal(n int) string {
+	suffix := "th"
+	switch n % 10 {
+	case 1:
+		suffix = "st"
+	case 2:
+		suffix = "nd"
+	case 3:
+		suffix = "rd"
+	}
+
+	if n%100 >= 11 && n%100 <= 13 {
+		suffix = "th"
+	}
+
+	return fmt.Sprintf("%d%s", n, suffix)
+}
+
+// tournamentHandEnded eliminates the players who ran out of chips
+// Returns true if the tournament is over
+func (d *Dealer) tournamentHandEnded(busted []int64, balanceAdjustments map[int64]int) bool {
+	t := d.session.Tournament
+	eliminated := t.eliminate(busted, balanceAdjustments)
+	logs := make([]*playable.LogMessage, 0, len(eliminated))
+	for _, id := range eliminated {
+		logs = append(logs, playable.SimpleLogMessage(id, "{} was eliminated in %s place", ordinal(t.place(id))))
+	}
+
+	if len(logs) > 0 {
+		d.sendLogMessages(logs)
+	}
+
+	if t.remaining() > 1 {
+		return false
+	}
+
+	if err := d.finishTournament(); err != nil {
+		logrus.WithError(err).Error("could not post the results of the tournament")
+	}
+
+	return true
+}
+
+// finishTournament posts the results of the tournament to the players' balances
+func (d *Dealer) finishTournament() error {
+	s := d.session
+	results := s.Tournament.results()
+
+	adjustments := make(map[int64]int, len(results))
+	logs := make([]*playable.LogMessage, 0, len(results))
+	for _, result := range results {
+		adjustments[result.PlayerID] = result.Adjustment
+		if result.Payout > 0 {
+			logs = append(logs, playable.SimpleLogMessage(result.PlayerID, "{} finished in %s place and won ${%d}", ordinal(result.Place), result.Payout))
+		}
+	}
+
+	d.sendLogMessages(logs)
+
+	tablePlayers, err := d.table.GetPlayers(context.Background())
+	if err != nil {
+		return fmt.Errorf("could not get players: %w", err)
+	}
+
+	seats := seatsByPlayerID(tablePlayers)
+	entrants := make([]*model.GameParticipant, len(s.Tournament.Entrants))
+	for i, id := range s.Tournament.Entrants {
+		entrants[i] = &model.GameParticipant{PlayerID: id, Seat: seats[id], StartingStake: s.Tournament.BuyIn}
+	}
+
+	record, err := d.table.StartGame(context.Background(), "Tournament: "+s.Name, entrants)
+	if err != nil {
+		return fmt.Errorf("could not create game: %w", err)
+	}
+
+	if err := record.EndGame(context.Background(), results, adjustments); err != nil {
+		if !errors.Is(err, model.ErrSettlementPending) {
+			return fmt.Errorf("could not save game: %w", err)
+		}
+
+		d.settlementFailed(record, err)
+	}
+
+	s.Tournament.Finished = true
+	d.stateChanged <- stateClientEvent
+	return nil
+}
@@ -0,0 +1,221 @@
+package room
+
+import (
+	"mondaynightpoker-server/pkg/model"
+	"mondaynightpoker-server/pkg/playable"
+	"mondaynightpoker-server/pkg/room/gamefactory"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewTournament(t *testing.T) {
+	a := assert.New(t)
+
+	factory := texasHoldEmSessionFactory(t)
+
+	tourney, err := newTournament(factory, playable.AdditionalData{"tournament": true})
+	a.NoError(err)
+	a.Equal(defaultBuyIn, tourney.BuyIn)
+	a.Equal(defaultStartingStack, tourney.StartingStack)
+	a.Equal(defaultBlindLevels(), tourney.Levels)
+	a.Equal(defaultLevelMinutes, tourney.LevelMinutes)
+	a.Nil(tourney.Payouts)
+
+	tourney, err = newTournament(factory, playable.AdditionalData{
+		"tournament":    true,
+		"buyIn":         float64(1000),
+		"startingStack": float64(5000),
+		"levelHands":    float64(10),
+		"payouts":       []interface{}{float64(60), float64(40)},
+		"levels": []interface{}{
+			map[string]interface{}{"smallBlind": float64(25), "bigBlind": float64(50)},
+			map[string]interface{}{"smallBlind": float64(50), "bigBlind": float64(100), "ante": float64(25)},
+		},
+	})
+	a.NoError(err)
+	a.Equal(1000, tourney.BuyIn)
+	a.Equal(5000, tourney.StartingStack)
+	a.Equal(10, tourney.LevelHands)
+	a.Equal(0, tourney.LevelMinutes)
+	a.Equal([]int{60, 40}, tourney.Payouts)
+	a.Equal([]*blindLevel{
+		{SmallBlind: 25, BigBlind: 50},
+		{SmallBlind: 50, BigBlind: 100, Ante: 25},
+	}, tourney.Levels)
+
+	invalid := []struct {
+		data playable.AdditionalData
+		err  string
+	}{
+		{playable.AdditionalData{"buyIn": float64(10)}, "the buy-in must be in increments of ${25}"},
+		{playable.AdditionalData{"startingStack": float64(0)}, "the starting stack must be in increments of ${25}"},
+		{playable.AdditionalData{"levels": []interface{}{}}, "the tournament must have at least one level"},
+		{playable.AdditionalData{"levels": "25/50"}, "levels must be a list of blinds and antes"},
+		{playable.AdditionalData{"levelHands": float64(10), "levelMinutes": float64(10)}, "the levels must go up by hands or by minutes"},
+		{playable.AdditionalData{"levels": []interface{}{
+			map[string]interface{}{"smallBlind": float64(25), "bigBlind": float64(50)},
+			map[string]interface{}{"smallBlind": float64(500), "bigBlind": float64(1000)},
+		}}, "level 2: small blind must be at most ${100}"},
+		{playable.AdditionalData{"payouts": []interface{}{float64(50), float64(40)}}, "the payouts must add up to 100%"},
+		{playable.AdditionalData{"payouts": []interface{}{float64(100), float64(0)}}, "each payout must be more than 0%"},
+	}
+
+	for _, e := range invalid {
+		_, err := newTournament(factory, e.data)
+		a.EqualError(err, e.err)
+	}
+}
+
+func TestTournament_register(t *testing.T) {
+	a := assert.New(t)
+
+	players := []*model.PlayerTable{
+		{PlayerID: 1, Active: true},
+		{PlayerID: 2, Active: false},
+		{PlayerID: 3, Active: true},
+	}
+
+	tourney := &tournament{StartingStack: 1500}
+	stacks := make(map[int64]int)
+	a.NoError(tourney.register(players, stacks))
+	a.Equal([]int64{1, 3}, tourney.Entrants)
+	a.Equal(map[int64]int{1: 1500, 3: 1500}, stacks)
+	a.Equal([]int{100}, tourney.Payouts)
+
+	tourney = &tournament{StartingStack: 1500, Payouts: []int{50, 30, 20}}
+	a.EqualError(tourney.register(players, stacks), "the tournament pays 3 places, but only 2 players entered")
+
+	tourney = &tournament{StartingStack: 1500}
+	a.EqualError(tourney.register(players[:2], stacks), "the tournament needs at least two players")
+
+	a.Equal([]int{100}, defaultPayouts(4))
+	a.Equal([]int{70, 30}, defaultPayouts(5))
+	a.Equal([]int{50, 30, 20}, defaultPayouts(8))
+}
+
+func TestTournament_updateLevel(t *testing.T) {
+	a := assert.New(t)
+
+	// by hands
+	tourney := &tournament{Levels: defaultBlindLevels(), LevelHands: 5}
+	a.False(tourney.updateLevel(4, time.Now()))
+	a.True(tourney.updateLevel(5, time.Now()))
+	a.Equal(1, tourney.Level)
+	a.False(tourney.updateLevel(9, time.Now()))
+	a.True(tourney.updateLevel(100, time.Now()))
+	a.Equal(len(tourney.Levels)-1, tourney.Level)
+
+	// by minutes
+	started := time.Now()
+	tourney = &tournament{Levels: defaultBlindLevels(), LevelMinutes: 15, Started: started}
+	a.False(tourney.updateLevel(100, started.Add(14*time.Minute)))
+	a.True(tourney.updateLevel(100, started.Add(31*time.Minute)))
+	a.Equal(2, tourney.Level)
+}
+
+func TestTournament_results(t *testing.T) {
+	a := assert.New(t)
+
+	tourney := &tournament{
+		BuyIn:    1000,
+		Entrants: []int64{1, 2, 3, 4, 5},
+		Payouts:  []int{70, 30},
+	}
+
+	// two players are eliminated in the same hand, the player with the bigger stack finishes higher
+	a.Equal([]int64{4, 2}, tourney.eliminate([]int64{2, 4}, map[int64]int{2: -800, 4: -300, 1: 1100}))
+	a.Equal(5, tourney.place(4))
+	a.Equal(4, tourney.place(2))
+	a.Equal(3, tourney.remaining())
+
+	tourney.eliminate([]int64{5}, map[int64]int{5: -2000})
+	tourney.eliminate([]int64{1}, map[int64]int{1: -3000})
+	a.Equal(1, tourney.remaining())
+
+	a.Equal([]*tournamentResult{
+		{PlayerID: 3, Place: 1, Payout: 3500, Adjustment: 2500},
+		{PlayerID: 1, Place: 2, Payout: 1500, Adjustment: 500},
+		{PlayerID: 5, Place: 3, Payout: 0, Adjustment: -1000},
+		{PlayerID: 2, Place: 4, Payout: 0, Adjustment: -1000},
+		{PlayerID: 4, Place: 5, Payout: 0, Adjustment: -1000},
+	}, tourney.results())
+
+	// the rounding goes to the winner
+	tourney = &tournament{
+		BuyIn:      25,
+		Entrants:   []int64{1, 2, 3},
+		Payouts:    []int{50, 30, 20},
+		Eliminated: []int64{3, 2},
+	}
+
+	results := tourney.results()
+	a.Equal(38, results[0].Payout)
+	a.Equal(22, results[1].Payout)
+	a.Equal(15, results[2].Payout)
+}
+
+func TestSession_seat_tournament(t *testing.T) {
+	a := assert.New(t)
+
+	players := []*model.PlayerTable{
+		{PlayerID: 1, Active: true, TableStake: 1000},
+		{PlayerID: 2, Active: true, TableStake: 1000},
+		{PlayerID: 3, Active: true, TableStake: 1000},
+	}
+
+	s := &session{Stacks: make(map[int64]int), Tournament: &tournament{StartingStack: 1500}}
+	a.NoError(s.Tournament.register(players, s.Stacks))
+
+	seated := s.seat(players)
+	a.Equal([]int64{2, 3, 1}, sessionPlayerIDs(seated))
+	a.Equal(1500, seated[0].GetTableStake())
+
+	// an eliminated player is not dealt in, even if they are active at the table
+	s.Hands++
+	a.Equal([]int64{2}, s.settle(map[int64]int{1: 1500, 2: -1500}))
+	seated = s.seat(players)
+	a.Equal([]int64{1, 3}, sessionPlayerIDs(seated))
+	a.Equal(3000, seated[0].GetTableStake())
+}
+
+func TestSession_handOptions_tournament(t *testing.T) {
+	a := assert.New(t)
+
+	msg := &playable.PayloadIn{Subject: "texas-hold-em", AdditionalData: playable.AdditionalData{"tournament": true, "levelHands": float64(2)}}
+	a.True(isSessionRequest(msg))
+
+	s := &session{message: msg, factory: texasHoldEmSessionFactory(t)}
+	tourney, err := newTournament(s.factory, msg.AdditionalData)
+	a.NoError(err)
+	s.Tournament = tourney
+	tourney.Level = 3
+
+	options := s.handOptions()
+	a.Equal(float64(75), options["smallBlind"])
+	a.Equal(float64(150), options["bigBlind"])
+	a.Equal(float64(25), options["ante"])
+	_, ok := msg.AdditionalData["smallBlind"]
+	a.False(ok)
+}
+
+func TestOrdinal(t *testing.T) {
+	a := assert.New(t)
+	a.Equal("1st", ordinal(1))
+	a.Equal("2nd", ordinal(2))
+	a.Equal("3rd", ordinal(3))
+	a.Equal("4th", ordinal(4))
+	a.Equal("11th", ordinal(11))
+	a.Equal("12th", ordinal(12))
+	a.Equal("21st", ordinal(21))
+	a.Equal("113th", ordinal(113))
+}
+
+func texasHoldEmSessionFactory(t *testing.T) gamefactory.Session {
+	t.Helper()
+
+	factory, err := getSessionFactory(&playable.PayloadIn{Subject: "texas-hold-em"})
+	assert.NoError(t, err)
+	return factory
+}
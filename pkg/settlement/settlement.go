@@ -0,0 +1,158 @@
+package settlement
+
+import (
+	"math/bits"
+	"sort"
+)
+
+// Transfer is a payment from a player who owes money to a player who is owed money
+type Transfer struct {
+	From   int64 `json:"from"`
+	To     int64 `json:"to"`
+	Amount int   `json:"amount"`
+}
+
+type balance struct {
+	playerID int64
+	amount   int
+}
+
+// maxExactBalances is the most non-zero balances that are settled with the fewest possible transfers
+// Finding the fewest transfers takes time exponential in the number of balances, so beyond this the
+// balances are settled by settleGreedy, which may take more transfers than needed
+const maxExactBalances = 16
+
+// Settle returns the transfers that bring every balance to zero
+// Settling a group of n players whose balances add up to zero takes n-1 transfers, so the fewest
+// transfers come from splitting the players into as many groups that add up to zero as possible
+// If the balances do not add up to zero, the difference is left unsettled
+func Settle(balances map[int64]int) []*Transfer {
+	nonZero := make([]*balance, 0, len(balances))
+	for id, amount := range balances {
+		if amount != 0 {
+			nonZero = append(nonZero, &balance{playerID: id, amount: amount})
+		}
+	}
+
+	sort.Slice(nonZero, func(i, j int) bool {
+		return nonZero[i].playerID < nonZero[j].playerID
+	})
+
+	if len(nonZero) > maxExactBalances {
+		return settleGreedy(nonZero)
+	}
+
+	transfers := make([]*Transfer, 0)
+	for _, group := range zeroSumGroups(nonZero) {
+		transfers = append(transfers, settleGreedy(group)...)
+	}
+
+	return transfers
+}
+
+// zeroSumGroups splits the balances into the most groups that add up to zero
+// If the balances do not add up to zero, the remainder is returned as its own group
+func zeroSumGroups(balances []*balance) [][]*balance {
+	n := len(balances)
+	full := 1<<n - 1
+
+	// sums[mask] is the sum of the balances in mask
+	sums := make([]int, full+1)
+	for mask := 1; mask <= full; mask++ {
+		i := bits.TrailingZeros(uint(mask))
+		sums[mask] = sums[mask&(mask-1)] + balances[i].amount
+	}
+
+	// groups[mask] is the most groups that add up to zero that can be made from mask
+	// when mask is settled one balance at a time, and removed[mask] is the balance to settle last
+	groups := make([]int, full+1)
+	removed := make([]int, full+1)
+	for mask := 1; mask <= full; mask++ {
+		groups[mask] = -1
+		for i := 0; i < n; i++ {
+			if mask&(1<<i) != 0 && groups[mask^(1<<i)] > groups[mask] {
+				groups[mask] = groups[mask^(1<<i)]
+				removed[mask] = i
+			}
+		}
+
+		if sums[mask] == 0 {
+			groups[mask]++
+		}
+	}
+
+	result := make([][]*balance, 0, groups[full]+1)
+	group := make([]*balance, 0)
+	for mask := full; mask != 0; {
+		i := removed[mask]
+		group = append(group, balances[i])
+		mask ^= 1 << i
+		if sums[mask] == 0 {
+			result = append(result, group)
+			group = make([]*balance, 0)
+		}
+	}
+
+	return result
+}
+
+// settleGreedy settles the balances by having players who owe the exact amount another player is owed
+// pay that player directly, then having the player who owes the most pay the player who is owed the most
+// This never takes more than one fewer transfer than there are balances
+func settleGreedy(balances []*balance) []*Transfer {
+	debtors := make([]*balance, 0)
+	creditors := make([]*balance, 0)
+	for _, b := range balances {
+		if b.amount < 0 {
+			debtors = append(debtors, &balance{playerID: b.playerID, amount: -b.amount})
+		} else if b.amount > 0 {
+			creditors = append(creditors, &balance{playerID: b.playerID, amount: b.amount})
+		}
+	}
+
+	sortBalances(debtors)
+	sortBalances(creditors)
+
+	transfers := make([]*Transfer, 0)
+	for _, debtor := range debtors {
+		for _, creditor := range creditors {
+			if creditor.amount > 0 && creditor.amount == debtor.amount {
+				transfers = append(transfers, &Transfer{From: debtor.playerID, To: creditor.playerID, Amount: debtor.amount})
+				debtor.amount = 0
+				creditor.amount = 0
+				break
+			}
+		}
+	}
+
+	for {
+		sortBalances(debtors)
+		sortBalances(creditors)
+		if len(debtors) == 0 || len(creditors) == 0 || debtors[0].amount == 0 || creditors[0].amount == 0 {
+			break
+		}
+
+		debtor, creditor := debtors[0], creditors[0]
+		amount := debtor.amount
+		if creditor.amount < amount {
+			amount = creditor.amount
+		}
+
+		transfers = append(transfers, &Transfer{From: debtor.playerID, To: creditor.playerID, Amount: amount})
+		debtor.amount -= amount
+		creditor.amount -= amount
+	}
+
+	return transfers
+}
+
+// sortBalances sorts the balances by the largest amount, then by player ID
+func sortBalances(balances []*balance) {
+	sort.Slice(balances, func(i, j int) bool {
+		if balances[i].amount == balances[j].amount {
+			return balances[i].playerID < balances[j].playerID
+		}
+
+		return balances[i].amount > balances[j].amount
+	})
+}
@@ -0,0 +1,102 @@
+package settlement
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestSettle(t *testing.T) {
+	a := assert.New(t)
+
+	a.Empty(Settle(map[int64]int{}))
+	a.Empty(Settle(map[int64]int{1: 0, 2: 0}))
+
+	a.Equal([]*Transfer{
+		{From: 2, To: 1, Amount: 500},
+	}, Settle(map[int64]int{1: 500, 2: -500, 3: 0}))
+
+	// the largest debtor pays the largest creditor
+	a.Equal([]*Transfer{
+		{From: 3, To: 1, Amount: 700},
+		{From: 4, To: 2, Amount: 300},
+		{From: 3, To: 2, Amount: 100},
+	}, Settle(map[int64]int{1: 700, 2: 400, 3: -800, 4: -300}))
+
+	// exact matches are paid directly
+	a.Equal([]*Transfer{
+		{From: 4, To: 1, Amount: 750},
+		{From: 3, To: 2, Amount: 250},
+	}, Settle(map[int64]int{1: 750, 2: 250, 3: -250, 4: -750}))
+
+	a.Equal([]*Transfer{
+		{From: 4, To: 1, Amount: 600},
+		{From: 5, To: 2, Amount: 300},
+		{From: 4, To: 3, Amount: 100},
+		{From: 5, To: 3, Amount: 100},
+	}, Settle(map[int64]int{1: 600, 2: 300, 3: 200, 4: -700, 5: -400}))
+}
+
+func TestSettle_fewestTransfers(t *testing.T) {
+	a := assert.New(t)
+
+	// the largest debtor paying the largest creditor would take five transfers
+	balances := map[int64]int{1: 600, 2: 400, 3: 400, 4: 100, 5: -800, 6: -700}
+	transfers := Settle(balances)
+	a.Equal(4, len(transfers))
+	assertSettled(t, balances, transfers)
+
+	for _, transfer := range transfers {
+		if transfer.From == 5 {
+			a.Contains([]int64{2, 3}, transfer.To)
+		} else {
+			a.Contains([]int64{1, 4}, transfer.To)
+		}
+	}
+}
+
+func TestSettle_zeroesBalances(t *testing.T) {
+	a := assert.New(t)
+
+	balances := map[int64]int{1: 1275, 2: -350, 3: -25, 4: 600, 5: -1100, 6: -400}
+	transfers := Settle(balances)
+	a.LessOrEqual(len(transfers), len(balances)-1)
+	assertSettled(t, balances, transfers)
+
+	// too many balances to find the fewest transfers
+	balances = make(map[int64]int)
+	for id := int64(1); id <= maxExactBalances+1; id++ {
+		balances[id] = int(id) * 25
+		balances[-id] = -int(id) * 25
+	}
+
+	transfers = Settle(balances)
+	a.Equal(maxExactBalances+1, len(transfers))
+	assertSettled(t, balances, transfers)
+}
+
+func assertSettled(t *testing.T, balances map[int64]int, transfers []*Transfer) {
+	t.Helper()
+
+	remaining := make(map[int64]int, len(balances))
+	for id, amount := range balances {
+		remaining[id] = amount
+	}
+
+	for _, transfer := range transfers {
+		assert.Greater(t, transfer.Amount, 0)
+		remaining[transfer.From] += transfer.Amount
+		remaining[transfer.To] -= transfer.Amount
+	}
+
+	for id, amount := range remaining {
+		assert.Equal(t, 0, amount, "player %d", id)
+	}
+}
+
+func TestSettle_unbalanced(t *testing.T) {
+	a := assert.New(t)
+
+	a.Equal([]*Transfer{
+		{From: 2, To: 1, Amount: 300},
+	}, Settle(map[int64]int{1: 500, 2: -300}))
+}
@@ -0,0 +1,71 @@
+package stats
+
+import (
+	"encoding/json"
+	"errors"
+	"mondaynightpoker-server/pkg/model"
+	"mondaynightpoker-server/pkg/playable/poker/texasholdem"
+)
+
+// FromGame returns the statistics of each player in a game by player ID
+// log is the log that is stored in games.data when the game ends, and balanceAdjustments
+// are the amounts each player won or lost
+// Showdowns and the Hold'em statistics are only recorded for games with a hand history
+func FromGame(log interface{}, balanceAdjustments map[int64]int) (map[int64]*model.PlayerStats, error) {
+	stats := make(map[int64]*model.PlayerStats, len(balanceAdjustments))
+	for playerID, adjustment := range balanceAdjustments {
+		s := &model.PlayerStats{
+			GamesPlayed: 1,
+			Net:         adjustment,
+		}
+
+		if adjustment > 0 {
+			s.BiggestPot = adjustment
+		}
+
+		stats[playerID] = s
+	}
+
+	data, err := json.Marshal(log)
+	if err != nil {
+		return nil, err
+	}
+
+	hands, err := texasholdem.HandStatsFromLog(data)
+	if err != nil {
+		if errors.Is(err, texasholdem.ErrNoHandHistory) {
+			return stats, nil
+		}
+
+		return nil, err
+	}
+
+	for playerID, hand := range hands {
+		s, ok := stats[playerID]
+		if !ok {
+			continue
+		}
+
+		if hand.Winnings > s.BiggestPot {
+			s.BiggestPot = hand.Winnings
+		}
+
+		s.Hands = 1
+		s.Showdowns = boolToInt(hand.Showdown)
+		s.ShowdownsWon = boolToInt(hand.WonShowdown)
+		s.VPIPHands = boolToInt(hand.VPIP)
+		s.PFRHands = boolToInt(hand.PFR)
+		s.BetsRaises = hand.BetsRaises
+		s.Calls = hand.Calls
+	}
+
+	return stats, nil
+}
+
+func boolToInt(b bool) int {
+	if b {
+		return 1
+	}
+
+	return 0
+}
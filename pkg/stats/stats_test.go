@@ -0,0 +1,52 @@
+package stats
+
+import (
+	"encoding/json"
+	"mondaynightpoker-server/pkg/model"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFromGame(t *testing.T) {
+	a := assert.New(t)
+
+	stats, err := FromGame(map[string]interface{}{"pot": 50}, map[int64]int{1: 50, 2: -25, 3: -25})
+	a.NoError(err)
+	a.Equal(map[int64]*model.PlayerStats{
+		1: {GamesPlayed: 1, Net: 50, BiggestPot: 50},
+		2: {GamesPlayed: 1, Net: -25},
+		3: {GamesPlayed: 1, Net: -25},
+	}, stats)
+}
+
+func TestFromGame_handHistory(t *testing.T) {
+	a := assert.New(t)
+
+	log := json.RawMessage(`{
+  "participants": [
+    {"playerId": 1, "folded": true, "result": "folded"},
+    {"playerId": 2, "result": "lost"},
+    {"playerId": 3, "result": "won", "winnings": 500}
+  ],
+  "history": [
+    {"type": "small-blind", "playerId": 1, "amount": 25},
+    {"type": "big-blind", "playerId": 2, "amount": 50},
+    {"type": "action", "playerId": 3, "action": {"id": "raise"}, "amount": 100},
+    {"type": "action", "playerId": 1, "action": {"id": "fold"}},
+    {"type": "action", "playerId": 2, "action": {"id": "call"}, "amount": 50},
+    {"type": "flop"},
+    {"type": "action", "playerId": 2, "action": {"id": "check"}},
+    {"type": "action", "playerId": 3, "action": {"id": "bet"}, "amount": 100},
+    {"type": "action", "playerId": 2, "action": {"id": "call"}, "amount": 100}
+  ]
+}`)
+
+	stats, err := FromGame(log, map[int64]int{1: -25, 2: -200, 3: 225})
+	a.NoError(err)
+	a.Equal(map[int64]*model.PlayerStats{
+		1: {GamesPlayed: 1, Net: -25, Hands: 1},
+		2: {GamesPlayed: 1, Net: -200, Hands: 1, Showdowns: 1, VPIPHands: 1, Calls: 2},
+		3: {GamesPlayed: 1, Net: 225, BiggestPot: 500, Hands: 1, Showdowns: 1, ShowdownsWon: 1, VPIPHands: 1, PFRHands: 1, BetsRaises: 2},
+	}, stats)
+}
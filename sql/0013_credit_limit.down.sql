@@ -0,0 +1,4 @@
+BEGIN;
+ALTER TABLE players_tables DROP COLUMN credit_limit;
+ALTER TABLE tables DROP COLUMN credit_limit;
+COMMIT;
\ No newline at end of file
@@ -0,0 +1,4 @@
+BEGIN;
+ALTER TABLE tables ADD COLUMN credit_limit INT;
+ALTER TABLE players_tables ADD COLUMN credit_limit INT;
+COMMIT;
\ No newline at end of file
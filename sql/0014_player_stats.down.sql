@@ -0,0 +1,3 @@
+BEGIN;
+DROP TABLE player_stats;
+COMMIT;
\ No newline at end of file
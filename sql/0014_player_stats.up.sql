@@ -0,0 +1,22 @@
+BEGIN;
+CREATE TABLE player_stats
+(
+    player_id     bigint    NOT NULL REFERENCES players (id),
+    table_uuid    uuid      NOT NULL REFERENCES tables (uuid),
+    game_type     text      NOT NULL,
+    games_played  int       NOT NULL DEFAULT 0,
+    net           int       NOT NULL DEFAULT 0,
+    biggest_pot   int       NOT NULL DEFAULT 0,
+    showdowns     int       NOT NULL DEFAULT 0,
+    showdowns_won int       NOT NULL DEFAULT 0,
+    hands         int       NOT NULL DEFAULT 0,
+    vpip          int       NOT NULL DEFAULT 0,
+    pfr           int       NOT NULL DEFAULT 0,
+    bets_raises   int       NOT NULL DEFAULT 0,
+    calls         int       NOT NULL DEFAULT 0,
+    updated       timestamp NOT NULL DEFAULT (NOW() AT TIME ZONE 'utc'),
+    PRIMARY KEY (player_id, table_uuid, game_type)
+);
+
+CREATE INDEX player_stats_table_uuid_idx ON player_stats (table_uuid);
+COMMIT;
\ No newline at end of file
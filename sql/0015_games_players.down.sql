@@ -0,0 +1,4 @@
+BEGIN;
+DROP TABLE games_players;
+ALTER TABLE games DROP COLUMN abandoned;
+COMMIT;
\ No newline at end of file
@@ -0,0 +1,33 @@
+BEGIN;
+ALTER TABLE games ADD COLUMN abandoned boolean NOT NULL DEFAULT FALSE;
+
+CREATE TABLE games_players
+(
+    game_id        bigint NOT NULL REFERENCES games (id),
+    player_id      bigint NOT NULL REFERENCES players (id),
+    seat           int    NOT NULL,
+    starting_stake int,
+    adjustment     int,
+    result         text,
+    PRIMARY KEY (game_id, player_id)
+);
+
+CREATE INDEX games_players_player_id_idx ON games_players (player_id);
+
+-- games recorded before this table only know their participants from the ledger, so their seats are numbered
+-- from 1 in the order the players' balances were adjusted
+INSERT INTO games_players (game_id, player_id, seat, adjustment, result)
+SELECT players_tables_transactions.game_id,
+       players_tables.player_id,
+       ROW_NUMBER() OVER (PARTITION BY players_tables_transactions.game_id ORDER BY players_tables_transactions.id),
+       players_tables_transactions.adjustment,
+       CASE
+           WHEN players_tables_transactions.adjustment > 0 THEN 'won'
+           WHEN players_tables_transactions.adjustment < 0 THEN 'lost'
+           ELSE 'even'
+           END
+FROM players_tables_transactions
+INNER JOIN players_tables ON players_tables_transactions.players_tables_id = players_tables.id
+WHERE players_tables_transactions.game_id IS NOT NULL
+ON CONFLICT DO NOTHING;
+COMMIT;
\ No newline at end of file
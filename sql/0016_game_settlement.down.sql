@@ -0,0 +1,7 @@
+BEGIN;
+DROP INDEX games_unsettled_idx;
+ALTER TABLE games DROP COLUMN settlement_error;
+ALTER TABLE games DROP COLUMN settlement_attempts;
+ALTER TABLE games DROP COLUMN settled;
+ALTER TABLE games DROP COLUMN balance_adjustments;
+COMMIT;
\ No newline at end of file
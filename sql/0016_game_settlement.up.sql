@@ -0,0 +1,11 @@
+BEGIN;
+ALTER TABLE games ADD COLUMN balance_adjustments jsonb;
+ALTER TABLE games ADD COLUMN settled timestamp;
+ALTER TABLE games ADD COLUMN settlement_attempts int NOT NULL DEFAULT 0;
+ALTER TABLE games ADD COLUMN settlement_error text;
+
+-- the balances of the games that have already ended were adjusted when they ended
+UPDATE games SET settled = ended WHERE ended IS NOT NULL;
+
+CREATE INDEX games_unsettled_idx ON games (table_uuid) WHERE settled IS NULL AND balance_adjustments IS NOT NULL;
+COMMIT;
\ No newline at end of file
@@ -0,0 +1,5 @@
+BEGIN;
+ALTER TABLE tables DROP COLUMN button_seat;
+DROP INDEX players_tables_seat_idx;
+ALTER TABLE players_tables DROP COLUMN seat;
+COMMIT;
\ No newline at end of file
@@ -0,0 +1,21 @@
+BEGIN;
+ALTER TABLE players_tables ADD COLUMN seat int CHECK (seat BETWEEN 1 AND 10);
+CREATE UNIQUE INDEX players_tables_seat_idx ON players_tables (table_uuid, seat);
+
+-- seat up to ten players at each table, preferring the active players, in the order they joined
+WITH chosen AS (
+    SELECT id, table_uuid, ROW_NUMBER() OVER (PARTITION BY table_uuid ORDER BY active DESC, id) AS n
+    FROM players_tables
+),
+     seats AS (
+         SELECT id, ROW_NUMBER() OVER (PARTITION BY table_uuid ORDER BY id) AS seat
+         FROM chosen
+         WHERE n <= 10
+     )
+UPDATE players_tables
+SET seat = seats.seat
+FROM seats
+WHERE players_tables.id = seats.id;
+
+ALTER TABLE tables ADD COLUMN button_seat int CHECK (button_seat BETWEEN 1 AND 10);
+COMMIT;
\ No newline at end of file